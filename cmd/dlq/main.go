@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-payment-aggregator/internal/config"
+	queue "go-payment-aggregator/internal/repository/redis"
+	"os"
+	"strconv"
+)
+
+const usage = `Usage: dlq <command> [limit]
+
+Commands:
+  list [limit]     Print dead-lettered webhooks, oldest first (default 20)
+  replay [limit]   Move the oldest dead-lettered webhooks back to the webhook stream (default 100)`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	viperConfig := config.NewViper()
+	logger := config.NewLogger(viperConfig)
+	rdb := config.NewRedis(viperConfig, logger)
+	retryQueue := queue.NewWebhookRetryQueue(rdb)
+
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "list":
+		payloads, err := retryQueue.ListDeadLetters(ctx, 0, limitArg(20))
+		if err != nil {
+			logger.Fatalf("Failed to list dead letters: %v", err)
+		}
+
+		for _, payload := range payloads {
+			line, _ := json.Marshal(payload)
+			fmt.Println(string(line))
+		}
+	case "replay":
+		replayed, err := retryQueue.ReplayDeadLetters(ctx, limitArg(100))
+		if err != nil {
+			logger.Fatalf("Failed to replay dead letters: %v", err)
+		}
+
+		fmt.Printf("Replayed %d dead-lettered webhooks\n", replayed)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func limitArg(fallback int) int {
+	if len(os.Args) < 3 {
+		return fallback
+	}
+
+	limit, err := strconv.Atoi(os.Args[2])
+	if err != nil || limit <= 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	return limit
+}
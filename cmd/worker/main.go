@@ -1,16 +1,16 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"go-payment-aggregator/internal/config"
+	"go-payment-aggregator/internal/repository/postgres"
+	queue "go-payment-aggregator/internal/repository/redis"
+	"go-payment-aggregator/internal/usecase"
 	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
-
-	"github.com/redis/go-redis/v9"
-	"github.com/sirupsen/logrus"
 )
 
 func main() {
@@ -18,101 +18,69 @@ func main() {
 	logger := config.NewLogger(viperConfig)
 
 	rdb := config.NewRedis(viperConfig, logger)
-
-	fmt.Println("Worker started. Listening for webhooks on 'webhook_queue'...")
-
-	ctx := context.Background()
-
-	for {
-		result, err := rdb.BLPop(ctx, 0*time.Second, "webhook_queue").Result()
-		if err != nil {
-			logger.Errorf("Redis connection error: %v", err)
-			time.Sleep(5 * time.Second)
-			continue
-		}
-
-		if len(result) < 2 {
-			continue
-		}
-
-		payloadStr := result[1]
-		go processWebhook(payloadStr, logger, rdb)
+	db := config.NewDatabase(viperConfig, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	transactionRepository := postgres.NewTransactionRepository(db)
+	merchantRepository := postgres.NewMerchantRepository(db)
+	gateways := config.NewGateways(viperConfig)
+	timeout := time.Second * time.Duration(viperConfig.GetInt64("CONTEXT_TIMEOUT"))
+
+	transactionUsecase := usecase.NewTransactionUC(
+		transactionRepository,
+		gateways,
+		timeout,
+	)
+	reconciliationUsecase := usecase.NewReconciliationUC(
+		transactionRepository,
+		transactionUsecase,
+		gateways,
+		config.NewReconciliationConfig(viperConfig),
+		timeout,
+	)
+
+	outboxRelayUsecase := usecase.NewOutboxRelayUC(
+		postgres.NewOutboxRepository(db),
+		queue.NewWebhookPublisher(rdb),
+		timeout,
+	)
+
+	worker := &webhookWorker{
+		merchantRepo: merchantRepository,
+		endpointRepo: postgres.NewWebhookEndpointRepository(db),
+		deliveryRepo: postgres.NewWebhookDeliveryRepository(db),
+		publisher:    queue.NewWebhookPublisher(rdb),
+		consumer:     queue.NewWebhookConsumer(rdb, consumerName(viperConfig)),
+		retryQueue:   queue.NewWebhookRetryQueue(rdb),
+		hosts:        newHostLimiter(hostConcurrency(viperConfig)),
+		policy:       newRetryPolicy(viperConfig),
+		client:       &http.Client{Timeout: 10 * time.Second},
+		logger:       logger,
 	}
-}
 
-type WebhookPayload struct {
-	TransactionID string  `json:"transaction_id"`
-	OrderID       string  `json:"order_id"`
-	Status        string  `json:"status"`
-	Amount        float64 `json:"amount"`
-	Provider      string  `json:"provider"`
-	CallbackURL   string  `json:"callback_url"`
-	RetryCount    int     `json:"retry_count"`
-}
+	go runRetryScheduler(ctx, worker.retryQueue, viperConfig, logger)
+	go runOutboxRelay(ctx, outboxRelayUsecase, viperConfig, logger)
+	go runExpirySweeper(ctx, transactionUsecase, viperConfig, logger)
+	go runReconciler(ctx, reconciliationUsecase, viperConfig, logger)
 
-func processWebhook(raw string, logger *logrus.Logger, rdb *redis.Client) {
-	var payload WebhookPayload
-	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
-		logger.Errorf("[ERROR] Invalid JSON payload: %v | Payload: %s", err, raw)
-		return
+	if err := worker.consumer.CreateGroup(ctx); err != nil {
+		logger.Fatalf("failed to create webhook consumer group: %v", err)
 	}
 
-	if payload.CallbackURL == "" {
-		logger.Errorf("[SKIP] No callback_url for Order: %s", payload.OrderID)
-		return
-	}
-
-	logger.Infof("[PROCESSING] Sending webhook for Order %s to %s", payload.OrderID, payload.CallbackURL)
-
-	merchantBody := map[string]any{
-		"transaction_id": payload.TransactionID,
-		"order_id":       payload.OrderID,
-		"status":         payload.Status,
-		"amount":         payload.Amount,
-		"provider":       payload.Provider,
-		"timestamp":      time.Now().Unix(),
-	}
-
-	jsonBody, _ := json.Marshal(merchantBody)
-
-	client := http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Post(payload.CallbackURL, "application/json", bytes.NewBuffer(jsonBody))
+	// jobs queued by a release that used the list would otherwise never be delivered
+	moved, err := worker.consumer.MoveLegacyQueue(ctx)
 	if err != nil {
-		logger.Errorf("[FAILED] Order %s: %v", payload.OrderID, err)
-		retry(payload, logger, rdb)
-		return
+		logger.Fatalf("failed to move legacy webhook queue: %v", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		logger.Infof("[SUCCESS] Order %s: Merchant responded %d", payload.OrderID, resp.StatusCode)
-	} else {
-		logger.Errorf("[FAILED] Order %s: Merchant responded %d", payload.OrderID, resp.StatusCode)
-		retry(payload, logger, rdb)
+	if moved > 0 {
+		logger.Infof("[MIGRATE] Moved %d webhook jobs from 'webhook_queue' to 'webhook_stream'", moved)
 	}
-}
-
-func retry(payload WebhookPayload, logger *logrus.Logger, rdb *redis.Client) {
-	maxRetry := 5
-	if payload.RetryCount >= maxRetry {
-		logger.Errorf("[GIVE UP] Max retry reached for Order %s", payload.OrderID)
-		return
-	}
-
-	payload.RetryCount++
-	newBody, _ := json.Marshal(payload)
 
-	waitTime := time.Duration(payload.RetryCount*5) * time.Second
+	fmt.Println("Worker started. Listening for webhooks on 'webhook_stream'...")
 
-	logger.Warnf("[RETRY] Rescheduling Order %s in %v (Attempt %d/%d)", payload.OrderID, waitTime, payload.RetryCount, maxRetry)
+	runWebhookConsumer(ctx, worker.consumer, worker, viperConfig, logger)
 
-	go func() {
-		time.Sleep(waitTime)
-		if err := rdb.RPush(context.Background(), "webhook_queue", newBody).Err(); err != nil {
-			logger.Errorf("Failed to re-queue task: %v", err)
-		}
-	}()
+	fmt.Println("Worker stopped.")
 }
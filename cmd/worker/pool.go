@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// hostLimiter caps concurrent deliveries per callback host, so one slow merchant endpoint
+// cannot take up every slot of the pool
+type hostLimiter struct {
+	limit    int
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{
+		limit:    limit,
+		inFlight: make(map[string]int),
+	}
+}
+
+func (l *hostLimiter) tryAcquire(host string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[host] >= l.limit {
+		return false
+	}
+	l.inFlight[host]++
+	return true
+}
+
+func (l *hostLimiter) release(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight[host]--
+	if l.inFlight[host] <= 0 {
+		delete(l.inFlight, host)
+	}
+}
+
+// runWebhookConsumer feeds the webhook stream to a bounded pool of deliveries until ctx is done,
+// then waits for in-flight deliveries. Deliveries still running when the shutdown timeout runs
+// out are aborted and left unacknowledged, so another worker claims them.
+func runWebhookConsumer(ctx context.Context, consumer domain.WebhookConsumer, worker *webhookWorker, config *viper.Viper, logger *logrus.Logger) {
+	concurrency := config.GetInt("WEBHOOK_WORKER_CONCURRENCY")
+	if concurrency <= 0 {
+		concurrency = 20
+	}
+
+	shutdownTimeout := time.Duration(config.GetInt("WORKER_SHUTDOWN_TIMEOUT")) * time.Second
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = 30 * time.Second
+	}
+
+	claimInterval := time.Duration(config.GetInt("WEBHOOK_CLAIM_INTERVAL")) * time.Second
+	if claimInterval <= 0 {
+		claimInterval = 30 * time.Second
+	}
+
+	// must stay above the longest a delivery can take, or jobs still in flight get claimed twice
+	claimMinIdle := time.Duration(config.GetInt("WEBHOOK_CLAIM_MIN_IDLE")) * time.Second
+	if claimMinIdle <= 0 {
+		claimMinIdle = time.Minute
+	}
+
+	// deliveries get their own context, a signal stops the intake but lets them finish
+	deliveryCtx, cancelDeliveries := context.WithCancel(context.Background())
+	defer cancelDeliveries()
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	var claimed []*domain.WebhookMessage
+	lastClaim := time.Time{}
+
+consume:
+	for {
+		// a job is only taken once there is a slot to run it in
+		select {
+		case <-ctx.Done():
+			break consume
+		case slots <- struct{}{}:
+		}
+
+		if len(claimed) == 0 && time.Since(lastClaim) >= claimInterval {
+			lastClaim = time.Now()
+
+			stale, err := consumer.ClaimStale(ctx, claimMinIdle, concurrency)
+			if err != nil {
+				logger.Errorf("[CLAIM] Failed to claim stale webhook jobs: %v", err)
+			}
+			if len(stale) > 0 {
+				logger.Infof("[CLAIM] Claimed %d stale webhook jobs", len(stale))
+			}
+			claimed = stale
+		}
+
+		var message *domain.WebhookMessage
+		if len(claimed) > 0 {
+			message, claimed = claimed[0], claimed[1:]
+		} else {
+			// a job read just as ctx is cancelled stays pending and is claimed later, nothing is lost
+			var err error
+			message, err = consumer.Read(ctx, time.Second)
+			if err != nil && ctx.Err() == nil {
+				logger.Errorf("Redis connection error: %v", err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(5 * time.Second):
+				}
+			}
+		}
+
+		if message == nil {
+			<-slots
+			continue
+		}
+
+		wg.Add(1)
+		go func(message *domain.WebhookMessage) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			worker.process(deliveryCtx, message)
+		}(message)
+	}
+
+	logger.Infof("[SHUTDOWN] Waiting up to %v for in-flight deliveries", shutdownTimeout)
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(shutdownTimeout):
+		logger.Warn("[SHUTDOWN] Timed out, leaving unfinished deliveries to be claimed by another worker")
+		cancelDeliveries()
+		<-drained
+	}
+}
+
+func hostConcurrency(config *viper.Viper) int {
+	limit := config.GetInt("WEBHOOK_HOST_CONCURRENCY")
+	if limit <= 0 {
+		limit = 5
+	}
+	return limit
+}
+
+// consumerName identifies this replica within the consumer group
+func consumerName(config *viper.Viper) string {
+	if name := config.GetString("WEBHOOK_CONSUMER_NAME"); name != "" {
+		return name
+	}
+
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostLimiter(t *testing.T) {
+	limiter := newHostLimiter(2)
+
+	assert.True(t, limiter.tryAcquire("merchant.test"))
+	assert.True(t, limiter.tryAcquire("merchant.test"))
+	assert.False(t, limiter.tryAcquire("merchant.test"), "a host at its limit gets no slot")
+	assert.True(t, limiter.tryAcquire("other.test"), "other hosts are not held back")
+
+	limiter.release("merchant.test")
+	assert.True(t, limiter.tryAcquire("merchant.test"), "a released slot can be taken again")
+	assert.False(t, limiter.tryAcquire("merchant.test"))
+
+	limiter.release("merchant.test")
+	limiter.release("merchant.test")
+	limiter.release("other.test")
+	assert.Empty(t, limiter.inFlight, "idle hosts are forgotten")
+}
+
+func TestRunWebhookConsumer_Shutdown(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+
+	tests := []struct {
+		name string
+		// finish tells whether the merchant endpoint answers once shutdown starts, or hangs
+		finish  bool
+		wantAck bool
+	}{
+		{
+			name:    "In-Flight Delivery Finishes",
+			finish:  true,
+			wantAck: true,
+		},
+		{
+			name:    "Drain Timeout Leaves Delivery Unacknowledged",
+			finish:  false,
+			wantAck: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, stop := context.WithCancel(context.Background())
+			defer stop()
+
+			received := make(chan struct{})
+			shutdown := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// the server only notices the client hanging up once the body is read
+				io.Copy(io.Discard, r.Body)
+				close(received)
+				<-shutdown
+				if !tt.finish {
+					// hang until the worker aborts the delivery
+					<-r.Context().Done()
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			body, err := json.Marshal(&domain.WebhookPayload{
+				MerchantID:  merchantID.String(),
+				OrderID:     "ORDER-TEST-123",
+				CallbackURL: server.URL,
+			})
+			require.NoError(t, err)
+			message := &domain.WebhookMessage{ID: "1-0", Body: string(body)}
+
+			mockConsumer := new(mocks.MockWebhookConsumer)
+			mockConsumer.On("ClaimStale", mock.Anything, time.Minute, 20).Return(nil, nil)
+			mockConsumer.On("Read", mock.Anything, time.Second).Return(message, nil).Once()
+			mockConsumer.On("Read", mock.Anything, time.Second).Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).Return(nil, nil)
+
+			mockMerchantRepo := new(mocks.MockMerchantRepository)
+			mockMerchantRepo.On("FindByID", mock.Anything, merchantID).
+				Return(&domain.Merchant{ID: merchantID, WebhookSecret: "whsec_test"}, nil)
+
+			mockDeliveryRepo := new(mocks.MockWebhookDeliveryRepository)
+			if tt.wantAck {
+				mockDeliveryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.WebhookDelivery")).Return(nil)
+				mockConsumer.On("Ack", mock.Anything, "1-0").Return(nil)
+			}
+
+			worker := &webhookWorker{
+				merchantRepo: mockMerchantRepo,
+				deliveryRepo: mockDeliveryRepo,
+				consumer:     mockConsumer,
+				hosts:        newHostLimiter(5),
+				policy:       retryPolicy{MaxAttempts: 6, MinBackoff: time.Minute, MaxBackoff: time.Hour},
+				client:       &http.Client{Timeout: 10 * time.Second},
+				logger:       newTestLogger(),
+			}
+
+			config := viper.New()
+			config.Set("WORKER_SHUTDOWN_TIMEOUT", 1)
+
+			done := make(chan struct{})
+			go func() {
+				runWebhookConsumer(ctx, mockConsumer, worker, config, newTestLogger())
+				close(done)
+			}()
+
+			<-received
+			stop()
+			close(shutdown)
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("consumer did not stop after the shutdown timeout")
+			}
+
+			mockConsumer.AssertExpectations(t)
+			mockMerchantRepo.AssertExpectations(t)
+			mockDeliveryRepo.AssertExpectations(t)
+			if !tt.wantAck {
+				mockConsumer.AssertNotCalled(t, "Ack", mock.Anything, mock.Anything)
+				mockDeliveryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
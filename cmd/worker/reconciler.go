@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// runReconciler periodically polls providers for transactions whose webhook may have been lost
+func runReconciler(ctx context.Context, reconciliationUC domain.ReconciliationUC, config *viper.Viper, logger *logrus.Logger) {
+	interval := time.Duration(config.GetInt("RECONCILE_INTERVAL")) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			corrections, err := reconciliationUC.ReconcilePending(ctx)
+			for _, c := range corrections {
+				logger.Warnf("[RECONCILE] Order %s (%s) corrected from %s to %s", c.OrderID, c.Provider, c.From, c.To)
+			}
+			if err != nil {
+				logger.Errorf("[RECONCILE] Failed to reconcile some transactions: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// runOutboxRelay periodically moves committed outbox events onto the webhook queue.
+// Running it on several workers is safe because each batch is locked with SKIP LOCKED.
+func runOutboxRelay(ctx context.Context, outboxRelayUC domain.OutboxRelayUC, config *viper.Viper, logger *logrus.Logger) {
+	interval := time.Duration(config.GetInt("OUTBOX_RELAY_INTERVAL")) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	batchSize := config.GetInt("OUTBOX_RELAY_BATCH_SIZE")
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			relayed, err := outboxRelayUC.Relay(ctx, batchSize)
+			if err != nil {
+				logger.Errorf("[OUTBOX] Failed to relay some events: %v", err)
+			}
+			if relayed > 0 {
+				logger.Infof("[OUTBOX] Relayed %d events to the webhook queue", relayed)
+			}
+		}
+	}
+}
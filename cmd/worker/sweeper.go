@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// runExpirySweeper periodically resolves PENDING transactions the provider never notified us about.
+// Running it on several workers is safe because every status change is a conditional update.
+func runExpirySweeper(ctx context.Context, transactionUC domain.TransactionUC, config *viper.Viper, logger *logrus.Logger) {
+	interval := time.Duration(config.GetInt("EXPIRY_SWEEP_INTERVAL")) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	batchSize := config.GetInt("EXPIRY_SWEEP_BATCH_SIZE")
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			resolved, err := transactionUC.ExpirePending(ctx, batchSize)
+			if err != nil {
+				logger.Errorf("[SWEEPER] Failed to resolve some expired transactions: %v", err)
+			}
+			if resolved > 0 {
+				logger.Infof("[SWEEPER] Resolved %d expired transactions", resolved)
+			}
+		}
+	}
+}
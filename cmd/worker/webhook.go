@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/pkg/webhook"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// retryPolicy decides when a failed delivery is attempted again and when it is given up
+type retryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+func newRetryPolicy(config *viper.Viper) retryPolicy {
+	policy := retryPolicy{
+		MaxAttempts: config.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+		MinBackoff:  time.Duration(config.GetInt("WEBHOOK_RETRY_MIN_BACKOFF")) * time.Second,
+		MaxBackoff:  time.Duration(config.GetInt("WEBHOOK_RETRY_MAX_BACKOFF")) * time.Second,
+	}
+
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 6
+	}
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = 5 * time.Second
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		policy.MaxBackoff = time.Hour
+	}
+
+	return policy
+}
+
+// hostBusyDelay is how long a job waits when its callback host is already at its concurrency cap
+const hostBusyDelay = time.Second
+
+type webhookWorker struct {
+	merchantRepo domain.MerchantRepository
+	endpointRepo domain.WebhookEndpointRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+	publisher    domain.WebhookPublisher
+	consumer     domain.WebhookConsumer
+	retryQueue   domain.WebhookRetryQueue
+	hosts        *hostLimiter
+	policy       retryPolicy
+	client       *http.Client
+	logger       *logrus.Logger
+}
+
+// process delivers a single job and acknowledges it once it is settled: delivered, handed to the
+// retry queue or dropped as invalid. A job left unacknowledged, because ctx was cancelled or
+// Redis failed, is claimed again after WEBHOOK_CLAIM_MIN_IDLE.
+func (w *webhookWorker) process(ctx context.Context, message *domain.WebhookMessage) {
+	if !w.handle(ctx, message.Body) {
+		return
+	}
+
+	if err := w.consumer.Ack(context.Background(), message.ID); err != nil {
+		w.logger.Errorf("Failed to ack webhook job %s: %v", message.ID, err)
+	}
+}
+
+// handle reports whether the job is settled and can be acknowledged
+func (w *webhookWorker) handle(ctx context.Context, raw string) bool {
+	var payload domain.WebhookPayload
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		w.logger.Errorf("[ERROR] Invalid JSON payload: %v | Payload: %s", err, raw)
+		return true
+	}
+
+	merchantID, err := uuid.Parse(payload.MerchantID)
+	if err != nil {
+		w.logger.Errorf("[SKIP] Invalid merchant_id for Order %s: %v", payload.OrderID, err)
+		return true
+	}
+
+	if payload.CallbackURL == "" {
+		if payload.Event == "" {
+			w.logger.Errorf("[SKIP] No callback_url for Order: %s", payload.OrderID)
+			return true
+		}
+		return w.fanOut(ctx, merchantID, payload) == nil
+	}
+
+	if payload.EndpointID != "" {
+		active, err := w.endpointActive(ctx, merchantID, payload.EndpointID)
+		if err != nil {
+			w.logger.Errorf("Failed to load webhook endpoint %s: %v", payload.EndpointID, err)
+			return false
+		}
+		if !active {
+			w.logger.Infof("[SKIP] Endpoint %s was disabled or deleted, dropping Order %s", payload.EndpointID, payload.OrderID)
+			return true
+		}
+	}
+
+	callbackURL, err := url.Parse(payload.CallbackURL)
+	if err != nil {
+		w.logger.Errorf("[SKIP] Invalid callback_url for Order %s: %v", payload.OrderID, err)
+		return true
+	}
+
+	// a busy host does not cost the job an attempt, it is only pushed back a little
+	if !w.hosts.tryAcquire(callbackURL.Host) {
+		if err := w.retryQueue.Schedule(context.Background(), &payload, time.Now().Add(hostBusyDelay)); err != nil {
+			w.logger.Errorf("Failed to reschedule Order %s for busy host %s: %v", payload.OrderID, callbackURL.Host, err)
+			return false
+		}
+		return true
+	}
+	defer w.hosts.release(callbackURL.Host)
+
+	w.logger.Infof("[PROCESSING] Sending webhook for Order %s to %s", payload.OrderID, payload.CallbackURL)
+
+	delivery := w.deliver(ctx, merchantID, &payload)
+	if ctx.Err() != nil {
+		w.logger.Warnf("[INTERRUPTED] Delivery for Order %s aborted by shutdown, leaving it pending", payload.OrderID)
+		return false
+	}
+	w.record(delivery)
+
+	if !delivery.Succeeded {
+		w.logger.Errorf("[FAILED] Order %s: %s", payload.OrderID, delivery.Error)
+		return w.retry(payload, errors.New(delivery.Error)) == nil
+	}
+
+	w.logger.Infof("[SUCCESS] Order %s: Merchant responded %d", payload.OrderID, delivery.ResponseStatus)
+	return true
+}
+
+// fanOut queues a delivery of the event to the merchant's profile callback_url and to every
+// enabled endpoint subscribed to it. A failure part way leaves the job pending, so targets that
+// were already queued may be sent the event twice.
+func (w *webhookWorker) fanOut(ctx context.Context, merchantID uuid.UUID, payload domain.WebhookPayload) error {
+	merchant, err := w.merchantRepo.FindByID(ctx, merchantID)
+	if err != nil {
+		w.logger.Errorf("Failed to load merchant for Order %s: %v", payload.OrderID, err)
+		return err
+	}
+
+	endpoints, err := w.endpointRepo.ListByMerchant(ctx, merchantID)
+	if err != nil {
+		w.logger.Errorf("Failed to load webhook endpoints for Order %s: %v", payload.OrderID, err)
+		return err
+	}
+
+	// the version is fixed when the event is fanned out, pinning another one only affects later events
+	payload.APIVersion = merchant.APIVersion
+
+	var targets []domain.WebhookPayload
+	if merchant.CallbackURL != "" {
+		target := payload
+		target.CallbackURL = merchant.CallbackURL
+		targets = append(targets, target)
+	}
+	for _, endpoint := range endpoints {
+		if !endpoint.Enabled || !endpoint.Subscribes(payload.Event) {
+			continue
+		}
+		target := payload
+		target.EndpointID = endpoint.ID.String()
+		target.CallbackURL = endpoint.URL
+		targets = append(targets, target)
+	}
+
+	for i := range targets {
+		if err := w.publisher.Publish(ctx, &targets[i]); err != nil {
+			w.logger.Errorf("Failed to queue %s for Order %s to %s: %v", payload.Event, payload.OrderID, targets[i].CallbackURL, err)
+			return err
+		}
+	}
+
+	w.logger.Infof("[FAN-OUT] Queued %s for Order %s to %d endpoints", payload.Event, payload.OrderID, len(targets))
+	return nil
+}
+
+func (w *webhookWorker) endpointActive(ctx context.Context, merchantID uuid.UUID, rawID string) (bool, error) {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return false, nil
+	}
+
+	endpoint, err := w.endpointRepo.GetByMerchant(ctx, merchantID, id)
+	if errors.Is(err, domain.ErrWebhookEndpointNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return endpoint.Enabled, nil
+}
+
+// webhookBody encodes the callback in the shape of the job's API version. Jobs queued before
+// events were versioned carry no snapshot and are always sent in the 2025-01-01 shape.
+func webhookBody(payload *domain.WebhookPayload, timestamp int64) ([]byte, error) {
+	if payload.Data != nil && payload.APIVersion != "" && payload.APIVersion != domain.APIVersion20250101 {
+		return json.Marshal(domain.NewEvent(payload))
+	}
+
+	merchantBody := map[string]any{
+		"transaction_id": payload.TransactionID,
+		"order_id":       payload.OrderID,
+		"status":         payload.Status,
+		"amount":         payload.Amount,
+		"provider":       payload.Provider,
+		"livemode":       payload.Livemode == nil || *payload.Livemode,
+		"timestamp":      timestamp,
+	}
+	if payload.Event != "" {
+		merchantBody["event"] = payload.Event
+	}
+	if payload.RefundID != "" {
+		merchantBody["refund_id"] = payload.RefundID
+		merchantBody["refund_amount"] = payload.RefundAmount
+	}
+	return json.Marshal(merchantBody)
+}
+
+// deliver makes a single attempt and describes its outcome
+func (w *webhookWorker) deliver(ctx context.Context, merchantID uuid.UUID, payload *domain.WebhookPayload) *domain.WebhookDelivery {
+	transactionID, _ := uuid.Parse(payload.TransactionID)
+	endpointID, _ := uuid.Parse(payload.EndpointID)
+	delivery := &domain.WebhookDelivery{
+		ID:            pkg.GenerateUUIDV7(),
+		MerchantID:    merchantID,
+		EndpointID:    endpointID,
+		TransactionID: transactionID,
+		Event:         payload.Event,
+		EventStatus:   payload.Status,
+		URL:           payload.CallbackURL,
+		Attempt:       payload.RetryCount + 1,
+		Payload:       payload,
+		CreatedAt:     time.Now(),
+	}
+
+	// the secret is read on every attempt so a rotation applies to pending retries too
+	merchant, err := w.merchantRepo.FindByID(ctx, merchantID)
+	if err != nil {
+		delivery.Error = fmt.Sprintf("cannot load webhook secret: %v", err)
+		return delivery
+	}
+
+	timestamp := time.Now().Unix()
+
+	jsonBody, err := webhookBody(payload, timestamp)
+	if err != nil {
+		delivery.Error = fmt.Sprintf("cannot encode event: %v", err)
+		return delivery
+	}
+	delivery.RequestBody = string(jsonBody)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, payload.CallbackURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		delivery.Error = fmt.Sprintf("invalid callback_url: %v", err)
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(merchant.WebhookSecret, timestamp, jsonBody))
+
+	start := time.Now()
+	resp, err := w.client.Do(req)
+	delivery.Latency = time.Since(start)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, domain.WebhookDeliveryResponseSnippetSize))
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseBody = string(snippet)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		delivery.Error = fmt.Sprintf("merchant responded %d", resp.StatusCode)
+		return delivery
+	}
+
+	delivery.Succeeded = true
+	return delivery
+}
+
+// record stores the attempt in the delivery log. A failure here is only logged, the log must
+// never hold back or repeat a callback.
+func (w *webhookWorker) record(delivery *domain.WebhookDelivery) {
+	if err := w.deliveryRepo.Create(context.Background(), delivery); err != nil {
+		w.logger.Errorf("Failed to record webhook delivery for Transaction %s: %v", delivery.TransactionID, err)
+	}
+}
+
+// retry schedules the next attempt in Redis, or parks the payload in the dead-letter queue once
+// it has used up its attempts
+func (w *webhookWorker) retry(payload domain.WebhookPayload, cause error) error {
+	ctx := context.Background()
+	payload.LastError = cause.Error()
+
+	if payload.RetryCount+1 >= w.policy.MaxAttempts {
+		w.logger.Errorf("[GIVE UP] Max attempts reached for Order %s, moving it to the dead-letter queue", payload.OrderID)
+		if err := w.retryQueue.DeadLetter(ctx, &payload); err != nil {
+			w.logger.Errorf("Failed to dead-letter Order %s: %v", payload.OrderID, err)
+			return err
+		}
+		return nil
+	}
+
+	waitTime := pkg.BackoffWithJitter(payload.RetryCount, w.policy.MinBackoff, w.policy.MaxBackoff)
+	payload.RetryCount++
+
+	w.logger.Warnf("[RETRY] Rescheduling Order %s in %v (Attempt %d/%d)", payload.OrderID, waitTime, payload.RetryCount+1, w.policy.MaxAttempts)
+
+	if err := w.retryQueue.Schedule(ctx, &payload, time.Now().Add(waitTime)); err != nil {
+		w.logger.Errorf("Failed to schedule retry for Order %s: %v", payload.OrderID, err)
+		return err
+	}
+	return nil
+}
+
+// runRetryScheduler moves retries whose backoff has elapsed back onto the webhook queue
+func runRetryScheduler(ctx context.Context, retryQueue domain.WebhookRetryQueue, config *viper.Viper, logger *logrus.Logger) {
+	interval := time.Duration(config.GetInt("WEBHOOK_RETRY_POLL_INTERVAL")) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := retryQueue.PromoteDue(ctx, time.Now(), 100); err != nil {
+				logger.Errorf("[RETRY] Failed to promote due retries: %v", err)
+			}
+		}
+	}
+}
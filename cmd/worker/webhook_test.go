@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+		want     retryPolicy
+	}{
+		{
+			name:     "Defaults",
+			settings: map[string]any{},
+			want:     retryPolicy{MaxAttempts: 6, MinBackoff: 5 * time.Second, MaxBackoff: time.Hour},
+		},
+		{
+			name:     "Configured",
+			settings: map[string]any{"WEBHOOK_MAX_ATTEMPTS": 3, "WEBHOOK_RETRY_MIN_BACKOFF": 10, "WEBHOOK_RETRY_MAX_BACKOFF": 60},
+			want:     retryPolicy{MaxAttempts: 3, MinBackoff: 10 * time.Second, MaxBackoff: time.Minute},
+		},
+		{
+			name:     "Max Backoff Below Min Backoff",
+			settings: map[string]any{"WEBHOOK_RETRY_MIN_BACKOFF": 10, "WEBHOOK_RETRY_MAX_BACKOFF": 5},
+			want:     retryPolicy{MaxAttempts: 6, MinBackoff: 10 * time.Second, MaxBackoff: time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := viper.New()
+			for key, value := range tt.settings {
+				config.Set(key, value)
+			}
+
+			assert.Equal(t, tt.want, newRetryPolicy(config))
+		})
+	}
+}
+
+func TestWebhookWorker_Retry(t *testing.T) {
+	policy := retryPolicy{MaxAttempts: 3, MinBackoff: time.Minute, MaxBackoff: time.Hour}
+	cause := errors.New("callback returned 500")
+
+	tests := []struct {
+		name       string
+		retryCount int
+		mock       func(queue *mocks.MockWebhookRetryQueue)
+		wantErr    bool
+	}{
+		{
+			name:       "First Failure Is Retried",
+			retryCount: 0,
+			mock: func(queue *mocks.MockWebhookRetryQueue) {
+				queue.On("Schedule", mock.Anything, mock.MatchedBy(func(payload *domain.WebhookPayload) bool {
+					return payload.RetryCount == 1 && payload.LastError == cause.Error()
+				}), mock.MatchedBy(func(at time.Time) bool {
+					// the jittered first backoff lies between half and all of MinBackoff
+					delay := time.Until(at)
+					return delay > 29*time.Second && delay <= time.Minute
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Attempt Before The Last Is Retried",
+			retryCount: 1,
+			mock: func(queue *mocks.MockWebhookRetryQueue) {
+				queue.On("Schedule", mock.Anything, mock.MatchedBy(func(payload *domain.WebhookPayload) bool {
+					return payload.RetryCount == 2
+				}), mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name:       "Last Attempt Is Dead Lettered",
+			retryCount: 2,
+			mock: func(queue *mocks.MockWebhookRetryQueue) {
+				queue.On("DeadLetter", mock.Anything, mock.MatchedBy(func(payload *domain.WebhookPayload) bool {
+					return payload.RetryCount == 2 && payload.LastError == cause.Error()
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Failed Dead Letter",
+			retryCount: 2,
+			mock: func(queue *mocks.MockWebhookRetryQueue) {
+				queue.On("DeadLetter", mock.Anything, mock.AnythingOfType("*domain.WebhookPayload")).
+					Return(errors.New("redis error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Failed Schedule",
+			retryCount: 0,
+			mock: func(queue *mocks.MockWebhookRetryQueue) {
+				queue.On("Schedule", mock.Anything, mock.AnythingOfType("*domain.WebhookPayload"), mock.AnythingOfType("time.Time")).
+					Return(errors.New("redis error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueue := new(mocks.MockWebhookRetryQueue)
+			tt.mock(mockQueue)
+
+			worker := &webhookWorker{
+				retryQueue: mockQueue,
+				policy:     policy,
+				logger:     newTestLogger(),
+			}
+
+			err := worker.retry(domain.WebhookPayload{OrderID: "ORDER-TEST-123", RetryCount: tt.retryCount}, cause)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockQueue.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS refunds;
+ALTER TABLE transactions DROP COLUMN IF EXISTS refunded_amount;
\ No newline at end of file
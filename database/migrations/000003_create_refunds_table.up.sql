@@ -0,0 +1,16 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    reason VARCHAR(255),
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    external_ref VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_transaction_id ON refunds(transaction_id);
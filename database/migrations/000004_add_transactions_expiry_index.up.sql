@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_pending_expired_at ON transactions(expired_at) WHERE status = 'PENDING';
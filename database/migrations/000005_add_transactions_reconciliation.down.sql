@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_pending_next_reconcile_at;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS next_reconcile_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reconcile_attempts;
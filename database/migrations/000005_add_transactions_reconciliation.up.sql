@@ -0,0 +1,4 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reconcile_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS next_reconcile_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_pending_next_reconcile_at ON transactions(next_reconcile_at, created_at) WHERE status = 'PENDING';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_merchant_created_at;
+DROP INDEX IF EXISTS idx_transactions_merchant_amount_id;
+DROP INDEX IF EXISTS idx_transactions_merchant_status_id;
+DROP INDEX IF EXISTS idx_transactions_merchant_id_id;
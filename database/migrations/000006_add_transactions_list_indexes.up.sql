@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_id_id ON transactions(merchant_id, id);
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_status_id ON transactions(merchant_id, status, id);
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_amount_id ON transactions(merchant_id, amount, id);
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_created_at ON transactions(merchant_id, created_at);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_external_ref ON transactions(merchant_id, external_ref);
@@ -0,0 +1,5 @@
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_merchant_id_order_id_key;
+ALTER TABLE transactions ADD CONSTRAINT transactions_order_id_key UNIQUE (order_id);
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_provider_order_id_key;
+ALTER TABLE transactions DROP COLUMN IF EXISTS provider_order_id;
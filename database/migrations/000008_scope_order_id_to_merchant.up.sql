@@ -0,0 +1,10 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS provider_order_id VARCHAR(255);
+
+-- existing transactions were sent to the provider with the merchant's order_id
+UPDATE transactions SET provider_order_id = order_id WHERE provider_order_id IS NULL;
+
+ALTER TABLE transactions ALTER COLUMN provider_order_id SET NOT NULL;
+ALTER TABLE transactions ADD CONSTRAINT transactions_provider_order_id_key UNIQUE (provider_order_id);
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_order_id_key;
+ALTER TABLE transactions ADD CONSTRAINT transactions_merchant_id_order_id_key UNIQUE (merchant_id, order_id);
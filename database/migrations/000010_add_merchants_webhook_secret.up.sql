@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS webhook_secret VARCHAR(255);
+
+-- existing merchants have to rotate to learn their secret, until then they cannot verify callbacks
+UPDATE merchants SET webhook_secret = 'whsec_' || encode(gen_random_bytes(32), 'hex') WHERE webhook_secret IS NULL;
+
+ALTER TABLE merchants ALTER COLUMN webhook_secret SET NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    merchant_id UUID NOT NULL REFERENCES merchants(id),
+    transaction_id UUID NOT NULL,
+    event_status VARCHAR(50) NOT NULL,
+    url TEXT NOT NULL,
+    attempt INT NOT NULL,
+    request_body TEXT,
+    response_status INT,
+    response_body TEXT,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    succeeded BOOLEAN NOT NULL DEFAULT FALSE,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_merchant ON webhook_deliveries(merchant_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_transaction ON webhook_deliveries(merchant_id, transaction_id, id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_endpoint;
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS event;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS endpoint_id;
+
+DROP TABLE IF EXISTS webhook_endpoints;
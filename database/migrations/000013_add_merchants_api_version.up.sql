@@ -0,0 +1,2 @@
+-- merchants registered before events were versioned keep receiving the flat callback body
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS api_version VARCHAR(20) NOT NULL DEFAULT '2025-01-01';
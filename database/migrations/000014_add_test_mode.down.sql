@@ -0,0 +1,7 @@
+ALTER TABLE merchants DROP CONSTRAINT IF EXISTS merchants_test_api_key_key;
+ALTER TABLE merchants DROP COLUMN IF EXISTS test_api_key;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_merchant_id_livemode_order_id_key;
+ALTER TABLE transactions ADD CONSTRAINT transactions_merchant_id_order_id_key UNIQUE (merchant_id, order_id);
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS livemode;
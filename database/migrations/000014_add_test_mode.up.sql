@@ -0,0 +1,9 @@
+-- everything created before test mode existed went through the live gateways
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS livemode BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- test and live order ids live side by side, so a test order never blocks a live one
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_merchant_id_order_id_key;
+ALTER TABLE transactions ADD CONSTRAINT transactions_merchant_id_livemode_order_id_key UNIQUE (merchant_id, livemode, order_id);
+
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS test_api_key VARCHAR(255);
+ALTER TABLE merchants ADD CONSTRAINT merchants_test_api_key_key UNIQUE (test_api_key);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    merchant_id UUID NOT NULL REFERENCES merchants(id),
+    name VARCHAR(100) NOT NULL,
+    key_hash VARCHAR(255) NOT NULL UNIQUE,
+    prefix VARCHAR(32) NOT NULL,
+    scopes JSONB NOT NULL DEFAULT '[]',
+    livemode BOOLEAN NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_merchant ON api_keys(merchant_id);
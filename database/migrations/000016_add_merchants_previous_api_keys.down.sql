@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_merchants_previous_test_api_key;
+DROP INDEX IF EXISTS idx_merchants_previous_api_key;
+
+ALTER TABLE merchants DROP COLUMN IF EXISTS previous_test_api_key_expires_at;
+ALTER TABLE merchants DROP COLUMN IF EXISTS previous_test_api_key;
+ALTER TABLE merchants DROP COLUMN IF EXISTS previous_api_key_expires_at;
+ALTER TABLE merchants DROP COLUMN IF EXISTS previous_api_key;
@@ -0,0 +1,7 @@
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS previous_api_key VARCHAR(255);
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS previous_api_key_expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS previous_test_api_key VARCHAR(255);
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS previous_test_api_key_expires_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_merchants_previous_api_key ON merchants(previous_api_key);
+CREATE INDEX IF NOT EXISTS idx_merchants_previous_test_api_key ON merchants(previous_test_api_key);
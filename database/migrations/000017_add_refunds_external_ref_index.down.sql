@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_refunds_external_ref;
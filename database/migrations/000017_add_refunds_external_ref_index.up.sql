@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_refunds_external_ref ON refunds(external_ref);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_reconcile_next_reconcile_at;
+CREATE INDEX IF NOT EXISTS idx_transactions_pending_next_reconcile_at ON transactions(next_reconcile_at, created_at) WHERE status = 'PENDING';
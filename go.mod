@@ -3,16 +3,18 @@ module go-payment-aggregator
 go 1.25.1
 
 require (
+	github.com/alicebob/miniredis/v2 v2.39.0
 	github.com/midtrans/midtrans-go v1.3.8
 	github.com/redis/go-redis/v9 v9.17.2
 	github.com/stretchr/testify v1.11.1
+	github.com/xendit/xendit-go/v7 v7.0.0
 )
 
 require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	github.com/xendit/xendit-go/v7 v7.0.0 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 )
 
 require (
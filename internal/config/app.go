@@ -4,16 +4,14 @@ import (
 	"go-payment-aggregator/internal/delivery/http/handler"
 	"go-payment-aggregator/internal/delivery/http/middleware"
 	"go-payment-aggregator/internal/delivery/http/route"
-	"go-payment-aggregator/internal/domain"
-	"go-payment-aggregator/internal/gateway"
 	"go-payment-aggregator/internal/repository/postgres"
+	"go-payment-aggregator/internal/repository/redis"
 	"go-payment-aggregator/internal/usecase"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/midtrans/midtrans-go"
-	"github.com/redis/go-redis/v9"
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -25,53 +23,55 @@ type BootstrapConfig struct {
 	Log      *logrus.Logger
 	Config   *viper.Viper
 	Validate *validator.Validate
-	Redis    *redis.Client
+	Redis    *goredis.Client
 }
 
 func Bootstrap(b *BootstrapConfig) {
-	var midtransEnv midtrans.EnvironmentType
-	if b.Config.GetString("MIDTRANS_ENVIRONMENT") == "production" {
-		midtransEnv = midtrans.Production
-	} else {
-		midtransEnv = midtrans.Sandbox
-	}
-
-	mConfig := gateway.MidtransConfig{
-		ServerKey: b.Config.GetString("MIDTRANS_SERVER_KEY"),
-		Env:       midtransEnv,
-	}
-
-	xConfig := gateway.XenditConfig{
-		ApiKey: b.Config.GetString("XENDIT_API_KEY"),
-	}
-
-	midtransGateway := gateway.NewMidtransGateway(mConfig)
-	xenditGateway := gateway.NewXenditGateway(xConfig)
-
-	gateways := map[string]domain.PaymentGateway{
-		"midtrans": midtransGateway,
-		"xendit":   xenditGateway,
-	}
+	gateways := NewGateways(b.Config)
 
 	merchantRepository := postgres.NewMerchantRepository(b.DB)
 	transactionRepository := postgres.NewTransactionRepository(b.DB)
+	refundRepository := postgres.NewRefundRepository(b.DB)
+	webhookEndpointRepository := postgres.NewWebhookEndpointRepository(b.DB)
+	webhookDeliveryRepository := postgres.NewWebhookDeliveryRepository(b.DB)
+	apiKeyRepository := postgres.NewApiKeyRepository(b.DB)
+	idempotencyStore := redis.NewIdempotencyStore(b.Redis)
 
 	merchantUsecase := usecase.NewMerchantUC(merchantRepository, time.Second*2)
 	transactionUsecase := usecase.NewTransactionUC(transactionRepository, gateways, time.Second*time.Duration(b.Config.GetInt64("CONTEXT_TIMEOUT")))
+	refundUsecase := usecase.NewRefundUC(refundRepository, transactionRepository, gateways, time.Second*time.Duration(b.Config.GetInt64("CONTEXT_TIMEOUT")))
+	webhookEndpointUsecase := usecase.NewWebhookEndpointUC(webhookEndpointRepository, time.Second*time.Duration(b.Config.GetInt64("CONTEXT_TIMEOUT")))
+	webhookDeliveryUsecase := usecase.NewWebhookDeliveryUC(webhookDeliveryRepository, webhookEndpointRepository, merchantRepository, redis.NewWebhookPublisher(b.Redis), time.Second*time.Duration(b.Config.GetInt64("CONTEXT_TIMEOUT")))
+	apiKeyUsecase := usecase.NewApiKeyUC(apiKeyRepository, merchantRepository, time.Second*2)
+	idempotencyUsecase := usecase.NewIdempotencyUC(idempotencyStore, time.Second*time.Duration(b.Config.GetInt64("CONTEXT_TIMEOUT")))
 
 	merchantHandler := handler.NewMerchantHandler(merchantUsecase)
 	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+	refundHandler := handler.NewRefundHandler(refundUsecase)
+	webhookEndpointHandler := handler.NewWebhookEndpointHandler(webhookEndpointUsecase)
+	webhookDeliveryHandler := handler.NewWebhookDeliveryHandler(webhookDeliveryUsecase)
+	apiKeyHandler := handler.NewApiKeyHandler(apiKeyUsecase)
 
-	authMiddleware := middleware.NewAuthMiddleware(merchantUsecase)
+	authMiddleware := middleware.NewAuthMiddleware(merchantUsecase, apiKeyUsecase)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(idempotencyUsecase, b.Log)
 
-	midtransWebhookHandler := handler.NewMidtransWebhookHandler(transactionUsecase, b.Config.GetString("MIDTRANS_SERVER_KEY"))
+	midtransWebhookHandler := handler.NewMidtransWebhookHandler(transactionUsecase, b.Config.GetString("MIDTRANS_SERVER_KEY"), b.Config.GetString("MIDTRANS_SANDBOX_SERVER_KEY"))
+	xenditWebhookHandler := handler.NewXenditWebhookHandler(transactionUsecase, refundUsecase, b.Config.GetString("XENDIT_CALLBACK_TOKEN"), b.Config.GetString("XENDIT_TEST_CALLBACK_TOKEN"))
+	stripeWebhookHandler := handler.NewStripeWebhookHandler(transactionUsecase, refundUsecase, b.Config.GetString("STRIPE_WEBHOOK_SECRET"), b.Config.GetString("STRIPE_TEST_WEBHOOK_SECRET"))
 
 	routeConfig := &route.RouteConfig{
 		App:                    b.App,
 		MerchantHandler:        merchantHandler,
 		TransactionHandler:     transactionHandler,
+		RefundHandler:          refundHandler,
+		WebhookEndpointHandler: webhookEndpointHandler,
+		WebhookDeliveryHandler: webhookDeliveryHandler,
+		ApiKeyHandler:          apiKeyHandler,
 		AuthMiddleware:         authMiddleware,
+		IdempotencyMiddleware:  idempotencyMiddleware,
 		MidtransWebhookHandler: midtransWebhookHandler,
+		XenditWebhookHandler:   xenditWebhookHandler,
+		StripeWebhookHandler:   stripeWebhookHandler,
 	}
 
 	routeConfig.Setup()
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/gateway"
+
+	"github.com/midtrans/midtrans-go"
+	"github.com/spf13/viper"
+)
+
+func NewGateways(config *viper.Viper) domain.Gateways {
+	return domain.Gateways{
+		Live: newLiveGateways(config),
+		Test: newTestGateways(config),
+	}
+}
+
+func newLiveGateways(config *viper.Viper) map[string]domain.PaymentGateway {
+	var midtransEnv midtrans.EnvironmentType
+	if config.GetString("MIDTRANS_ENVIRONMENT") == "production" {
+		midtransEnv = midtrans.Production
+	} else {
+		midtransEnv = midtrans.Sandbox
+	}
+
+	mConfig := gateway.MidtransConfig{
+		ServerKey: config.GetString("MIDTRANS_SERVER_KEY"),
+		Env:       midtransEnv,
+	}
+
+	xConfig := gateway.XenditConfig{
+		ApiKey: config.GetString("XENDIT_API_KEY"),
+	}
+
+	sConfig := gateway.StripeConfig{
+		SecretKey:  config.GetString("STRIPE_SECRET_KEY"),
+		BaseURL:    config.GetString("STRIPE_BASE_URL"),
+		SuccessURL: config.GetString("STRIPE_SUCCESS_URL"),
+		CancelURL:  config.GetString("STRIPE_CANCEL_URL"),
+	}
+
+	midtransGateway := gateway.NewMidtransGateway(mConfig)
+	xenditGateway := gateway.NewXenditGateway(xConfig)
+	stripeGateway := gateway.NewStripeGateway(sConfig)
+
+	return map[string]domain.PaymentGateway{
+		"midtrans": midtransGateway,
+		"xendit":   xenditGateway,
+		"stripe":   stripeGateway,
+	}
+}
+
+// newTestGateways talks to each provider's sandbox when its test credentials are set and falls
+// back to the simulator otherwise, so test mode never needs a provider account to try the API
+func newTestGateways(config *viper.Viper) map[string]domain.PaymentGateway {
+	gateways := map[string]domain.PaymentGateway{
+		"midtrans": gateway.NewSimulatorGateway(),
+		"xendit":   gateway.NewSimulatorGateway(),
+		"stripe":   gateway.NewSimulatorGateway(),
+	}
+
+	if serverKey := config.GetString("MIDTRANS_SANDBOX_SERVER_KEY"); serverKey != "" {
+		gateways["midtrans"] = gateway.NewMidtransGateway(gateway.MidtransConfig{
+			ServerKey: serverKey,
+			Env:       midtrans.Sandbox,
+		})
+	}
+
+	if apiKey := config.GetString("XENDIT_TEST_API_KEY"); apiKey != "" {
+		gateways["xendit"] = gateway.NewXenditGateway(gateway.XenditConfig{
+			ApiKey: apiKey,
+		})
+	}
+
+	if secretKey := config.GetString("STRIPE_TEST_SECRET_KEY"); secretKey != "" {
+		gateways["stripe"] = gateway.NewStripeGateway(gateway.StripeConfig{
+			SecretKey:  secretKey,
+			BaseURL:    config.GetString("STRIPE_BASE_URL"),
+			SuccessURL: config.GetString("STRIPE_SUCCESS_URL"),
+			CancelURL:  config.GetString("STRIPE_CANCEL_URL"),
+		})
+	}
+
+	return gateways
+}
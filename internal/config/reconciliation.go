@@ -0,0 +1,46 @@
+package config
+
+import (
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/usecase"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func NewReconciliationConfig(config *viper.Viper) usecase.ReconciliationConfig {
+	cfg := usecase.ReconciliationConfig{
+		PendingThreshold: time.Duration(config.GetInt("RECONCILE_PENDING_THRESHOLD")) * time.Second,
+		ExpiredWindow:    time.Duration(config.GetInt("RECONCILE_EXPIRED_WINDOW")) * time.Second,
+		BatchSize:        config.GetInt("RECONCILE_BATCH_SIZE"),
+		RateLimits:       map[string]float64{},
+		MinBackoff:       time.Duration(config.GetInt("RECONCILE_MIN_BACKOFF")) * time.Second,
+		MaxBackoff:       time.Duration(config.GetInt("RECONCILE_MAX_BACKOFF")) * time.Second,
+	}
+
+	// polling has to start well before the sweeper expires a transaction to be of any use
+	if cfg.PendingThreshold <= 0 {
+		cfg.PendingThreshold = domain.TransactionExpiry / 2
+	}
+	if cfg.ExpiredWindow <= 0 {
+		cfg.ExpiredWindow = 24 * time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = time.Minute
+	}
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = time.Hour
+	}
+
+	for _, provider := range []string{"midtrans", "xendit", "stripe"} {
+		if limit := config.GetFloat64("RECONCILE_RATE_LIMIT_" + strings.ToUpper(provider)); limit > 0 {
+			cfg.RateLimits[provider] = limit
+		}
+	}
+
+	return cfg
+}
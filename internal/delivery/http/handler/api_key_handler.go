@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ApiKeyHandler struct {
+	apiKeyUC domain.ApiKeyUC
+}
+
+func NewApiKeyHandler(u domain.ApiKeyUC) *ApiKeyHandler {
+	return &ApiKeyHandler{
+		apiKeyUC: u,
+	}
+}
+
+func (h *ApiKeyHandler) Create(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	var req domain.CreateApiKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+
+	livemode, ok := requestLivemode(c, req.Mode)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	key, err := h.apiKeyUC.Create(ctx, merchant.ID, livemode, &req)
+	if err != nil {
+		writeApiKeyError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success", "API key created successfully", toApiKeyResponse(key))
+}
+
+func (h *ApiKeyHandler) List(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	ctx := c.Request.Context()
+	keys, err := h.apiKeyUC.List(ctx, merchant.ID, c.GetBool("livemode"))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to list API keys")
+		return
+	}
+
+	data := make([]response.ApiKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		data = append(data, toApiKeyResponse(key))
+	}
+
+	response.Success(c, http.StatusOK, "success", "API keys retrieved successfully", data)
+}
+
+func (h *ApiKeyHandler) Revoke(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid API key ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	key, err := h.apiKeyUC.Revoke(ctx, merchant.ID, c.GetBool("livemode"), id)
+	if err != nil {
+		writeApiKeyError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "API key revoked successfully", toApiKeyResponse(key))
+}
+
+func writeApiKeyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrApiKeyNotFound):
+		response.Error(c, http.StatusNotFound, "error", "API key not found")
+	case errors.Is(err, domain.ErrInvalidApiKeyRequest):
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+	case errors.Is(err, domain.ErrApiKeyLimitExceeded):
+		response.Error(c, http.StatusUnprocessableEntity, "error", err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, "error", err.Error())
+	}
+}
+
+func toApiKeyResponse(key *domain.ApiKey) response.ApiKeyResponse {
+	return response.ApiKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Key:        key.Key,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		Livemode:   key.Livemode,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg/response"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -34,12 +37,15 @@ func (h *MerchantHandler) Register(c *gin.Context) {
 	}
 
 	data := response.RegisterMerchantResponse{
-		ID:          merchant.ID.String(),
-		Name:        merchant.Name,
-		Email:       merchant.Email,
-		Status:      string(merchant.Status),
-		ApiKey:      merchant.ApiKey,
-		CallbackURL: merchant.CallbackURL,
+		ID:            merchant.ID.String(),
+		Name:          merchant.Name,
+		Email:         merchant.Email,
+		Status:        string(merchant.Status),
+		ApiKey:        merchant.ApiKey,
+		TestApiKey:    merchant.TestApiKey,
+		WebhookSecret: merchant.WebhookSecret,
+		CallbackURL:   merchant.CallbackURL,
+		APIVersion:    merchant.APIVersion,
 	}
 
 	response.Success(c, http.StatusCreated, "success", "Merchant created successfully", data)
@@ -68,6 +74,7 @@ func (h *MerchantHandler) Get(c *gin.Context) {
 		Status:      string(freshMerchant.Status),
 		Balance:     freshMerchant.Balance,
 		CallbackURL: freshMerchant.CallbackURL,
+		APIVersion:  freshMerchant.APIVersion,
 		CreatedAt:   freshMerchant.CreatedAt,
 		UpdatedAt:   freshMerchant.UpdatedAt,
 	}
@@ -93,6 +100,10 @@ func (h *MerchantHandler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
 	updateMerchant, err := h.merchantUC.UpdateProfile(ctx, merchant.ID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAPIVersion) {
+			response.Error(c, http.StatusBadRequest, "error", err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "error", "Failed to update profile")
 		return
 	}
@@ -104,6 +115,7 @@ func (h *MerchantHandler) Update(c *gin.Context) {
 		Status:      string(updateMerchant.Status),
 		Balance:     updateMerchant.Balance,
 		CallbackURL: updateMerchant.CallbackURL,
+		APIVersion:  updateMerchant.APIVersion,
 		CreatedAt:   updateMerchant.CreatedAt,
 		UpdatedAt:   updateMerchant.UpdatedAt,
 	}
@@ -120,14 +132,78 @@ func (h *MerchantHandler) RegenerateApiKey(c *gin.Context) {
 
 	merchant := merchantData.(*domain.Merchant)
 
+	var req domain.RegenerateApiKeyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+	}
+
+	livemode, ok := requestLivemode(c, req.Mode)
+	if !ok {
+		return
+	}
+
+	// checked before converting, so a huge grace_period cannot overflow into a valid duration
+	maxGracePeriod := int64(domain.MaxApiKeyGracePeriod / time.Second)
+	if req.GracePeriod < 0 || req.GracePeriod > maxGracePeriod {
+		response.Error(c, http.StatusBadRequest, "error", fmt.Sprintf("grace_period must be between 0 and %d seconds", maxGracePeriod))
+		return
+	}
+
 	ctx := c.Request.Context()
-	newApiKey, err := h.merchantUC.RegenerateApiKey(ctx, merchant.ID)
+	newApiKey, previousExpiresAt, err := h.merchantUC.RegenerateApiKey(ctx, merchant.ID, livemode, time.Duration(req.GracePeriod)*time.Second)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "error", "Failed to regenerate API key")
 		return
 	}
 
 	response.Success(c, http.StatusOK, "success", "API key regenerated successfully", &response.GenerateApiKeyResponse{
-		ApiKey: newApiKey,
+		ApiKey:               newApiKey,
+		Livemode:             livemode,
+		PreviousKeyExpiresAt: previousExpiresAt,
 	})
 }
+
+func (h *MerchantHandler) RotateWebhookSecret(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	ctx := c.Request.Context()
+	newSecret, err := h.merchantUC.RotateWebhookSecret(ctx, merchant.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to rotate webhook secret")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "Webhook secret rotated successfully", &response.RotateWebhookSecretResponse{
+		WebhookSecret: newSecret,
+	})
+}
+
+// requestLivemode resolves the mode a request body asks for, falling back to the mode of the calling
+// key. Test keys are handed to less trusted environments, so they cannot create or regenerate live
+// keys. It writes the error response itself when the mode is not allowed.
+func requestLivemode(c *gin.Context, mode string) (bool, bool) {
+	callerLivemode := c.GetBool("livemode")
+
+	switch mode {
+	case "":
+		return callerLivemode, true
+	case "live", "test":
+		if mode == "live" && !callerLivemode {
+			response.Error(c, http.StatusForbidden, "forbidden", "A test key cannot manage live keys")
+			return false, false
+		}
+		return mode == "live", true
+	default:
+		response.Error(c, http.StatusBadRequest, "error", "mode must be live or test")
+		return false, false
+	}
+}
@@ -11,12 +11,15 @@ import (
 type MidtransWebhookHandler struct {
 	transactionUC domain.TransactionUC
 	ServerKey     string
+	// SandboxServerKey signs notifications for test mode transactions, empty when test mode runs on the simulator
+	SandboxServerKey string
 }
 
-func NewMidtransWebhookHandler(u domain.TransactionUC, serverKey string) *MidtransWebhookHandler {
+func NewMidtransWebhookHandler(u domain.TransactionUC, serverKey, sandboxServerKey string) *MidtransWebhookHandler {
 	return &MidtransWebhookHandler{
-		transactionUC: u,
-		ServerKey:     serverKey,
+		transactionUC:    u,
+		ServerKey:        serverKey,
+		SandboxServerKey: sandboxServerKey,
 	}
 }
 
@@ -24,15 +27,21 @@ func (h *MidtransWebhookHandler) Handle(c *gin.Context) {
 	var req MidtransWebhookRequest
 	c.BindJSON(&req)
 
+	livemode := true
 	isValidSignature := pkg.VerifySignature(req.OrderID, req.StatusCode, req.GrossAmount, h.ServerKey, req.SignatureKey)
+	if !isValidSignature && h.SandboxServerKey != "" {
+		livemode = false
+		isValidSignature = pkg.VerifySignature(req.OrderID, req.StatusCode, req.GrossAmount, h.SandboxServerKey, req.SignatureKey)
+	}
 	if !isValidSignature {
 		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid signature key"})
 		return
 	}
 
 	domainReq := domain.UpdateStatusRequest{
-		OrderID: req.OrderID,
-		Status:  pkg.MapMidtransStatus(req.TransactionStatus, req.FraudStatus),
+		OrderID:  req.OrderID,
+		Status:   pkg.MapMidtransStatus(req.TransactionStatus, req.FraudStatus),
+		Livemode: livemode,
 	}
 
 	ctx := c.Request.Context()
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RefundHandler struct {
+	refundUC domain.RefundUC
+}
+
+func NewRefundHandler(u domain.RefundUC) *RefundHandler {
+	return &RefundHandler{
+		refundUC: u,
+	}
+}
+
+func (h *RefundHandler) Create(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid transaction ID")
+		return
+	}
+
+	var req domain.CreateRefundRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	refund, err := h.refundUC.Create(ctx, merchant.ID, c.GetBool("livemode"), transactionID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTransactionNotFound):
+			response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+		case errors.Is(err, domain.ErrTransactionNotRefundable),
+			errors.Is(err, domain.ErrRefundAmountExceeded),
+			errors.Is(err, domain.ErrInvalidRefundAmount),
+			errors.Is(err, domain.ErrInvalidStatusTransition):
+			response.Error(c, http.StatusUnprocessableEntity, "error", err.Error())
+		case errors.Is(err, domain.ErrStatusConflict):
+			response.Error(c, http.StatusConflict, "error", err.Error())
+		case errors.Is(err, domain.ErrGatewayTimeout):
+			response.Error(c, http.StatusGatewayTimeout, "error", "Payment provider did not respond in time")
+		default:
+			response.Error(c, http.StatusInternalServerError, "error", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success", "Refund created successfully", toRefundResponse(refund))
+}
+
+func (h *RefundHandler) List(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid transaction ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	refunds, err := h.refundUC.List(ctx, merchant.ID, c.GetBool("livemode"), transactionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to get refunds")
+		return
+	}
+
+	data := make([]response.RefundResponse, 0, len(refunds))
+	for _, refund := range refunds {
+		data = append(data, toRefundResponse(refund))
+	}
+
+	response.Success(c, http.StatusOK, "success", "Refunds retrieved successfully", data)
+}
+
+func toRefundResponse(refund *domain.Refund) response.RefundResponse {
+	return response.RefundResponse{
+		ID:            refund.ID.String(),
+		TransactionID: refund.TransactionID.String(),
+		Amount:        refund.Amount,
+		Currency:      refund.Currency,
+		Reason:        refund.Reason,
+		Status:        string(refund.Status),
+		ExternalID:    refund.ExternalID,
+		CreatedAt:     refund.CreatedAt,
+		UpdatedAt:     refund.UpdatedAt,
+	}
+}
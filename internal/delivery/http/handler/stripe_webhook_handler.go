@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stripeSignatureTolerance matches the default replay window of Stripe's official libraries
+const stripeSignatureTolerance = 5 * time.Minute
+
+type StripeWebhookHandler struct {
+	transactionUC domain.TransactionUC
+	refundUC      domain.RefundUC
+	WebhookSecret string
+	// TestWebhookSecret signs events for test mode transactions, empty when test mode runs on the simulator
+	TestWebhookSecret string
+}
+
+func NewStripeWebhookHandler(u domain.TransactionUC, ru domain.RefundUC, webhookSecret, testWebhookSecret string) *StripeWebhookHandler {
+	return &StripeWebhookHandler{
+		transactionUC:     u,
+		refundUC:          ru,
+		WebhookSecret:     webhookSecret,
+		TestWebhookSecret: testWebhookSecret,
+	}
+}
+
+func (h *StripeWebhookHandler) Handle(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid payload"})
+		return
+	}
+
+	livemode := true
+	isValidSignature := pkg.VerifySignatureStripe(payload, c.GetHeader("Stripe-Signature"), h.WebhookSecret, stripeSignatureTolerance)
+	if !isValidSignature && h.TestWebhookSecret != "" {
+		livemode = false
+		isValidSignature = pkg.VerifySignatureStripe(payload, c.GetHeader("Stripe-Signature"), h.TestWebhookSecret, stripeSignatureTolerance)
+	}
+	if !isValidSignature {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid signature"})
+		return
+	}
+
+	var event StripeWebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid payload"})
+		return
+	}
+
+	switch event.Type {
+	case "refund.updated", "refund.failed", "charge.refund.updated":
+		h.handleRefund(c, event, livemode)
+		return
+	}
+
+	var domainReq domain.UpdateStatusRequest
+	switch event.Type {
+	case "checkout.session.completed", "checkout.session.expired":
+		var session StripeCheckoutSessionObject
+		if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+			c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid checkout session"})
+			return
+		}
+		domainReq = domain.UpdateStatusRequest{
+			OrderID: session.ClientReferenceID,
+			Status:  pkg.MapStripeStatus(session.Status, session.PaymentStatus),
+		}
+	// payment_intent.payment_failed is ignored on purpose: a declined attempt does not end a Checkout
+	// session, the customer can try again on the same page until checkout.session.expired arrives
+	case "payment_intent.succeeded":
+		var intent StripePaymentIntentObject
+		if err := json.Unmarshal(event.Data.Object, &intent); err != nil {
+			c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid payment intent"})
+			return
+		}
+		domainReq = domain.UpdateStatusRequest{
+			OrderID: intent.Metadata["order_id"],
+			Status:  "PAID",
+		}
+	default:
+		c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Event ignored"})
+		return
+	}
+
+	if domainReq.OrderID == "" {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Missing order reference"})
+		return
+	}
+
+	domainReq.Livemode = livemode
+
+	ctx := c.Request.Context()
+	err = h.transactionUC.HandleNotification(ctx, &domainReq)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Notification processed"})
+}
+
+// handleRefund settles refunds Stripe accepted as pending
+func (h *StripeWebhookHandler) handleRefund(c *gin.Context, event StripeWebhookEvent, livemode bool) {
+	var refund StripeRefundObject
+	if err := json.Unmarshal(event.Data.Object, &refund); err != nil || refund.ID == "" {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid refund"})
+		return
+	}
+
+	domainReq := domain.UpdateRefundStatusRequest{
+		ExternalID: refund.ID,
+		RefundID:   refund.Metadata["refund_id"],
+		Status:     domain.RefundStatus(pkg.MapStripeRefundStatus(refund.Status)),
+		Livemode:   livemode,
+	}
+
+	ctx := c.Request.Context()
+	if err := h.refundUC.HandleNotification(ctx, &domainReq); err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Notification processed"})
+}
+
+type StripeWebhookEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+type StripeCheckoutSessionObject struct {
+	ID                string `json:"id"`
+	ClientReferenceID string `json:"client_reference_id"`
+	Status            string `json:"status"`
+	PaymentStatus     string `json:"payment_status"`
+}
+
+type StripePaymentIntentObject struct {
+	ID       string            `json:"id"`
+	Status   string            `json:"status"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+type StripeRefundObject struct {
+	ID       string            `json:"id"`
+	Status   string            `json:"status"`
+	Metadata map[string]string `json:"metadata"`
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/delivery/http/handler"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	stripeLiveSecret = "whsec_live"
+	stripeTestSecret = "whsec_test"
+)
+
+// signStripe builds a Stripe-Signature header for payload signed now with secret
+func signStripe(payload, secret string) string {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestStripeWebhookHandler_Refund(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	refundEvent := func(eventType, status string) string {
+		return `{"id":"evt_1","type":"` + eventType + `","data":{"object":{"id":"re_123","status":"` + status + `","metadata":{"refund_id":"0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b"}}}}`
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		secret  string
+		mock    func(refundUC *mocks.MockRefundUC)
+	}{
+		{
+			name:    "Live Refund Succeeded",
+			payload: refundEvent("refund.updated", "succeeded"),
+			secret:  stripeLiveSecret,
+			mock: func(refundUC *mocks.MockRefundUC) {
+				refundUC.On("HandleNotification", mock.Anything, &domain.UpdateRefundStatusRequest{
+					ExternalID: "re_123",
+					RefundID:   "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:     domain.RefundStatusSucceeded,
+					Livemode:   true,
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "Test Refund Failed",
+			payload: refundEvent("charge.refund.updated", "failed"),
+			secret:  stripeTestSecret,
+			mock: func(refundUC *mocks.MockRefundUC) {
+				refundUC.On("HandleNotification", mock.Anything, &domain.UpdateRefundStatusRequest{
+					ExternalID: "re_123",
+					RefundID:   "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:     domain.RefundStatusFailed,
+					Livemode:   false,
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "Wrong Secret Is Rejected",
+			payload: refundEvent("refund.updated", "succeeded"),
+			secret:  "whsec_other",
+			mock:    func(refundUC *mocks.MockRefundUC) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransactionUC := new(mocks.MockTransactionUC)
+			mockRefundUC := new(mocks.MockRefundUC)
+			tt.mock(mockRefundUC)
+
+			h := handler.NewStripeWebhookHandler(mockTransactionUC, mockRefundUC, stripeLiveSecret, stripeTestSecret)
+
+			router := gin.New()
+			router.POST("/webhooks/stripe", h.Handle)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", strings.NewReader(tt.payload))
+			req.Header.Set("Stripe-Signature", signStripe(tt.payload, tt.secret))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			mockRefundUC.AssertExpectations(t)
+			mockTransactionUC.AssertExpectations(t)
+		})
+	}
+}
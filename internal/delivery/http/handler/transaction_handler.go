@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg/response"
 	"net/http"
@@ -35,32 +36,38 @@ func (h *TransactionHandler) Create(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	createdTransaction, err := h.transactionUC.Create(ctx, merchant.ID, &req)
+	createdTransaction, err := h.transactionUC.Create(ctx, merchant.ID, c.GetBool("livemode"), &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrDuplicateOrderID) {
+			response.Error(c, http.StatusConflict, "duplicate_order_id", "A transaction with this order_id already exists")
+			return
+		}
+		if errors.Is(err, domain.ErrGatewayTimeout) {
+			response.Error(c, http.StatusGatewayTimeout, "error", "Payment provider did not respond in time")
+			return
+		}
+		if errors.Is(err, domain.ErrPaymentMethodNotSupported) {
+			response.Error(c, http.StatusUnprocessableEntity, "error", err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "error", err.Error())
 		return
 	}
 
-	data := response.CreateTransactionResponse{
-		ID:            createdTransaction.ID.String(),
-		MerchantID:    createdTransaction.MerchantID.String(),
-		OrderID:       createdTransaction.OrderID,
-		Provider:      createdTransaction.Provider,
-		Currency:      createdTransaction.Currency,
-		Amount:        createdTransaction.Amount,
-		Status:        string(createdTransaction.Status),
-		PaymentMethod: createdTransaction.PaymentMethod,
-		PaymentURL:    createdTransaction.PaymentURL,
-		ExternalID:    createdTransaction.ExternalID,
-		ExpiredAt:     createdTransaction.ExpiredAt,
-		CreatedAt:     createdTransaction.CreatedAt,
-		UpdatedAt:     createdTransaction.UpdatedAt,
-	}
+	data := toTransactionResponse(createdTransaction)
 
 	response.Success(c, http.StatusCreated, "success", "Transaction created successfully", data)
 }
 
 func (h *TransactionHandler) Get(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
 	transactionID := c.Param("id")
 	if transactionID == "" {
 		response.Error(c, http.StatusBadRequest, "error", "Transaction ID is required")
@@ -74,27 +81,160 @@ func (h *TransactionHandler) Get(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	transaction, err := h.transactionUC.Get(ctx, id)
+	transaction, err := h.transactionUC.Get(ctx, merchant.ID, c.GetBool("livemode"), id)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to get transaction")
 		return
 	}
 
-	data := response.CreateTransactionResponse{
-		ID:            transaction.ID.String(),
-		MerchantID:    transaction.MerchantID.String(),
-		OrderID:       transaction.OrderID,
-		Provider:      transaction.Provider,
-		Currency:      transaction.Currency,
-		Amount:        transaction.Amount,
-		Status:        string(transaction.Status),
-		PaymentMethod: transaction.PaymentMethod,
-		PaymentURL:    transaction.PaymentURL,
-		ExternalID:    transaction.ExternalID,
-		ExpiredAt:     transaction.ExpiredAt,
-		CreatedAt:     transaction.CreatedAt,
-		UpdatedAt:     transaction.UpdatedAt,
-	}
+	data := toTransactionResponse(transaction)
 
 	response.Success(c, http.StatusOK, "success", "Transaction retrieved successfully", data)
 }
+
+func (h *TransactionHandler) GetByOrderID(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	ctx := c.Request.Context()
+	transaction, err := h.transactionUC.GetByOrderID(ctx, merchant.ID, c.GetBool("livemode"), c.Param("order_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to get transaction")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "Transaction retrieved successfully", toTransactionResponse(transaction))
+}
+
+func (h *TransactionHandler) GetByExternalID(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	ctx := c.Request.Context()
+	transaction, err := h.transactionUC.GetByExternalID(ctx, merchant.ID, c.GetBool("livemode"), c.Param("external_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to get transaction")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "Transaction retrieved successfully", toTransactionResponse(transaction))
+}
+
+func (h *TransactionHandler) Cancel(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid transaction ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	transaction, err := h.transactionUC.Cancel(ctx, merchant.ID, c.GetBool("livemode"), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTransactionNotFound):
+			response.Error(c, http.StatusNotFound, "error", "Transaction not found")
+		case errors.Is(err, domain.ErrTransactionNotCancellable), errors.Is(err, domain.ErrPaymentNotStarted):
+			response.Error(c, http.StatusUnprocessableEntity, "error", err.Error())
+		case errors.Is(err, domain.ErrStatusConflict):
+			response.Error(c, http.StatusConflict, "error", err.Error())
+		case errors.Is(err, domain.ErrGatewayTimeout):
+			response.Error(c, http.StatusGatewayTimeout, "error", "Payment provider did not respond in time")
+		default:
+			response.Error(c, http.StatusInternalServerError, "error", err.Error())
+		}
+		return
+	}
+
+	data := toTransactionResponse(transaction)
+
+	response.Success(c, http.StatusOK, "success", "Transaction cancelled successfully", data)
+}
+
+func (h *TransactionHandler) List(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	var req domain.ListTransactionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	page, err := h.transactionUC.List(ctx, merchant.ID, c.GetBool("livemode"), &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidListFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+			response.Error(c, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to list transactions")
+		return
+	}
+
+	data := response.TransactionListResponse{
+		Transactions: make([]response.CreateTransactionResponse, 0, len(page.Transactions)),
+		NextCursor:   page.NextCursor,
+		HasMore:      page.NextCursor != "",
+	}
+	for _, transaction := range page.Transactions {
+		data.Transactions = append(data.Transactions, toTransactionResponse(transaction))
+	}
+
+	response.Success(c, http.StatusOK, "success", "Transactions retrieved successfully", data)
+}
+
+func toTransactionResponse(transaction *domain.Transaction) response.CreateTransactionResponse {
+	return response.CreateTransactionResponse{
+		ID:              transaction.ID.String(),
+		MerchantID:      transaction.MerchantID.String(),
+		OrderID:         transaction.OrderID,
+		ProviderOrderID: transaction.ProviderOrderID,
+		Provider:        transaction.Provider,
+		Currency:        transaction.Currency,
+		Amount:          transaction.Amount,
+		RefundedAmount:  transaction.RefundedAmount,
+		Status:          string(transaction.Status),
+		PaymentMethod:   transaction.PaymentMethod,
+		PaymentURL:      transaction.PaymentURL,
+		ExternalID:      transaction.ExternalID,
+		Livemode:        transaction.Livemode,
+		ExpiredAt:       transaction.ExpiredAt,
+		CreatedAt:       transaction.CreatedAt,
+		UpdatedAt:       transaction.UpdatedAt,
+	}
+}
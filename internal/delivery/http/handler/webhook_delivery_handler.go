@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryHandler struct {
+	webhookDeliveryUC domain.WebhookDeliveryUC
+}
+
+func NewWebhookDeliveryHandler(u domain.WebhookDeliveryUC) *WebhookDeliveryHandler {
+	return &WebhookDeliveryHandler{
+		webhookDeliveryUC: u,
+	}
+}
+
+func (h *WebhookDeliveryHandler) List(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	var req domain.ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	page, err := h.webhookDeliveryUC.List(ctx, merchant.ID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhookDeliveryQuery) || errors.Is(err, domain.ErrInvalidCursor) {
+			response.Error(c, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to list webhook deliveries")
+		return
+	}
+
+	data := response.WebhookDeliveryListResponse{
+		Deliveries: make([]response.WebhookDeliveryResponse, 0, len(page.Deliveries)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+	}
+	for _, delivery := range page.Deliveries {
+		data.Deliveries = append(data.Deliveries, toWebhookDeliveryResponse(delivery))
+	}
+
+	response.Success(c, http.StatusOK, "success", "Webhook deliveries retrieved successfully", data)
+}
+
+func (h *WebhookDeliveryHandler) Redeliver(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid webhook delivery ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.webhookDeliveryUC.Redeliver(ctx, merchant.ID, id); err != nil {
+		if errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+			response.Error(c, http.StatusNotFound, "error", "Webhook delivery not found")
+			return
+		}
+		if errors.Is(err, domain.ErrWebhookEndpointNotFound) {
+			response.Error(c, http.StatusNotFound, "error", "Webhook endpoint of the delivery was deleted")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to queue redelivery")
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, "success", "Redelivery queued successfully", nil)
+}
+
+func toWebhookDeliveryResponse(delivery *domain.WebhookDelivery) response.WebhookDeliveryResponse {
+	res := response.WebhookDeliveryResponse{
+		ID:             delivery.ID.String(),
+		TransactionID:  delivery.TransactionID.String(),
+		Event:          delivery.Event,
+		EventStatus:    delivery.EventStatus,
+		URL:            delivery.URL,
+		Attempt:        delivery.Attempt,
+		Succeeded:      delivery.Succeeded,
+		RequestBody:    delivery.RequestBody,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LatencyMs:      delivery.Latency.Milliseconds(),
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.EndpointID != uuid.Nil {
+		res.EndpointID = delivery.EndpointID.String()
+	}
+	return res
+}
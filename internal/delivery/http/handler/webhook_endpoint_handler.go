@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookEndpointHandler struct {
+	webhookEndpointUC domain.WebhookEndpointUC
+}
+
+func NewWebhookEndpointHandler(u domain.WebhookEndpointUC) *WebhookEndpointHandler {
+	return &WebhookEndpointHandler{
+		webhookEndpointUC: u,
+	}
+}
+
+func (h *WebhookEndpointHandler) Create(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	var req domain.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	endpoint, err := h.webhookEndpointUC.Create(ctx, merchant.ID, &req)
+	if err != nil {
+		writeWebhookEndpointError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success", "Webhook endpoint created successfully", toWebhookEndpointResponse(endpoint))
+}
+
+func (h *WebhookEndpointHandler) List(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	ctx := c.Request.Context()
+	endpoints, err := h.webhookEndpointUC.List(ctx, merchant.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "error", "Failed to list webhook endpoints")
+		return
+	}
+
+	data := make([]response.WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		data = append(data, toWebhookEndpointResponse(endpoint))
+	}
+
+	response.Success(c, http.StatusOK, "success", "Webhook endpoints retrieved successfully", data)
+}
+
+func (h *WebhookEndpointHandler) Get(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid webhook endpoint ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	endpoint, err := h.webhookEndpointUC.Get(ctx, merchant.ID, id)
+	if err != nil {
+		writeWebhookEndpointError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "Webhook endpoint retrieved successfully", toWebhookEndpointResponse(endpoint))
+}
+
+func (h *WebhookEndpointHandler) Update(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid webhook endpoint ID")
+		return
+	}
+
+	var req domain.UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	endpoint, err := h.webhookEndpointUC.Update(ctx, merchant.ID, id, &req)
+	if err != nil {
+		writeWebhookEndpointError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "Webhook endpoint updated successfully", toWebhookEndpointResponse(endpoint))
+}
+
+func (h *WebhookEndpointHandler) Delete(c *gin.Context) {
+	merchantData, exists := c.Get("merchant")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+		return
+	}
+
+	merchant := merchantData.(*domain.Merchant)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "error", "Invalid webhook endpoint ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.webhookEndpointUC.Delete(ctx, merchant.ID, id); err != nil {
+		writeWebhookEndpointError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success", "Webhook endpoint deleted successfully", nil)
+}
+
+func writeWebhookEndpointError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookEndpointNotFound):
+		response.Error(c, http.StatusNotFound, "error", "Webhook endpoint not found")
+	case errors.Is(err, domain.ErrInvalidWebhookEndpoint):
+		response.Error(c, http.StatusBadRequest, "error", err.Error())
+	case errors.Is(err, domain.ErrWebhookEndpointLimitExceeded):
+		response.Error(c, http.StatusUnprocessableEntity, "error", err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, "error", err.Error())
+	}
+}
+
+func toWebhookEndpointResponse(endpoint *domain.WebhookEndpoint) response.WebhookEndpointResponse {
+	return response.WebhookEndpointResponse{
+		ID:          endpoint.ID.String(),
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      endpoint.Events,
+		Enabled:     endpoint.Enabled,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type XenditWebhookHandler struct {
+	transactionUC domain.TransactionUC
+	refundUC      domain.RefundUC
+	CallbackToken string
+	// TestCallbackToken authenticates callbacks for test mode transactions, empty when test mode runs on the simulator
+	TestCallbackToken string
+}
+
+func NewXenditWebhookHandler(u domain.TransactionUC, ru domain.RefundUC, callbackToken, testCallbackToken string) *XenditWebhookHandler {
+	return &XenditWebhookHandler{
+		transactionUC:     u,
+		refundUC:          ru,
+		CallbackToken:     callbackToken,
+		TestCallbackToken: testCallbackToken,
+	}
+}
+
+// verify reports whether the callback carries the live or the test token, and which one
+func (h *XenditWebhookHandler) verify(c *gin.Context) (livemode bool, ok bool) {
+	if pkg.VerifyCallbackTokenXendit(c.GetHeader("x-callback-token"), h.CallbackToken) {
+		return true, true
+	}
+	if h.TestCallbackToken != "" && pkg.VerifyCallbackTokenXendit(c.GetHeader("x-callback-token"), h.TestCallbackToken) {
+		return false, true
+	}
+	return false, false
+}
+
+// Handle answers 401 to callbacks without a valid token, anything else is acknowledged with 200 so
+// Xendit does not retry a callback that can never be applied
+func (h *XenditWebhookHandler) Handle(c *gin.Context) {
+	livemode, isValidToken := h.verify(c)
+	if !isValidToken {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Invalid callback token"})
+		return
+	}
+
+	var req XenditWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid payload"})
+		return
+	}
+
+	domainReq := domain.UpdateStatusRequest{
+		OrderID:  req.ExternalID,
+		Status:   pkg.MapXenditStatus(req.Status),
+		Livemode: livemode,
+	}
+
+	ctx := c.Request.Context()
+	err := h.transactionUC.HandleNotification(ctx, &domainReq)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Notification processed"})
+}
+
+// HandleRefund settles refunds from Xendit's refund callbacks, which Xendit sends separately from invoice callbacks
+func (h *XenditWebhookHandler) HandleRefund(c *gin.Context) {
+	livemode, isValidToken := h.verify(c)
+	if !isValidToken {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Invalid callback token"})
+		return
+	}
+
+	var req XenditRefundWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": "Invalid payload"})
+		return
+	}
+
+	domainReq := domain.UpdateRefundStatusRequest{
+		ExternalID: req.Data.ID,
+		RefundID:   req.Data.ReferenceID,
+		Status:     domain.RefundStatus(pkg.MapXenditRefundStatus(req.Data.Status)),
+		Livemode:   livemode,
+	}
+
+	ctx := c.Request.Context()
+	err := h.refundUC.HandleNotification(ctx, &domainReq)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Notification processed"})
+}
+
+type XenditWebhookRequest struct {
+	ID             string  `json:"id"`
+	ExternalID     string  `json:"external_id"`
+	Status         string  `json:"status"`
+	Amount         float64 `json:"amount"`
+	PaidAmount     float64 `json:"paid_amount"`
+	PaymentMethod  string  `json:"payment_method"`
+	PaymentChannel string  `json:"payment_channel"`
+}
+
+type XenditRefundWebhookRequest struct {
+	Event string `json:"event"`
+	Data  struct {
+		ID          string  `json:"id"`
+		InvoiceID   string  `json:"invoice_id"`
+		ReferenceID string  `json:"reference_id"`
+		Status      string  `json:"status"`
+		Amount      float64 `json:"amount"`
+		FailureCode string  `json:"failure_code"`
+	} `json:"data"`
+}
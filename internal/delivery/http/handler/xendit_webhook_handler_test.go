@@ -0,0 +1,257 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-payment-aggregator/internal/delivery/http/handler"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	xenditLiveToken = "xnd_live_token"
+	xenditTestToken = "xnd_test_token"
+)
+
+func TestXenditWebhookHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	invoiceCallback := func(status string) string {
+		return `{"id":"inv_123","external_id":"0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b","status":"` + status + `","amount":100000}`
+	}
+
+	tests := []struct {
+		name     string
+		payload  string
+		token    string
+		mock     func(transactionUC *mocks.MockTransactionUC)
+		wantCode int
+	}{
+		{
+			name:    "Live Invoice Paid",
+			payload: invoiceCallback("PAID"),
+			token:   xenditLiveToken,
+			mock: func(transactionUC *mocks.MockTransactionUC) {
+				transactionUC.On("HandleNotification", mock.Anything, &domain.UpdateStatusRequest{
+					OrderID:  "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:   "PAID",
+					Livemode: true,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "Test Invoice Settled",
+			payload: invoiceCallback("SETTLED"),
+			token:   xenditTestToken,
+			mock: func(transactionUC *mocks.MockTransactionUC) {
+				transactionUC.On("HandleNotification", mock.Anything, &domain.UpdateStatusRequest{
+					OrderID:  "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:   "PAID",
+					Livemode: false,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "Live Invoice Expired",
+			payload: invoiceCallback("EXPIRED"),
+			token:   xenditLiveToken,
+			mock: func(transactionUC *mocks.MockTransactionUC) {
+				transactionUC.On("HandleNotification", mock.Anything, &domain.UpdateStatusRequest{
+					OrderID:  "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:   "EXPIRED",
+					Livemode: true,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "Cancelled Invoice Fails The Transaction",
+			payload: invoiceCallback("CANCELLED"),
+			token:   xenditLiveToken,
+			mock: func(transactionUC *mocks.MockTransactionUC) {
+				transactionUC.On("HandleNotification", mock.Anything, &domain.UpdateStatusRequest{
+					OrderID:  "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:   "FAILED",
+					Livemode: true,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "Notification Error Is Acknowledged",
+			payload: invoiceCallback("PAID"),
+			token:   xenditLiveToken,
+			mock: func(transactionUC *mocks.MockTransactionUC) {
+				transactionUC.On("HandleNotification", mock.Anything, mock.AnythingOfType("*domain.UpdateStatusRequest")).
+					Return(domain.ErrTransactionNotFound)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Missing Token Is Rejected",
+			payload:  invoiceCallback("PAID"),
+			token:    "",
+			mock:     func(transactionUC *mocks.MockTransactionUC) {},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Wrong Token Is Rejected",
+			payload:  invoiceCallback("PAID"),
+			token:    "xnd_other_token",
+			mock:     func(transactionUC *mocks.MockTransactionUC) {},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransactionUC := new(mocks.MockTransactionUC)
+			mockRefundUC := new(mocks.MockRefundUC)
+			tt.mock(mockTransactionUC)
+
+			h := handler.NewXenditWebhookHandler(mockTransactionUC, mockRefundUC, xenditLiveToken, xenditTestToken)
+
+			router := gin.New()
+			router.POST("/webhooks/xendit", h.Handle)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/xendit", strings.NewReader(tt.payload))
+			if tt.token != "" {
+				req.Header.Set("x-callback-token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+
+			mockTransactionUC.AssertExpectations(t)
+			mockRefundUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestXenditWebhookHandler_HandleTestTokenUnset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockTransactionUC := new(mocks.MockTransactionUC)
+
+	// without sandbox credentials test mode runs on the simulator, so no callback is a test callback
+	h := handler.NewXenditWebhookHandler(mockTransactionUC, new(mocks.MockRefundUC), xenditLiveToken, "")
+
+	router := gin.New()
+	router.POST("/webhooks/xendit", h.Handle)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/xendit", strings.NewReader(`{"external_id":"ORDER-TEST-123","status":"PAID"}`))
+	req.Header.Set("x-callback-token", "")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockTransactionUC.AssertExpectations(t)
+}
+
+func TestXenditWebhookHandler_HandleRefund(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	refundCallback := func(status string) string {
+		return `{"event":"refund.succeeded","data":{"id":"rfd_123","invoice_id":"inv_123","reference_id":"0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b","status":"` + status + `","amount":50000}}`
+	}
+
+	tests := []struct {
+		name     string
+		payload  string
+		token    string
+		mock     func(refundUC *mocks.MockRefundUC)
+		wantCode int
+	}{
+		{
+			name:    "Live Refund Succeeded",
+			payload: refundCallback("SUCCEEDED"),
+			token:   xenditLiveToken,
+			mock: func(refundUC *mocks.MockRefundUC) {
+				refundUC.On("HandleNotification", mock.Anything, &domain.UpdateRefundStatusRequest{
+					ExternalID: "rfd_123",
+					RefundID:   "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:     domain.RefundStatusSucceeded,
+					Livemode:   true,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "Test Refund Cancelled",
+			payload: refundCallback("CANCELLED"),
+			token:   xenditTestToken,
+			mock: func(refundUC *mocks.MockRefundUC) {
+				refundUC.On("HandleNotification", mock.Anything, &domain.UpdateRefundStatusRequest{
+					ExternalID: "rfd_123",
+					RefundID:   "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:     domain.RefundStatusFailed,
+					Livemode:   false,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "Unknown Status Stays Pending",
+			payload: refundCallback("REQUESTED"),
+			token:   xenditLiveToken,
+			mock: func(refundUC *mocks.MockRefundUC) {
+				refundUC.On("HandleNotification", mock.Anything, &domain.UpdateRefundStatusRequest{
+					ExternalID: "rfd_123",
+					RefundID:   "0192a8b4-69f1-7e2d-8a3b-1c2d3e4f5a6b",
+					Status:     domain.RefundStatusPending,
+					Livemode:   true,
+				}).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Missing Token Is Rejected",
+			payload:  refundCallback("SUCCEEDED"),
+			token:    "",
+			mock:     func(refundUC *mocks.MockRefundUC) {},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Wrong Token Is Rejected",
+			payload:  refundCallback("SUCCEEDED"),
+			token:    "xnd_other_token",
+			mock:     func(refundUC *mocks.MockRefundUC) {},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransactionUC := new(mocks.MockTransactionUC)
+			mockRefundUC := new(mocks.MockRefundUC)
+			tt.mock(mockRefundUC)
+
+			h := handler.NewXenditWebhookHandler(mockTransactionUC, mockRefundUC, xenditLiveToken, xenditTestToken)
+
+			router := gin.New()
+			router.POST("/webhooks/xendit/refunds", h.HandleRefund)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/xendit/refunds", strings.NewReader(tt.payload))
+			if tt.token != "" {
+				req.Header.Set("x-callback-token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+
+			mockRefundUC.AssertExpectations(t)
+			mockTransactionUC.AssertExpectations(t)
+		})
+	}
+}
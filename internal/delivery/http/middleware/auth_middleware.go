@@ -1,24 +1,31 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg/response"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthMiddleware struct {
 	merchantUC domain.MerchantUC
+	apiKeyUC   domain.ApiKeyUC
 }
 
-func NewAuthMiddleware(usecase domain.MerchantUC) *AuthMiddleware {
+func NewAuthMiddleware(usecase domain.MerchantUC, apiKeyUsecase domain.ApiKeyUC) *AuthMiddleware {
 	return &AuthMiddleware{
 		merchantUC: usecase,
+		apiKeyUC:   apiKeyUsecase,
 	}
 }
 
-func (m *AuthMiddleware) RequireApiKey() gin.HandlerFunc {
+// RequireApiKey accepts the merchant's own live and test keys on every route. Named keys are only
+// accepted on routes that list scopes, and must have been granted all of them.
+func (m *AuthMiddleware) RequireApiKey(scopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		apiKey := c.GetHeader("X-API-KEY")
 
@@ -29,14 +36,63 @@ func (m *AuthMiddleware) RequireApiKey() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		merchant, err := m.merchantUC.ValidateApiKey(ctx, apiKey)
+		merchant, key, deprecatedUntil, err := m.authenticate(ctx, apiKey)
 		if err != nil {
 			response.Error(c, http.StatusUnauthorized, "unauthorized", "Invalid API key")
 			c.Abort()
 			return
 		}
 
+		// the key was regenerated with a grace period, tell the caller to switch before it stops working
+		if deprecatedUntil != nil {
+			c.Header("X-API-Key-Deprecated", "true")
+			c.Header("Sunset", deprecatedUntil.UTC().Format(http.TimeFormat))
+		}
+
+		if key != nil {
+			if len(scopes) == 0 {
+				response.Error(c, http.StatusForbidden, "forbidden", "This route requires the merchant's own API key")
+				c.Abort()
+				return
+			}
+			for _, scope := range scopes {
+				if !key.HasScopes(scope) {
+					response.Error(c, http.StatusForbidden, "forbidden", fmt.Sprintf("API key is missing the %s scope", scope))
+					c.Abort()
+					return
+				}
+			}
+			c.Set("api_key", key)
+		}
+
 		c.Set("merchant", merchant)
+		// keys issued before test mode existed have no mode in their prefix and stay live
+		c.Set("livemode", !domain.IsTestApiKey(apiKey))
+		c.Next()
+	}
+}
+
+// RequireLiveMode keeps test keys away from settings shared by both modes, such as the callback URL
+// and webhook endpoints. It must run after RequireApiKey.
+func (m *AuthMiddleware) RequireLiveMode() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("livemode") {
+			response.Error(c, http.StatusForbidden, "forbidden", "This route requires a live API key")
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
+
+// authenticate returns a nil key for the merchant's own keys, and when the merchant's key was
+// replaced, the end of its grace period
+func (m *AuthMiddleware) authenticate(ctx context.Context, apiKey string) (*domain.Merchant, *domain.ApiKey, *time.Time, error) {
+	merchant, deprecatedUntil, err := m.merchantUC.ValidateApiKey(ctx, apiKey)
+	if err == nil {
+		return merchant, nil, deprecatedUntil, nil
+	}
+
+	merchant, key, err := m.apiKeyUC.Authenticate(ctx, apiKey)
+	return merchant, key, nil, err
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/pkg/response"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type IdempotencyMiddleware struct {
+	idempotencyUC domain.IdempotencyUC
+	log           *logrus.Logger
+}
+
+func NewIdempotencyMiddleware(usecase domain.IdempotencyUC, log *logrus.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		idempotencyUC: usecase,
+		log:           log,
+	}
+}
+
+// Handle replays the stored response when a request is retried with the same Idempotency-Key.
+// It must run after RequireApiKey because keys are scoped to the merchant.
+func (m *IdempotencyMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			response.Error(c, http.StatusBadRequest, "error", "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		merchantData, exists := c.Get("merchant")
+		if !exists {
+			response.Error(c, http.StatusUnauthorized, "unauthorized", "Merchant not found in context")
+			c.Abort()
+			return
+		}
+
+		merchant := merchantData.(*domain.Merchant)
+
+		// test and live requests never replay each other's responses
+		if !c.GetBool("livemode") {
+			key = "test:" + key
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "error", "Failed to read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := pkg.HashKey256(c.Request.Method + " " + c.FullPath() + "\n" + string(body))
+
+		ctx := c.Request.Context()
+		record, err := m.idempotencyUC.Begin(ctx, merchant.ID, key, requestHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrIdempotencyKeyMismatch):
+				response.Error(c, http.StatusUnprocessableEntity, "idempotency_key_mismatch", "Idempotency-Key was already used with a different request")
+			case errors.Is(err, domain.ErrIdempotencyKeyInUse):
+				response.Error(c, http.StatusConflict, "idempotency_key_in_use", "A request with this Idempotency-Key is still being processed")
+			default:
+				response.Error(c, http.StatusInternalServerError, "error", "Failed to check Idempotency-Key")
+			}
+			c.Abort()
+			return
+		}
+
+		if record != nil {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.StatusCode, "application/json; charset=utf-8", record.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// the client may have hung up, which is exactly when it will retry, so the result must still be stored
+		ctx = context.WithoutCancel(ctx)
+
+		// server errors are not replayed, a retry runs the request again. A key that cannot be
+		// released stays in use until it times out, retries get 409 until then. A provider timeout
+		// is replayed instead: the provider may have created the payment, so running the request
+		// again could create a second one.
+		if recorder.Status() >= http.StatusInternalServerError && recorder.Status() != http.StatusGatewayTimeout {
+			if err := m.idempotencyUC.Release(ctx, merchant.ID, key); err != nil {
+				m.log.Errorf("[IDEMPOTENCY] Failed to release key %q of merchant %s: %v", key, merchant.ID, err)
+			}
+			return
+		}
+
+		// a response that cannot be stored is not replayed, a retry would run the request again
+		if err := m.idempotencyUC.Complete(ctx, merchant.ID, key, &domain.IdempotencyRecord{
+			RequestHash: requestHash,
+			StatusCode:  recorder.Status(),
+			Body:        recorder.body.Bytes(),
+		}); err != nil {
+			m.log.Errorf("[IDEMPOTENCY] Failed to store response for key %q of merchant %s: %v", key, merchant.ID, err)
+		}
+	}
+}
+
+// responseRecorder keeps a copy of the response body so it can be replayed later
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -3,6 +3,7 @@ package route
 import (
 	"go-payment-aggregator/internal/delivery/http/handler"
 	"go-payment-aggregator/internal/delivery/http/middleware"
+	"go-payment-aggregator/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,8 +12,15 @@ type RouteConfig struct {
 	App                    *gin.Engine
 	MerchantHandler        *handler.MerchantHandler
 	TransactionHandler     *handler.TransactionHandler
+	RefundHandler          *handler.RefundHandler
+	WebhookEndpointHandler *handler.WebhookEndpointHandler
+	WebhookDeliveryHandler *handler.WebhookDeliveryHandler
+	ApiKeyHandler          *handler.ApiKeyHandler
 	AuthMiddleware         *middleware.AuthMiddleware
+	IdempotencyMiddleware  *middleware.IdempotencyMiddleware
 	MidtransWebhookHandler *handler.MidtransWebhookHandler
+	XenditWebhookHandler   *handler.XenditWebhookHandler
+	StripeWebhookHandler   *handler.StripeWebhookHandler
 }
 
 func (c *RouteConfig) Setup() {
@@ -28,19 +36,51 @@ func (c *RouteConfig) SetupRoutes() {
 		{
 			m.POST("", c.MerchantHandler.Register)
 			m.GET("/profile", c.AuthMiddleware.RequireApiKey(), c.MerchantHandler.Get)
-			m.PUT("/profile", c.AuthMiddleware.RequireApiKey(), c.MerchantHandler.Update)
+			m.PUT("/profile", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.MerchantHandler.Update)
 			m.POST("/api-key/regenerate", c.AuthMiddleware.RequireApiKey(), c.MerchantHandler.RegenerateApiKey)
+			m.POST("/webhook-secret/rotate", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.MerchantHandler.RotateWebhookSecret)
 		}
 
 		t := v1.Group("/transactions")
 		{
-			t.POST("", c.AuthMiddleware.RequireApiKey(), c.TransactionHandler.Create)
-			t.GET("/:id", c.AuthMiddleware.RequireApiKey(), c.TransactionHandler.Get)
+			t.POST("", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsWrite), c.IdempotencyMiddleware.Handle(), c.TransactionHandler.Create)
+			t.GET("", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsRead), c.TransactionHandler.List)
+			t.GET("/by-order/:order_id", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsRead), c.TransactionHandler.GetByOrderID)
+			t.GET("/by-external/:external_id", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsRead), c.TransactionHandler.GetByExternalID)
+			t.GET("/:id", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsRead), c.TransactionHandler.Get)
+			t.POST("/:id/cancel", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsWrite), c.TransactionHandler.Cancel)
+			t.POST("/:id/refunds", c.AuthMiddleware.RequireApiKey(domain.ScopeRefundsWrite), c.RefundHandler.Create)
+			t.GET("/:id/refunds", c.AuthMiddleware.RequireApiKey(domain.ScopeTransactionsRead), c.RefundHandler.List)
+		}
+
+		k := v1.Group("/api-keys")
+		{
+			k.POST("", c.AuthMiddleware.RequireApiKey(), c.ApiKeyHandler.Create)
+			k.GET("", c.AuthMiddleware.RequireApiKey(), c.ApiKeyHandler.List)
+			k.POST("/:id/revoke", c.AuthMiddleware.RequireApiKey(), c.ApiKeyHandler.Revoke)
+		}
+
+		e := v1.Group("/webhook-endpoints")
+		{
+			e.POST("", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookEndpointHandler.Create)
+			e.GET("", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookEndpointHandler.List)
+			e.GET("/:id", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookEndpointHandler.Get)
+			e.PATCH("/:id", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookEndpointHandler.Update)
+			e.DELETE("/:id", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookEndpointHandler.Delete)
+		}
+
+		d := v1.Group("/webhook-deliveries")
+		{
+			d.GET("", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookDeliveryHandler.List)
+			d.POST("/:id/redeliver", c.AuthMiddleware.RequireApiKey(), c.AuthMiddleware.RequireLiveMode(), c.WebhookDeliveryHandler.Redeliver)
 		}
 
 		w := v1.Group("/webhooks")
 		{
 			w.POST("/midtrans", c.MidtransWebhookHandler.Handle)
+			w.POST("/xendit", c.XenditWebhookHandler.Handle)
+			w.POST("/xendit/refunds", c.XenditWebhookHandler.HandleRefund)
+			w.POST("/stripe", c.StripeWebhookHandler.Handle)
 		}
 	}
 }
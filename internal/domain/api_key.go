@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeTransactionsWrite = "transactions:write"
+	ScopeTransactionsRead  = "transactions:read"
+	ScopeRefundsWrite      = "refunds:write"
+
+	MaxApiKeysPerMerchant = 20
+	MaxApiKeyNameLength   = 100
+
+	// ApiKeyDisplayLength is how much of a named key is kept in the clear, enough to tell keys apart
+	ApiKeyDisplayLength = 17
+
+	// ApiKeyLastUsedInterval bounds how often last_used_at is written for a key in constant use
+	ApiKeyLastUsedInterval = time.Minute
+)
+
+// ApiKeyScopes lists every scope a named key can be granted
+var ApiKeyScopes = []string{
+	ScopeTransactionsWrite,
+	ScopeTransactionsRead,
+	ScopeRefundsWrite,
+}
+
+var (
+	ErrApiKeyNotFound       = errors.New("api key not found")
+	ErrInvalidApiKeyRequest = errors.New("invalid api key")
+	ErrApiKeyLimitExceeded  = errors.New("api key limit reached")
+)
+
+// IsValidApiKeyScope reports whether scope can be granted
+func IsValidApiKeyScope(scope string) bool {
+	for _, known := range ApiKeyScopes {
+		if known == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ApiKey is a named key a merchant hands to one of its services. Unlike the merchant's own live
+// and test keys it only reaches the routes its scopes allow, and it can expire or be revoked
+// without touching any other key.
+type ApiKey struct {
+	ID         uuid.UUID
+	MerchantID uuid.UUID
+	Name       string
+	// Key is only set right after creation, only KeyHash is stored
+	Key     string
+	KeyHash string
+	// Prefix is the start of the key, shown so merchants can tell their keys apart
+	Prefix     string
+	Scopes     []string
+	Livemode   bool
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// HasScopes reports whether the key was granted every one of scopes
+func (k *ApiKey) HasScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		granted := false
+		for _, s := range k.Scopes {
+			if s == scope {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			return false
+		}
+	}
+	return true
+}
+
+// Usable reports whether the key can still authenticate requests at now
+func (k *ApiKey) Usable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+type ApiKeyRepository interface {
+	Create(ctx context.Context, key *ApiKey) error
+	FindByHash(ctx context.Context, keyHash string) (*ApiKey, error)
+	GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*ApiKey, error)
+	ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]*ApiKey, error)
+	Revoke(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type ApiKeyUC interface {
+	// Create issues a named key of the given mode, the raw key is only returned here
+	Create(ctx context.Context, merchantID uuid.UUID, livemode bool, req *CreateApiKeyRequest) (*ApiKey, error)
+	// List and Revoke only reach test keys when livemode, the mode of the caller, is false
+	List(ctx context.Context, merchantID uuid.UUID, livemode bool) ([]*ApiKey, error)
+	Revoke(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*ApiKey, error)
+	// Authenticate resolves a named key to its active merchant, revoked and expired keys are not found
+	Authenticate(ctx context.Context, apiKey string) (*Merchant, *ApiKey, error)
+}
+
+// CreateApiKeyRequest creates a key of the mode the request was made with unless Mode is set.
+// A key without ExpiresAt never expires.
+type CreateApiKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	Mode      string     `json:"mode" validate:"omitempty,oneof=live test"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
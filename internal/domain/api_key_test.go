@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKey_HasScopes(t *testing.T) {
+	key := &domain.ApiKey{Scopes: []string{domain.ScopeTransactionsRead, domain.ScopeRefundsWrite}}
+
+	assert.True(t, key.HasScopes(domain.ScopeTransactionsRead))
+	assert.True(t, key.HasScopes(domain.ScopeTransactionsRead, domain.ScopeRefundsWrite))
+	assert.False(t, key.HasScopes(domain.ScopeTransactionsWrite))
+	assert.False(t, key.HasScopes(domain.ScopeRefundsWrite, domain.ScopeTransactionsWrite))
+}
+
+func TestApiKey_Usable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		key  *domain.ApiKey
+		want bool
+	}{
+		{"Never Expires", &domain.ApiKey{}, true},
+		{"Expires Later", &domain.ApiKey{ExpiresAt: &future}, true},
+		{"Expired", &domain.ApiKey{ExpiresAt: &past}, false},
+		{"Expires Now", &domain.ApiKey{ExpiresAt: &now}, false},
+		{"Revoked", &domain.ApiKey{RevokedAt: &past, ExpiresAt: &future}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.key.Usable(now), tt.name)
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// APIVersion20250101 sends the flat callback body used before events were versioned
+	APIVersion20250101 = "2025-01-01"
+	// APIVersion20261001 wraps every callback in an Event envelope
+	APIVersion20261001 = "2026-10-01"
+
+	// LatestAPIVersion is pinned on merchants when they register
+	LatestAPIVersion = APIVersion20261001
+)
+
+// APIVersions lists every version a merchant can pin, oldest first
+var APIVersions = []string{
+	APIVersion20250101,
+	APIVersion20261001,
+}
+
+var ErrInvalidAPIVersion = errors.New("unknown api version")
+
+// IsValidAPIVersion reports whether version can be pinned
+func IsValidAPIVersion(version string) bool {
+	for _, known := range APIVersions {
+		if known == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the body of a callback sent with API version 2026-10-01 or later. ID is the same for
+// every endpoint and every retry of the event, so merchants can use it to drop duplicates.
+type Event struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	APIVersion string    `json:"api_version"`
+	CreatedAt  time.Time `json:"created_at"`
+	Data       EventData `json:"data"`
+}
+
+type EventData struct {
+	Transaction *TransactionEventData `json:"transaction"`
+	// Refund is only set on refund.* events
+	Refund *RefundEventData `json:"refund,omitempty"`
+}
+
+// TransactionEventData is the transaction as it was right after the change an event announces.
+// Fields are added here deliberately, never by exposing Transaction itself.
+type TransactionEventData struct {
+	ID              string    `json:"id"`
+	MerchantID      string    `json:"merchant_id"`
+	OrderID         string    `json:"order_id"`
+	ProviderOrderID string    `json:"provider_order_id"`
+	ExternalID      string    `json:"external_id"`
+	Provider        string    `json:"provider"`
+	PaymentMethod   string    `json:"payment_method"`
+	Amount          int64     `json:"amount"`
+	RefundedAmount  int64     `json:"refunded_amount"`
+	Currency        string    `json:"currency"`
+	Status          string    `json:"status"`
+	PaymentURL      string    `json:"payment_url"`
+	Livemode        bool      `json:"livemode"`
+	ExpiredAt       time.Time `json:"expired_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func NewTransactionEventData(tx *Transaction) *TransactionEventData {
+	return &TransactionEventData{
+		ID:              tx.ID.String(),
+		MerchantID:      tx.MerchantID.String(),
+		OrderID:         tx.OrderID,
+		ProviderOrderID: tx.ProviderOrderID,
+		ExternalID:      tx.ExternalID,
+		Provider:        tx.Provider,
+		PaymentMethod:   tx.PaymentMethod,
+		Amount:          tx.Amount,
+		RefundedAmount:  tx.RefundedAmount,
+		Currency:        tx.Currency,
+		Status:          string(tx.Status),
+		PaymentURL:      tx.PaymentURL,
+		Livemode:        tx.Livemode,
+		ExpiredAt:       tx.ExpiredAt,
+		CreatedAt:       tx.CreatedAt,
+		UpdatedAt:       tx.UpdatedAt,
+	}
+}
+
+type RefundEventData struct {
+	ID         string    `json:"id"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	Reason     string    `json:"reason"`
+	Status     string    `json:"status"`
+	ExternalID string    `json:"external_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewRefundEventData(r *Refund) *RefundEventData {
+	return &RefundEventData{
+		ID:         r.ID.String(),
+		Amount:     r.Amount,
+		Currency:   r.Currency,
+		Reason:     r.Reason,
+		Status:     string(r.Status),
+		ExternalID: r.ExternalID,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+// NewEvent wraps the snapshot a job carries in the envelope of the job's API version
+func NewEvent(p *WebhookPayload) *Event {
+	return &Event{
+		ID:         p.EventID,
+		Type:       p.Event,
+		APIVersion: p.APIVersion,
+		CreatedAt:  p.EventCreatedAt,
+		Data:       *p.Data,
+	}
+}
@@ -1,8 +1,43 @@
 package domain
 
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrGatewayTimeout  = errors.New("payment gateway timeout")
+	ErrGatewayCanceled = errors.New("payment gateway request canceled")
+	ErrPaymentNotFound = errors.New("payment not found at provider")
+	// ErrPaymentMethodNotSupported is returned when the provider cannot take the payment method in the requested currency
+	ErrPaymentMethodNotSupported = errors.New("payment method is not supported in this currency by the provider")
+	// ErrPaymentNotStarted is returned when the provider cannot close a payment the customer has
+	// not opened yet, which could still be paid after a cancel
+	ErrPaymentNotStarted = errors.New("payment not started at provider yet, it cannot be cancelled until the customer opens it or it expires")
+)
+
+// Gateways holds a client per provider for each mode, so test transactions never reach live
+// credentials
+type Gateways struct {
+	Live map[string]PaymentGateway
+	Test map[string]PaymentGateway
+}
+
+// For returns the client handling provider's transactions in the given mode
+func (g Gateways) For(provider string, livemode bool) (PaymentGateway, bool) {
+	gateways := g.Test
+	if livemode {
+		gateways = g.Live
+	}
+	gateway, ok := gateways[provider]
+	return gateway, ok
+}
+
 type PaymentGateway interface {
-	CreatePayment(req *CreatePaymentRequest) (*PaymentResponse, error)
-	CheckStatus(orderID string) (string, error)
+	CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*PaymentResponse, error)
+	CheckStatus(ctx context.Context, req *CheckStatusRequest) (string, error)
+	Refund(ctx context.Context, req *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	Cancel(ctx context.Context, req *CancelPaymentRequest) error
 }
 
 type PaymentResponse struct {
@@ -19,3 +54,31 @@ type CreatePaymentRequest struct {
 	Customer      Customer `json:"customer"`
 	Items         []Item   `json:"items"`
 }
+
+// CheckStatusRequest carries both references because Midtrans looks payments up by our
+// order ID while Xendit and Stripe use their own invoice or session ID
+type CheckStatusRequest struct {
+	OrderID    string `json:"order_id"`
+	ExternalID string `json:"external_id"`
+}
+
+// RefundPaymentRequest carries both our order ID and the provider reference, since
+// providers disagree on which one identifies the original payment
+type RefundPaymentRequest struct {
+	RefundID   string `json:"refund_id"`
+	OrderID    string `json:"order_id"`
+	ExternalID string `json:"external_id"`
+	Amount     int64  `json:"amount"`
+	Currency   string `json:"currency"`
+	Reason     string `json:"reason"`
+}
+
+type RefundPaymentResponse struct {
+	RefundID string       `json:"refund_id"`
+	Status   RefundStatus `json:"status"`
+}
+
+type CancelPaymentRequest struct {
+	OrderID    string `json:"order_id"`
+	ExternalID string `json:"external_id"`
+}
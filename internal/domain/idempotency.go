@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyTTL is how long a completed request can be replayed with the same Idempotency-Key
+const IdempotencyKeyTTL = 24 * time.Hour
+
+var (
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInUse    = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyRecord is what is stored under an Idempotency-Key. A record without a
+// status code marks a request that is still in flight.
+type IdempotencyRecord struct {
+	RequestHash string `json:"request_hash"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+func (r *IdempotencyRecord) InProgress() bool {
+	return r.StatusCode == 0
+}
+
+type IdempotencyStore interface {
+	// Reserve stores record under key only if the key is unused and reports whether it did
+	Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (bool, error)
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+	Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type IdempotencyUC interface {
+	// Begin claims the key for a new request, or returns the completed record to replay
+	Begin(ctx context.Context, merchantID uuid.UUID, key string, requestHash string) (*IdempotencyRecord, error)
+	Complete(ctx context.Context, merchantID uuid.UUID, key string, record *IdempotencyRecord) error
+	Release(ctx context.Context, merchantID uuid.UUID, key string) error
+}
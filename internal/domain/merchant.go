@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,12 +11,34 @@ import (
 
 type MerchantStatus string
 
+const (
+	// LiveApiKeyPrefix marks keys that move real money. Keys issued before test mode existed
+	// start with "mch_" only and are live too.
+	LiveApiKeyPrefix = "mch_live"
+	// TestApiKeyPrefix marks keys whose transactions go to sandbox gateways
+	TestApiKeyPrefix = "mch_test"
+)
+
+// IsTestApiKey reports whether apiKey was issued for test mode
+func IsTestApiKey(apiKey string) bool {
+	return strings.HasPrefix(apiKey, TestApiKeyPrefix+"_")
+}
+
 const (
 	MerchantStatusActive    MerchantStatus = "ACTIVE"
 	MerchantStatusSuspended MerchantStatus = "SUSPENDED"
 	MerchantStatusInactive  MerchantStatus = "INACTIVE"
 )
 
+// MaxApiKeyGracePeriod bounds how long a replaced key keeps working after a regeneration
+const MaxApiKeyGracePeriod = 7 * 24 * time.Hour
+
+var (
+	ErrMerchantNotActive      = errors.New("merchant is not active")
+	ErrInvalidGracePeriod     = errors.New("invalid grace period")
+	ErrApiKeyGracePeriodEnded = errors.New("replaced api key is past its grace period")
+)
+
 type Merchant struct {
 	ID          uuid.UUID      `json:"id"`
 	Name        string         `json:"name"`
@@ -22,26 +46,48 @@ type Merchant struct {
 	ApiKey      string         `json:"api_key,omitempty"`
 	APIKeyHash  string         `json:"-"`
 	CallbackURL string         `json:"callback_url"`
+	APIVersion  string         `json:"api_version"`
 	Status      MerchantStatus `json:"status"`
 	Balance     int64          `json:"balance"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+
+	// TestApiKey is only set right after registration, like ApiKey
+	TestApiKey     string `json:"test_api_key,omitempty"`
+	TestAPIKeyHash string `json:"-"`
+
+	// The Previous fields hold the live and test keys replaced with a grace period. A replaced key
+	// keeps working until its ExpiresAt and is empty once a later regeneration drops it.
+	PreviousAPIKeyHash          string     `json:"-"`
+	PreviousAPIKeyExpiresAt     *time.Time `json:"-"`
+	PreviousTestAPIKeyHash      string     `json:"-"`
+	PreviousTestAPIKeyExpiresAt *time.Time `json:"-"`
+
+	// WebhookSecret signs the callbacks sent to the merchant, so unlike the API key it is stored as is
+	WebhookSecret string `json:"-"`
 }
 
 type MerchantRepository interface {
 	Create(ctx context.Context, m *Merchant) (*Merchant, error)
 	Update(ctx context.Context, m *Merchant) error
+	// FindByApiKey looks the hash up among the live and the test keys, current and replaced
 	FindByApiKey(ctx context.Context, apiKey string) (*Merchant, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*Merchant, error)
-	RegenerateApiKey(ctx context.Context, id uuid.UUID, newApiKey string) error
+	// RegenerateApiKey keeps the replaced key working until previousExpiresAt, a nil
+	// previousExpiresAt revokes it right away
+	RegenerateApiKey(ctx context.Context, id uuid.UUID, livemode bool, newApiKey string, previousExpiresAt *time.Time) error
+	RotateWebhookSecret(ctx context.Context, id uuid.UUID, newSecret string) error
 }
 
 type MerchantUC interface {
 	Register(ctx context.Context, req *RegisterMerchantRequest) (*Merchant, error)
 	GetProfile(ctx context.Context, id uuid.UUID) (*Merchant, error)
 	UpdateProfile(ctx context.Context, id uuid.UUID, req *UpdateMerchantRequest) (*Merchant, error)
-	ValidateApiKey(ctx context.Context, apiKey string) (*Merchant, error)
-	RegenerateApiKey(ctx context.Context, id uuid.UUID) (string, error)
+	// ValidateApiKey also accepts a replaced key inside its grace period, and then returns when the key stops working
+	ValidateApiKey(ctx context.Context, apiKey string) (*Merchant, *time.Time, error)
+	// RegenerateApiKey returns the new key and, when gracePeriod is set, when the replaced key stops working
+	RegenerateApiKey(ctx context.Context, id uuid.UUID, livemode bool, gracePeriod time.Duration) (string, *time.Time, error)
+	RotateWebhookSecret(ctx context.Context, id uuid.UUID) (string, error)
 }
 
 type RegisterMerchantRequest struct {
@@ -50,7 +96,16 @@ type RegisterMerchantRequest struct {
 	CallbackURL string `json:"callback_url" validate:"required,url"`
 }
 
+// RegenerateApiKeyRequest replaces the key of the mode the request was made with unless Mode is set.
+// GracePeriod is in seconds, zero revokes the replaced key right away.
+type RegenerateApiKeyRequest struct {
+	Mode        string `json:"mode" validate:"omitempty,oneof=live test"`
+	GracePeriod int64  `json:"grace_period" validate:"omitempty,min=0"`
+}
+
 type UpdateMerchantRequest struct {
 	Name        string `json:"name" validate:"omitempty,min=3"`
 	CallbackURL string `json:"callback_url" validate:"omitempty,url"`
+	// APIVersion pins the shape of callbacks for events created from now on
+	APIVersion string `json:"api_version"`
 }
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is written in the same database transaction as the change it describes and
+// relayed to the webhook queue afterwards, so a committed change can never lose its callback
+type OutboxEvent struct {
+	ID          uuid.UUID
+	AggregateID uuid.UUID
+	// EventType is the webhook event the payload announces, such as transaction.paid
+	EventType   string
+	Payload     []byte
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+// OutboxPublishFunc hands a single event to the message queue
+type OutboxPublishFunc func(ctx context.Context, event *OutboxEvent) error
+
+type OutboxRepository interface {
+	// PublishPending locks up to limit unpublished events in creation order, passes each to
+	// publish and marks it published, stopping at the first event publish fails on
+	PublishPending(ctx context.Context, limit int, publish OutboxPublishFunc) (int, error)
+}
+
+type OutboxRelayUC interface {
+	Relay(ctx context.Context, limit int) (int, error)
+}
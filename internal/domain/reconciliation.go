@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// StatusCorrection is a status change we only learned about by polling the provider
+type StatusCorrection struct {
+	TransactionID uuid.UUID         `json:"transaction_id"`
+	OrderID       string            `json:"order_id"`
+	Provider      string            `json:"provider"`
+	From          TransactionStatus `json:"from"`
+	To            TransactionStatus `json:"to"`
+}
+
+type ReconciliationUC interface {
+	ReconcilePending(ctx context.Context) ([]*StatusCorrection, error)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "PENDING"
+	RefundStatusSucceeded RefundStatus = "SUCCEEDED"
+	RefundStatusFailed    RefundStatus = "FAILED"
+)
+
+var (
+	ErrTransactionNotRefundable = errors.New("transaction cannot be refunded in its current status")
+	ErrRefundAmountExceeded     = errors.New("refund amount exceeds the refundable balance")
+	ErrInvalidRefundAmount      = errors.New("refund amount must be greater than zero")
+	ErrRefundNotFound           = errors.New("refund not found")
+)
+
+type Refund struct {
+	ID            uuid.UUID    `json:"id"`
+	TransactionID uuid.UUID    `json:"transaction_id"`
+	MerchantID    uuid.UUID    `json:"merchant_id"`
+	Amount        int64        `json:"amount"`
+	Currency      string       `json:"currency"`
+	Reason        string       `json:"reason"`
+	Status        RefundStatus `json:"status"`
+	ExternalID    string       `json:"external_id"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+type RefundRepository interface {
+	Create(ctx context.Context, r *Refund) (*Refund, error)
+	// UpdatePending saves the outcome of a refund that is still PENDING and records event, when not
+	// nil, in the outbox within the same database transaction. A refund that was already settled
+	// returns ErrStatusConflict.
+	UpdatePending(ctx context.Context, r *Refund, event *OutboxEvent) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Refund, error)
+	FindByExternalID(ctx context.Context, externalID string) (*Refund, error)
+	FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*Refund, error)
+}
+
+type RefundUC interface {
+	Create(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID, req *CreateRefundRequest) (*Refund, error)
+	List(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID) ([]*Refund, error)
+	// HandleNotification settles a PENDING refund from the provider's callback
+	HandleNotification(ctx context.Context, req *UpdateRefundStatusRequest) error
+}
+
+// CreateRefundRequest refunds the whole remaining balance when Amount is zero
+type CreateRefundRequest struct {
+	Amount int64  `json:"amount" validate:"omitempty,min=1"`
+	Reason string `json:"reason" validate:"omitempty,max=255"`
+}
+
+// UpdateRefundStatusRequest is a provider's report on a refund, identified by the provider's refund ID.
+// RefundID is our own ID echoed back by the provider, it finds refunds whose request timed out
+// before the provider's ID could be stored.
+type UpdateRefundStatusRequest struct {
+	ExternalID string
+	RefundID   string
+	Status     RefundStatus
+	Livemode   bool
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,40 +12,128 @@ import (
 type TransactionStatus string
 
 const (
-	TransactionStatusPending TransactionStatus = "PENDING"
-	TransactionStatusPaid    TransactionStatus = "PAID"
-	TransactionStatusFailed  TransactionStatus = "FAILED"
-	TransactionStatusExpired TransactionStatus = "EXPIRED"
+	TransactionStatusPending           TransactionStatus = "PENDING"
+	TransactionStatusPaid              TransactionStatus = "PAID"
+	TransactionStatusFailed            TransactionStatus = "FAILED"
+	TransactionStatusExpired           TransactionStatus = "EXPIRED"
+	TransactionStatusRefunded          TransactionStatus = "REFUNDED"
+	TransactionStatusPartiallyRefunded TransactionStatus = "PARTIALLY_REFUNDED"
+	TransactionStatusCancelled         TransactionStatus = "CANCELLED"
 )
 
+// TransactionExpiry is how long a customer has to pay a new transaction
+const TransactionExpiry = 2 * time.Minute
+
+var (
+	ErrTransactionNotFound       = errors.New("transaction not found")
+	ErrInvalidStatusTransition   = errors.New("invalid transaction status transition")
+	ErrStatusConflict            = errors.New("transaction status was changed concurrently")
+	ErrTransactionNotCancellable = errors.New("only pending transactions can be cancelled")
+	ErrDuplicateOrderID          = errors.New("order id already exists for this merchant")
+)
+
+// transactionStatusTransitions lists every status a transaction may move to from its current status.
+// Providers can settle a payment after we have marked it EXPIRED, so EXPIRED -> PAID stays legal.
+var transactionStatusTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending:           {TransactionStatusPaid, TransactionStatusFailed, TransactionStatusExpired, TransactionStatusCancelled},
+	TransactionStatusExpired:           {TransactionStatusPaid},
+	TransactionStatusPaid:              {TransactionStatusRefunded, TransactionStatusPartiallyRefunded},
+	TransactionStatusPartiallyRefunded: {TransactionStatusPartiallyRefunded, TransactionStatusRefunded},
+	TransactionStatusFailed:            {},
+	TransactionStatusRefunded:          {},
+	TransactionStatusCancelled:         {},
+}
+
+// CanTransitionTo reports whether the state machine allows moving from s to next
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range transactionStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether s is one of the known transaction statuses
+func (s TransactionStatus) IsValid() bool {
+	_, ok := transactionStatusTransitions[s]
+	return ok
+}
+
+type StatusTransitionError struct {
+	From TransactionStatus
+	To   TransactionStatus
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("invalid transaction status transition from %s to %s", e.From, e.To)
+}
+
+func (e *StatusTransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
+
 type Transaction struct {
-	ID            uuid.UUID         `json:"id"`
-	MerchantID    uuid.UUID         `json:"merchant_id"`
-	OrderID       string            `json:"order_id"`
-	ExternalID    string            `json:"external_id"`
-	Provider      string            `json:"provider"`
-	PaymentMethod string            `json:"payment_method"`
-	Amount        int64             `json:"amount"`
-	Currency      string            `json:"currency"`
-	Status        TransactionStatus `json:"status"`
-	PaymentURL    string            `json:"payment_url"`
-	RawResponse   string            `json:"-"`
-	ExpiredAt     time.Time         `json:"expired_at"`
-	CreatedAt     time.Time         `json:"created_at"`
-	UpdatedAt     time.Time         `json:"updated_at"`
+	ID         uuid.UUID `json:"id"`
+	MerchantID uuid.UUID `json:"merchant_id"`
+	OrderID    string    `json:"order_id"`
+	// ProviderOrderID is the order reference sent to the provider. Order IDs are only unique per
+	// merchant, while providers require them to be unique across the whole account.
+	ProviderOrderID string            `json:"provider_order_id"`
+	ExternalID      string            `json:"external_id"`
+	Provider        string            `json:"provider"`
+	PaymentMethod   string            `json:"payment_method"`
+	Amount          int64             `json:"amount"`
+	RefundedAmount  int64             `json:"refunded_amount"`
+	Currency        string            `json:"currency"`
+	Status          TransactionStatus `json:"status"`
+	PaymentURL      string            `json:"payment_url"`
+	// Livemode is false for transactions created with a test key, which go to sandbox gateways
+	Livemode    bool      `json:"livemode"`
+	RawResponse string    `json:"-"`
+	ExpiredAt   time.Time `json:"expired_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	ReconcileAttempts int `json:"-"`
+}
+
+// RefundableAmount returns how much of the transaction has not been refunded yet
+func (t *Transaction) RefundableAmount() int64 {
+	return t.Amount - t.RefundedAmount
 }
 
 type TransactionRepository interface {
 	Create(ctx context.Context, tx *Transaction) (*Transaction, error)
 	Update(ctx context.Context, tx *Transaction) (*Transaction, error)
-	Get(ctx context.Context, id uuid.UUID) (*Transaction, error)
-	FindByOrderID(ctx context.Context, orderID string) (*Transaction, error)
+	// DeleteUnstarted removes a PENDING transaction the provider never returned a payment for
+	DeleteUnstarted(ctx context.Context, id uuid.UUID) error
+	// UpdateStatus and AddRefundedAmount write event, when it is not nil, in the same database transaction as the change
+	UpdateStatus(ctx context.Context, id uuid.UUID, from TransactionStatus, to TransactionStatus, event *OutboxEvent) error
+	AddRefundedAmount(ctx context.Context, id uuid.UUID, from TransactionStatus, to TransactionStatus, amount int64, event *OutboxEvent) error
+	// ReleaseRefundedAmount gives back amount reserved by a refund the provider rejected and writes event with it
+	ReleaseRefundedAmount(ctx context.Context, id uuid.UUID, amount int64, event *OutboxEvent) error
+	GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*Transaction, error)
+	FindByProviderOrderID(ctx context.Context, providerOrderID string) (*Transaction, error)
+	FindByMerchantAndOrderID(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*Transaction, error)
+	FindByMerchantAndExternalID(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*Transaction, error)
+	List(ctx context.Context, filter *TransactionFilter) ([]*Transaction, error)
+	FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*Transaction, error)
+	FindDueForReconciliation(ctx context.Context, pendingSince time.Time, expiredSince time.Time, now time.Time, limit int) ([]*Transaction, error)
+	ScheduleReconciliation(ctx context.Context, id uuid.UUID, attempts int, nextAt time.Time) error
 }
 
+// TransactionUC only shows a merchant the transactions of the mode, test or live, of the key
+// the request was made with
 type TransactionUC interface {
-	Create(ctx context.Context, merchantID uuid.UUID, req *CreateTransactionRequest) (*Transaction, error)
-	Get(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	Create(ctx context.Context, merchantID uuid.UUID, livemode bool, req *CreateTransactionRequest) (*Transaction, error)
+	Get(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*Transaction, error)
+	GetByOrderID(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*Transaction, error)
+	GetByExternalID(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*Transaction, error)
+	List(ctx context.Context, merchantID uuid.UUID, livemode bool, req *ListTransactionsRequest) (*TransactionPage, error)
 	HandleNotification(ctx context.Context, req *UpdateStatusRequest) error
+	Cancel(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*Transaction, error)
+	ExpirePending(ctx context.Context, limit int) (int, error)
 }
 
 type Customer struct {
@@ -67,7 +157,10 @@ type CreateTransactionRequest struct {
 	Items         []Item   `json:"items" validate:"required,dive"`
 }
 
+// UpdateStatusRequest carries a provider notification, so OrderID is the provider-facing order reference.
+// Livemode tells whether it was verified with live or sandbox credentials.
 type UpdateStatusRequest struct {
-	OrderID string `json:"order_id" validate:"required"`
-	Status  string `json:"status" validate:"required,oneof=PAID FAILED EXPIRED"`
+	OrderID  string `json:"order_id" validate:"required"`
+	Status   string `json:"status" validate:"required,oneof=PAID FAILED EXPIRED"`
+	Livemode bool   `json:"livemode"`
 }
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TransactionSortCreatedAt = "created_at"
+	TransactionSortAmount    = "amount"
+
+	DefaultTransactionPageSize = 20
+	MaxTransactionPageSize     = 100
+)
+
+var (
+	ErrInvalidCursor     = errors.New("invalid pagination cursor")
+	ErrInvalidListFilter = errors.New("invalid transaction list filter")
+)
+
+// ListTransactionsRequest is bound from the query string, time filters use RFC 3339
+type ListTransactionsRequest struct {
+	Status        string    `form:"status"`
+	Provider      string    `form:"provider"`
+	PaymentMethod string    `form:"payment_method"`
+	Currency      string    `form:"currency"`
+	MinAmount     int64     `form:"min_amount"`
+	MaxAmount     int64     `form:"max_amount"`
+	CreatedFrom   time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo     time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	SortBy        string    `form:"sort_by"`
+	SortOrder     string    `form:"sort_order"`
+	Limit         int       `form:"limit"`
+	Cursor        string    `form:"cursor"`
+}
+
+// TransactionCursor marks the last row of a page. UUIDv7 IDs are time-ordered, so the ID alone
+// positions a created_at sort and breaks ties for every other sort.
+type TransactionCursor struct {
+	SortBy     string    `json:"s"`
+	Descending bool      `json:"d,omitempty"`
+	ID         uuid.UUID `json:"id"`
+	Amount     int64     `json:"a,omitempty"`
+}
+
+// TransactionFilter is the validated form of ListTransactionsRequest handed to the repository,
+// zero values mean the filter is not applied
+type TransactionFilter struct {
+	MerchantID    uuid.UUID
+	Livemode      bool
+	Status        TransactionStatus
+	Provider      string
+	PaymentMethod string
+	Currency      string
+	MinAmount     int64
+	MaxAmount     int64
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+	SortBy        string
+	Descending    bool
+	Limit         int
+	After         *TransactionCursor
+}
+
+type TransactionPage struct {
+	Transactions []*Transaction
+	NextCursor   string
+}
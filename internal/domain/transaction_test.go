@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"testing"
+
+	"go-payment-aggregator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from domain.TransactionStatus
+		to   domain.TransactionStatus
+		want bool
+	}{
+		{domain.TransactionStatusPending, domain.TransactionStatusPaid, true},
+		{domain.TransactionStatusPending, domain.TransactionStatusFailed, true},
+		{domain.TransactionStatusPending, domain.TransactionStatusExpired, true},
+		{domain.TransactionStatusPending, domain.TransactionStatusCancelled, true},
+		{domain.TransactionStatusPending, domain.TransactionStatusRefunded, false},
+		{domain.TransactionStatusPending, domain.TransactionStatusPending, false},
+		{domain.TransactionStatusPaid, domain.TransactionStatusRefunded, true},
+		{domain.TransactionStatusPaid, domain.TransactionStatusPartiallyRefunded, true},
+		{domain.TransactionStatusPaid, domain.TransactionStatusPending, false},
+		{domain.TransactionStatusPaid, domain.TransactionStatusFailed, false},
+		{domain.TransactionStatusPaid, domain.TransactionStatusExpired, false},
+		{domain.TransactionStatusPaid, domain.TransactionStatusCancelled, false},
+		{domain.TransactionStatusPartiallyRefunded, domain.TransactionStatusPartiallyRefunded, true},
+		{domain.TransactionStatusPartiallyRefunded, domain.TransactionStatusRefunded, true},
+		{domain.TransactionStatusPartiallyRefunded, domain.TransactionStatusPaid, false},
+		{domain.TransactionStatusExpired, domain.TransactionStatusPaid, true},
+		{domain.TransactionStatusExpired, domain.TransactionStatusPending, false},
+		{domain.TransactionStatusFailed, domain.TransactionStatusPaid, false},
+		{domain.TransactionStatusFailed, domain.TransactionStatusPending, false},
+		{domain.TransactionStatusRefunded, domain.TransactionStatusPaid, false},
+		{domain.TransactionStatusRefunded, domain.TransactionStatusPartiallyRefunded, false},
+		{domain.TransactionStatusCancelled, domain.TransactionStatusPaid, false},
+		{domain.TransactionStatusCancelled, domain.TransactionStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestStatusTransitionError(t *testing.T) {
+	err := &domain.StatusTransitionError{
+		From: domain.TransactionStatusPaid,
+		To:   domain.TransactionStatusPending,
+	}
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	assert.Equal(t, "invalid transaction status transition from PAID to PENDING", err.Error())
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookPayload is the job the worker picks up to notify a merchant. A job without a
+// CallbackURL announces Event and is fanned out to every URL subscribed to it, each of which
+// gets its own job.
+type WebhookPayload struct {
+	Event          string     `json:"event,omitempty"`
+	EventID        string     `json:"event_id,omitempty"`
+	EventCreatedAt time.Time  `json:"event_created_at"`
+	Data           *EventData `json:"data,omitempty"`
+	// APIVersion is the merchant's pinned version, copied onto the job when it is fanned out so
+	// retries and redeliveries keep the shape of the first attempt
+	APIVersion    string  `json:"api_version,omitempty"`
+	TransactionID string  `json:"transaction_id"`
+	MerchantID    string  `json:"merchant_id"`
+	OrderID       string  `json:"order_id"`
+	Status        string  `json:"status"`
+	Amount        float64 `json:"amount"`
+	Provider      string  `json:"provider"`
+	RefundID      string  `json:"refund_id,omitempty"`
+	RefundAmount  int64   `json:"refund_amount,omitempty"`
+	// Livemode is nil on jobs queued before test mode, which were all live
+	Livemode    *bool  `json:"livemode,omitempty"`
+	EndpointID  string `json:"endpoint_id,omitempty"`
+	CallbackURL string `json:"callback_url"`
+	RetryCount  int    `json:"retry_count"`
+	LastError   string `json:"last_error,omitempty"`
+}
+
+type WebhookPublisher interface {
+	Publish(ctx context.Context, payload *WebhookPayload) error
+}
+
+// WebhookRetryQueue keeps failed deliveries in Redis rather than in worker memory, so
+// scheduled retries and given up deliveries survive a worker restart
+type WebhookRetryQueue interface {
+	// Schedule queues payload for another delivery attempt at the given time
+	Schedule(ctx context.Context, payload *WebhookPayload, at time.Time) error
+	// PromoteDue moves up to limit retries that are due by now back onto the webhook queue
+	PromoteDue(ctx context.Context, now time.Time, limit int) (int, error)
+	// DeadLetter parks a payload that ran out of attempts
+	DeadLetter(ctx context.Context, payload *WebhookPayload) error
+	ListDeadLetters(ctx context.Context, offset int, limit int) ([]*WebhookPayload, error)
+	// ReplayDeadLetters moves up to limit of the oldest dead letters back onto the webhook queue with a fresh attempt count
+	ReplayDeadLetters(ctx context.Context, limit int) (int, error)
+}
+
+// WebhookMessage is a job read from the webhook queue. It stays pending until it is acknowledged,
+// and is handed to another consumer if it sits unacknowledged for too long.
+type WebhookMessage struct {
+	ID   string
+	Body string
+}
+
+// WebhookConsumer reads the webhook queue as one member of a consumer group, so several workers
+// share the jobs and a job taken by a crashed worker is not lost
+type WebhookConsumer interface {
+	// CreateGroup creates the consumer group if it does not exist yet
+	CreateGroup(ctx context.Context) error
+	// Read waits up to block for a new job, returning nil when none arrives
+	Read(ctx context.Context, block time.Duration) (*WebhookMessage, error)
+	// ClaimStale takes over up to count jobs that other consumers left unacknowledged for at least minIdle
+	ClaimStale(ctx context.Context, minIdle time.Duration, count int) ([]*WebhookMessage, error)
+	// Ack marks a job as handled, it is never handed out again
+	Ack(ctx context.Context, id string) error
+	// MoveLegacyQueue moves the jobs left on the list used before the stream onto the stream,
+	// returning how many it moved
+	MoveLegacyQueue(ctx context.Context) (int, error)
+}
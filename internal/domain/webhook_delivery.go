@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+
+	// WebhookDeliveryResponseSnippetSize is how much of the merchant's response body is kept per attempt
+	WebhookDeliveryResponseSnippetSize = 1024
+
+	DefaultWebhookDeliveryPageSize = 20
+	MaxWebhookDeliveryPageSize     = 100
+)
+
+var (
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidWebhookDeliveryQuery = errors.New("invalid webhook delivery list filter")
+)
+
+// WebhookDelivery records a single attempt to send a callback to a merchant
+type WebhookDelivery struct {
+	ID         uuid.UUID
+	MerchantID uuid.UUID
+	// EndpointID is uuid.Nil for deliveries to the merchant profile's callback_url
+	EndpointID    uuid.UUID
+	TransactionID uuid.UUID
+	Event         string
+	EventStatus   string
+	URL           string
+	Attempt       int
+	RequestBody   string
+	// ResponseStatus is zero when the merchant never answered
+	ResponseStatus int
+	ResponseBody   string
+	Latency        time.Duration
+	Error          string
+	Succeeded      bool
+	// Payload is the queue job the attempt was made for, kept so the delivery can be sent again
+	Payload   *WebhookPayload
+	CreatedAt time.Time
+}
+
+// ListWebhookDeliveriesRequest is bound from the query string
+type ListWebhookDeliveriesRequest struct {
+	TransactionID string `form:"transaction_id"`
+	EndpointID    string `form:"endpoint_id"`
+	Status        string `form:"status"`
+	Limit         int    `form:"limit"`
+	Cursor        string `form:"cursor"`
+}
+
+// WebhookDeliveryCursor marks the last row of a page, newest deliveries come first
+type WebhookDeliveryCursor struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// WebhookDeliveryFilter is the validated form of ListWebhookDeliveriesRequest, zero values mean
+// the filter is not applied
+type WebhookDeliveryFilter struct {
+	MerchantID    uuid.UUID
+	TransactionID uuid.UUID
+	EndpointID    uuid.UUID
+	Succeeded     *bool
+	Limit         int
+	After         *WebhookDeliveryCursor
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []*WebhookDelivery
+	NextCursor string
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*WebhookDelivery, error)
+	List(ctx context.Context, filter *WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+}
+
+type WebhookDeliveryUC interface {
+	List(ctx context.Context, merchantID uuid.UUID, req *ListWebhookDeliveriesRequest) (*WebhookDeliveryPage, error)
+	// Redeliver queues the callback of a past delivery again, sent to the current URL of the endpoint it went to
+	Redeliver(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookEventTransactionPaid      = "transaction.paid"
+	WebhookEventTransactionFailed    = "transaction.failed"
+	WebhookEventTransactionExpired   = "transaction.expired"
+	WebhookEventTransactionCancelled = "transaction.cancelled"
+	WebhookEventRefundSucceeded      = "refund.succeeded"
+	WebhookEventRefundPending        = "refund.pending"
+	WebhookEventRefundFailed         = "refund.failed"
+	// WebhookEventAll subscribes an endpoint to every event, including ones added later
+	WebhookEventAll = "*"
+
+	MaxWebhookEndpointsPerMerchant = 10
+)
+
+// WebhookEvents lists every event an endpoint can subscribe to
+var WebhookEvents = []string{
+	WebhookEventTransactionPaid,
+	WebhookEventTransactionFailed,
+	WebhookEventTransactionExpired,
+	WebhookEventTransactionCancelled,
+	WebhookEventRefundSucceeded,
+	WebhookEventRefundPending,
+	WebhookEventRefundFailed,
+}
+
+var (
+	ErrWebhookEndpointNotFound      = errors.New("webhook endpoint not found")
+	ErrInvalidWebhookEndpoint       = errors.New("invalid webhook endpoint")
+	ErrWebhookEndpointLimitExceeded = errors.New("webhook endpoint limit reached")
+)
+
+// IsValidWebhookEvent reports whether event can be subscribed to
+func IsValidWebhookEvent(event string) bool {
+	if event == WebhookEventAll {
+		return true
+	}
+	for _, known := range WebhookEvents {
+		if known == event {
+			return true
+		}
+	}
+	return false
+}
+
+// TransactionStatusEvent returns the event a transaction moving to status is announced with,
+// or an empty string when the change is not announced
+func TransactionStatusEvent(status TransactionStatus) string {
+	switch status {
+	case TransactionStatusPaid:
+		return WebhookEventTransactionPaid
+	case TransactionStatusFailed:
+		return WebhookEventTransactionFailed
+	case TransactionStatusExpired:
+		return WebhookEventTransactionExpired
+	case TransactionStatusCancelled:
+		return WebhookEventTransactionCancelled
+	default:
+		return ""
+	}
+}
+
+// WebhookEndpoint is a URL a merchant receives callbacks on, next to the profile's callback_url
+type WebhookEndpoint struct {
+	ID          uuid.UUID
+	MerchantID  uuid.UUID
+	URL         string
+	Description string
+	Events      []string
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Subscribes reports whether the endpoint wants to receive event
+func (e *WebhookEndpoint) Subscribes(event string) bool {
+	for _, subscribed := range e.Events {
+		if subscribed == WebhookEventAll || subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookEndpointRepository interface {
+	Create(ctx context.Context, endpoint *WebhookEndpoint) error
+	Update(ctx context.Context, endpoint *WebhookEndpoint) error
+	Delete(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error
+	GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*WebhookEndpoint, error)
+	ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]*WebhookEndpoint, error)
+}
+
+type WebhookEndpointUC interface {
+	Create(ctx context.Context, merchantID uuid.UUID, req *CreateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	List(ctx context.Context, merchantID uuid.UUID) ([]*WebhookEndpoint, error)
+	Get(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*WebhookEndpoint, error)
+	Update(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, req *UpdateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	Delete(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error
+}
+
+// CreateWebhookEndpointRequest creates an enabled endpoint unless Enabled is false
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,url"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	Events      []string `json:"events" validate:"required,min=1"`
+	Enabled     *bool    `json:"enabled"`
+}
+
+// UpdateWebhookEndpointRequest only changes the fields that are set
+type UpdateWebhookEndpointRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,url"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Events      []string `json:"events" validate:"omitempty,min=1"`
+	Enabled     *bool    `json:"enabled"`
+}
@@ -0,0 +1,28 @@
+package domain_test
+
+import (
+	"testing"
+
+	"go-payment-aggregator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookEndpoint_Subscribes(t *testing.T) {
+	tests := []struct {
+		events []string
+		event  string
+		want   bool
+	}{
+		{[]string{domain.WebhookEventTransactionPaid}, domain.WebhookEventTransactionPaid, true},
+		{[]string{domain.WebhookEventTransactionPaid}, domain.WebhookEventRefundSucceeded, false},
+		{[]string{domain.WebhookEventRefundPending, domain.WebhookEventRefundSucceeded}, domain.WebhookEventRefundSucceeded, true},
+		{[]string{domain.WebhookEventAll}, domain.WebhookEventTransactionExpired, true},
+		{nil, domain.WebhookEventTransactionPaid, false},
+	}
+
+	for _, tt := range tests {
+		endpoint := &domain.WebhookEndpoint{Events: tt.events}
+		assert.Equal(t, tt.want, endpoint.Subscribes(tt.event), "%v subscribes to %s", tt.events, tt.event)
+	}
+}
@@ -0,0 +1,29 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"net"
+)
+
+// wrapGatewayError tags provider failures caused by the request context so callers can tell
+// a timeout or cancellation apart from a rejected payment. SDK errors do not always wrap the
+// underlying transport error, so the context itself is checked as well.
+func wrapGatewayError(ctx context.Context, provider string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s: %w: %v", provider, domain.ErrGatewayTimeout, err)
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("%s: %w: %v", provider, domain.ErrGatewayCanceled, err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%s: %w: %v", provider, domain.ErrGatewayTimeout, err)
+	}
+
+	return err
+}
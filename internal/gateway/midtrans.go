@@ -1,8 +1,12 @@
 package gateway
 
 import (
+	"context"
+	"fmt"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg"
+	"io"
+	"net/http"
 
 	"github.com/midtrans/midtrans-go"
 	"github.com/midtrans/midtrans-go/coreapi"
@@ -20,11 +24,17 @@ type MidtransGateway struct {
 }
 
 func NewMidtransGateway(cfg MidtransConfig) domain.PaymentGateway {
+	httpClient := &midtransHttpClient{
+		HttpClientImplementation: midtrans.GetHttpClient(cfg.Env),
+	}
+
 	var s snap.Client
 	s.New(cfg.ServerKey, cfg.Env)
+	s.HttpClient = httpClient
 
 	var c coreapi.Client
 	c.New(cfg.ServerKey, cfg.Env)
+	c.HttpClient = httpClient
 
 	return &MidtransGateway{
 		snapClient: s,
@@ -32,6 +42,69 @@ func NewMidtransGateway(cfg MidtransConfig) domain.PaymentGateway {
 	}
 }
 
+// midtransHttpClient replaces the SDK transport because midtrans-go discards the
+// context set through ConfigOptions, so deadlines never reach the HTTP request
+type midtransHttpClient struct {
+	*midtrans.HttpClientImplementation
+}
+
+func (c *midtransHttpClient) Call(method string, url string, apiKey *string, options *midtrans.ConfigOptions, body io.Reader, result interface{}) *midtrans.Error {
+	ctx := context.Background()
+	if options != nil && options.Ctx != nil {
+		ctx = options.Ctx
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return &midtrans.Error{
+			Message:  "Error Request creation failed: " + err.Error(),
+			RawError: err,
+		}
+	}
+
+	if options != nil {
+		if options.PaymentIdempotencyKey != nil {
+			req.Header.Add("Idempotency-Key", *options.PaymentIdempotencyKey)
+		}
+
+		if options.PaymentOverrideNotification != nil {
+			req.Header.Add("X-Override-Notification", *options.PaymentOverrideNotification)
+		}
+
+		if options.PaymentAppendNotification != nil {
+			req.Header.Add("X-Append-Notification", *options.PaymentAppendNotification)
+		}
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+
+	if apiKey != nil {
+		if *apiKey == "" {
+			return &midtrans.Error{
+				Message: "The API Key (ServerKey) is invalid, as it is an empty string",
+			}
+		}
+		req.SetBasicAuth(*apiKey, "")
+	}
+
+	return c.DoRequest(req, result)
+}
+
+// snap returns a copy of the Snap client bound to ctx, so concurrent calls never share options
+func (g *MidtransGateway) snap(ctx context.Context) snap.Client {
+	c := g.snapClient
+	c.Options = &midtrans.ConfigOptions{Ctx: ctx}
+	return c
+}
+
+// core returns a copy of the Core API client bound to ctx
+func (g *MidtransGateway) core(ctx context.Context) coreapi.Client {
+	c := g.coreClient
+	c.Options = &midtrans.ConfigOptions{Ctx: ctx}
+	return c
+}
+
 func mapPaymentMethodToMidtrans(method string) []snap.SnapPaymentType {
 	mapping := map[string][]snap.SnapPaymentType{
 		"credit_card": {
@@ -55,7 +128,11 @@ func mapPaymentMethodToMidtrans(method string) []snap.SnapPaymentType {
 	return []snap.SnapPaymentType{}
 }
 
-func (g *MidtransGateway) CreatePayment(req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+func (g *MidtransGateway) CreatePayment(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapGatewayError(ctx, "midtrans", err)
+	}
+
 	snapReq := &snap.Request{
 		TransactionDetails: midtrans.TransactionDetails{
 			OrderID:  req.OrderID,
@@ -87,9 +164,9 @@ func (g *MidtransGateway) CreatePayment(req *domain.CreatePaymentRequest) (*doma
 		},
 	}
 
-	snapResp, err := g.snapClient.CreateTransaction(snapReq)
+	snapResp, err := g.snap(ctx).CreateTransaction(snapReq)
 	if err != nil {
-		return nil, err
+		return nil, wrapGatewayError(ctx, "midtrans", err)
 	}
 
 	return &domain.PaymentResponse{
@@ -98,13 +175,64 @@ func (g *MidtransGateway) CreatePayment(req *domain.CreatePaymentRequest) (*doma
 	}, nil
 }
 
-func (g *MidtransGateway) CheckStatus(orderID string) (string, error) {
-	res, err := g.coreClient.CheckTransaction(orderID)
+func (g *MidtransGateway) CheckStatus(ctx context.Context, req *domain.CheckStatusRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", wrapGatewayError(ctx, "midtrans", err)
+	}
+
+	res, err := g.core(ctx).CheckTransaction(req.OrderID)
 	if err != nil {
-		return "", err
+		// a Snap order only exists in the Core API once the customer picked a payment method
+		if err.GetStatusCode() == http.StatusNotFound {
+			return "", fmt.Errorf("midtrans: %w", domain.ErrPaymentNotFound)
+		}
+		return "", wrapGatewayError(ctx, "midtrans", err)
 	}
 
 	status := pkg.MapMidtransStatus(res.TransactionStatus, res.FraudStatus)
 
 	return status, nil
 }
+
+func (g *MidtransGateway) Refund(ctx context.Context, req *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapGatewayError(ctx, "midtrans", err)
+	}
+
+	res, err := g.core(ctx).RefundTransaction(req.OrderID, &coreapi.RefundReq{
+		RefundKey: req.RefundID,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, wrapGatewayError(ctx, "midtrans", err)
+	}
+
+	refundID := res.RefundChargebackUUID
+	if refundID == "" {
+		refundID = req.RefundID
+	}
+
+	return &domain.RefundPaymentResponse{
+		RefundID: refundID,
+		Status:   domain.RefundStatusSucceeded,
+	}, nil
+}
+
+func (g *MidtransGateway) Cancel(ctx context.Context, req *domain.CancelPaymentRequest) error {
+	if err := ctx.Err(); err != nil {
+		return wrapGatewayError(ctx, "midtrans", err)
+	}
+
+	// cancel only applies to card payments awaiting capture, expire is what closes a pending order
+	if _, err := g.core(ctx).ExpireTransaction(req.OrderID); err != nil {
+		// an unstarted Snap order is unknown to the Core API, so it cannot be closed while the
+		// Snap page stays payable until the order expires
+		if err.GetStatusCode() == http.StatusNotFound {
+			return domain.ErrPaymentNotStarted
+		}
+		return wrapGatewayError(ctx, "midtrans", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"context"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"strings"
+)
+
+const simulatorCheckoutURL = "https://checkout.simulator.test/pay/"
+
+// The last three digits of the amount pick how a simulated payment ends, like the magic amounts
+// of provider sandboxes. Every other amount is paid.
+const (
+	SimulatorAmountFailed  = 1
+	SimulatorAmountPending = 2
+)
+
+// SimulatorGateway stands in for a provider in test mode when no sandbox credentials are
+// configured. Nothing leaves the aggregator: the first status check reports the outcome picked
+// by the amount, so the reconciler settles paid and failed payments while pending ones are left
+// for the expiry sweeper or a cancel. Refunds succeed right away.
+type SimulatorGateway struct{}
+
+func NewSimulatorGateway() domain.PaymentGateway {
+	return &SimulatorGateway{}
+}
+
+func (g *SimulatorGateway) CreatePayment(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapGatewayError(ctx, "simulator", err)
+	}
+
+	outcome := domain.TransactionStatusPaid
+	switch req.Amount % 1000 {
+	case SimulatorAmountFailed:
+		outcome = domain.TransactionStatusFailed
+	case SimulatorAmountPending:
+		outcome = domain.TransactionStatusPending
+	}
+
+	// status checks only receive the token, so it carries the outcome
+	token := pkg.GenerateApiKey("sim_" + strings.ToLower(string(outcome)))
+
+	return &domain.PaymentResponse{
+		Token:      token,
+		PaymentURL: simulatorCheckoutURL + token,
+	}, nil
+}
+
+func (g *SimulatorGateway) CheckStatus(ctx context.Context, req *domain.CheckStatusRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", wrapGatewayError(ctx, "simulator", err)
+	}
+
+	for _, status := range []domain.TransactionStatus{domain.TransactionStatusPaid, domain.TransactionStatusFailed, domain.TransactionStatusPending} {
+		if strings.HasPrefix(req.ExternalID, "sim_"+strings.ToLower(string(status))+"_") {
+			return string(status), nil
+		}
+	}
+
+	// tokens issued before outcomes could be picked were always paid
+	if strings.HasPrefix(req.ExternalID, "sim_") {
+		return string(domain.TransactionStatusPaid), nil
+	}
+
+	return "", domain.ErrPaymentNotFound
+}
+
+func (g *SimulatorGateway) Refund(ctx context.Context, req *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapGatewayError(ctx, "simulator", err)
+	}
+
+	return &domain.RefundPaymentResponse{
+		RefundID: pkg.GenerateApiKey("sim_re"),
+		Status:   domain.RefundStatusSucceeded,
+	}, nil
+}
+
+func (g *SimulatorGateway) Cancel(ctx context.Context, req *domain.CancelPaymentRequest) error {
+	if err := ctx.Err(); err != nil {
+		return wrapGatewayError(ctx, "simulator", err)
+	}
+
+	return nil
+}
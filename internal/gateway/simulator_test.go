@@ -0,0 +1,47 @@
+package gateway_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/gateway"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatorGateway_Outcome(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     int64
+		wantStatus string
+	}{
+		{"Paid", 100000, "PAID"},
+		{"Failed", 100001, "FAILED"},
+		{"Left Pending", 100002, "PENDING"},
+	}
+
+	sim := gateway.NewSimulatorGateway()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			res, err := sim.CreatePayment(ctx, &domain.CreatePaymentRequest{OrderID: "ORDER-TEST-123", Amount: tt.amount})
+			assert.NoError(t, err)
+			assert.True(t, strings.HasSuffix(res.PaymentURL, res.Token))
+
+			status, err := sim.CheckStatus(ctx, &domain.CheckStatusRequest{OrderID: "ORDER-TEST-123", ExternalID: res.Token})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func TestSimulatorGateway_CheckStatusUnknownPayment(t *testing.T) {
+	sim := gateway.NewSimulatorGateway()
+
+	_, err := sim.CheckStatus(context.Background(), &domain.CheckStatusRequest{ExternalID: "ext-12345"})
+	assert.ErrorIs(t, err, domain.ErrPaymentNotFound)
+}
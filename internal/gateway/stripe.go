@@ -0,0 +1,275 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const stripeDefaultBaseURL = "https://api.stripe.com"
+
+// Stripe only accepts checkout sessions that live between 30 minutes and 24 hours
+const (
+	stripeMinSessionExpiry = 30 * time.Minute
+	stripeMaxSessionExpiry = 24 * time.Hour
+)
+
+type StripeConfig struct {
+	SecretKey  string
+	BaseURL    string
+	SuccessURL string
+	CancelURL  string
+}
+
+type StripeGateway struct {
+	secretKey  string
+	baseURL    string
+	successURL string
+	cancelURL  string
+	httpClient *http.Client
+}
+
+func NewStripeGateway(cfg StripeConfig) domain.PaymentGateway {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = stripeDefaultBaseURL
+	}
+
+	return &StripeGateway{
+		secretKey:  cfg.SecretKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		successURL: cfg.SuccessURL,
+		cancelURL:  cfg.CancelURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// stripeEWallets lists the wallets Checkout accepts for each currency. Stripe rejects a session
+// when any of its payment method types cannot take the currency, so IDR has no e-wallet here.
+var stripeEWallets = map[string][]string{
+	"usd": {"link", "alipay"},
+	"eur": {"link", "alipay"},
+	"gbp": {"link", "alipay"},
+	"aud": {"link", "alipay"},
+	"cad": {"link", "alipay"},
+	"hkd": {"link", "alipay"},
+	"jpy": {"link", "alipay"},
+	"nzd": {"link", "alipay"},
+	"sgd": {"link", "alipay", "grabpay"},
+	"myr": {"link", "alipay", "grabpay"},
+}
+
+// mapPaymentMethodToStripe returns the payment method types for method in currency. Methods
+// without a mapping return none, which lets Checkout pick from the account's settings.
+func mapPaymentMethodToStripe(method, currency string) ([]string, error) {
+	switch method {
+	case "credit_card":
+		// Apple Pay and Google Pay are served through the card payment method
+		return []string{"card"}, nil
+	case "e_wallet":
+		wallets, ok := stripeEWallets[strings.ToLower(currency)]
+		if !ok {
+			return nil, fmt.Errorf("%w: stripe has no e_wallet for %s", domain.ErrPaymentMethodNotSupported, strings.ToUpper(currency))
+		}
+		return wallets, nil
+	}
+	return []string{}, nil
+}
+
+type stripeCheckoutSession struct {
+	ID                string `json:"id"`
+	URL               string `json:"url"`
+	Status            string `json:"status"`
+	PaymentStatus     string `json:"payment_status"`
+	ClientReferenceID string `json:"client_reference_id"`
+	PaymentIntent     string `json:"payment_intent"`
+}
+
+type stripeRefund struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type stripeErrorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (s *StripeGateway) CreatePayment(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+	expiry := time.Duration(req.ExpiryMinutes) * time.Minute
+	if expiry < stripeMinSessionExpiry {
+		expiry = stripeMinSessionExpiry
+	}
+	if expiry > stripeMaxSessionExpiry {
+		expiry = stripeMaxSessionExpiry
+	}
+
+	currency := strings.ToLower(req.Currency)
+
+	form := url.Values{}
+	form.Set("mode", "payment")
+	form.Set("client_reference_id", req.OrderID)
+	form.Set("metadata[order_id]", req.OrderID)
+	form.Set("payment_intent_data[metadata][order_id]", req.OrderID)
+	form.Set("customer_email", req.Customer.Email)
+	form.Set("success_url", s.successURL)
+	form.Set("expires_at", strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+
+	if s.cancelURL != "" {
+		form.Set("cancel_url", s.cancelURL)
+	}
+
+	methods, err := mapPaymentMethodToStripe(req.PaymentMethod, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		form.Add("payment_method_types[]", method)
+	}
+
+	if len(req.Items) > 0 {
+		for i, item := range req.Items {
+			prefix := fmt.Sprintf("line_items[%d]", i)
+			form.Set(prefix+"[quantity]", strconv.Itoa(int(item.Quantity)))
+			form.Set(prefix+"[price_data][currency]", currency)
+			form.Set(prefix+"[price_data][unit_amount]", strconv.FormatInt(pkg.ToStripeAmount(item.Price, req.Currency), 10))
+			form.Set(prefix+"[price_data][product_data][name]", item.Name)
+		}
+	} else {
+		form.Set("line_items[0][quantity]", "1")
+		form.Set("line_items[0][price_data][currency]", currency)
+		form.Set("line_items[0][price_data][unit_amount]", strconv.FormatInt(pkg.ToStripeAmount(req.Amount, req.Currency), 10))
+		form.Set("line_items[0][price_data][product_data][name]", req.OrderID)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/v1/checkout/sessions", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var session stripeCheckoutSession
+	if err := s.do(httpReq, &session); err != nil {
+		return nil, err
+	}
+
+	return &domain.PaymentResponse{
+		Token:      session.ID,
+		PaymentURL: session.URL,
+	}, nil
+}
+
+func (s *StripeGateway) CheckStatus(ctx context.Context, req *domain.CheckStatusRequest) (string, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/v1/checkout/sessions/"+url.PathEscape(req.ExternalID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var session stripeCheckoutSession
+	if err := s.do(httpReq, &session); err != nil {
+		return "", err
+	}
+
+	status := pkg.MapStripeStatus(session.Status, session.PaymentStatus)
+
+	return status, nil
+}
+
+func (s *StripeGateway) Refund(ctx context.Context, req *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error) {
+	sessionReq, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/v1/checkout/sessions/"+url.PathEscape(req.ExternalID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var session stripeCheckoutSession
+	if err := s.do(sessionReq, &session); err != nil {
+		return nil, err
+	}
+
+	if session.PaymentIntent == "" {
+		return nil, errors.New("stripe: checkout session has no payment to refund")
+	}
+
+	form := url.Values{}
+	form.Set("payment_intent", session.PaymentIntent)
+	form.Set("amount", strconv.FormatInt(pkg.ToStripeAmount(req.Amount, req.Currency), 10))
+	form.Set("reason", "requested_by_customer")
+	form.Set("metadata[order_id]", req.OrderID)
+	form.Set("metadata[refund_id]", req.RefundID)
+	if req.Reason != "" {
+		form.Set("metadata[reason]", req.Reason)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/v1/refunds", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Idempotency-Key", req.RefundID)
+
+	var ref stripeRefund
+	if err := s.do(httpReq, &ref); err != nil {
+		return nil, err
+	}
+
+	return &domain.RefundPaymentResponse{
+		RefundID: ref.ID,
+		Status:   domain.RefundStatus(pkg.MapStripeRefundStatus(ref.Status)),
+	}, nil
+}
+
+func (s *StripeGateway) Cancel(ctx context.Context, req *domain.CancelPaymentRequest) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/v1/checkout/sessions/"+url.PathEscape(req.ExternalID)+"/expire", nil)
+	if err != nil {
+		return err
+	}
+
+	var session stripeCheckoutSession
+	if err := s.do(httpReq, &session); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StripeGateway) do(req *http.Request, out any) error {
+	req.SetBasicAuth(s.secretKey, "")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return wrapGatewayError(req.Context(), "stripe", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return wrapGatewayError(req.Context(), "stripe", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var stripeErr stripeErrorResponse
+		if err := json.Unmarshal(body, &stripeErr); err != nil || stripeErr.Error.Message == "" {
+			return fmt.Errorf("stripe: unexpected status %d", resp.StatusCode)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("stripe: %w: %s", domain.ErrPaymentNotFound, stripeErr.Error.Message)
+		}
+		return errors.New("stripe: " + stripeErr.Error.Message)
+	}
+
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,264 @@
+package gateway_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/gateway"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripeGateway_CreatePayment(t *testing.T) {
+	req := &domain.CreatePaymentRequest{
+		OrderID:       "ORDER-TEST-123",
+		Amount:        100000,
+		PaymentMethod: "credit_card",
+		Currency:      "IDR",
+		ExpiryMinutes: 2,
+		Customer: domain.Customer{
+			Name:  "user",
+			Email: "user@example.com",
+		},
+		Items: []domain.Item{
+			{Name: "Item 1", Quantity: 2, Price: 50000},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr bool
+	}{
+		{
+			name: "Success Create Checkout Session",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v1/checkout/sessions", r.URL.Path)
+
+				user, _, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "sk_test_123", user)
+
+				assert.NoError(t, r.ParseForm())
+				assert.Equal(t, "payment", r.PostForm.Get("mode"))
+				assert.Equal(t, req.OrderID, r.PostForm.Get("client_reference_id"))
+				assert.Equal(t, []string{"card"}, r.PostForm["payment_method_types[]"])
+				assert.Equal(t, "idr", r.PostForm.Get("line_items[0][price_data][currency]"))
+				assert.Equal(t, "5000000", r.PostForm.Get("line_items[0][price_data][unit_amount]"))
+				assert.Equal(t, "2", r.PostForm.Get("line_items[0][quantity]"))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":"cs_test_123","url":"https://checkout.stripe.com/c/pay/cs_test_123","status":"open","payment_status":"unpaid"}`))
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Stripe Error Response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"Invalid currency"}}`))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			stripeGateway := gateway.NewStripeGateway(gateway.StripeConfig{
+				SecretKey:  "sk_test_123",
+				BaseURL:    server.URL,
+				SuccessURL: "https://example.com/success",
+			})
+
+			res, err := stripeGateway.CreatePayment(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "cs_test_123", res.Token)
+				assert.Equal(t, "https://checkout.stripe.com/c/pay/cs_test_123", res.PaymentURL)
+			}
+		})
+	}
+}
+
+func TestStripeGateway_CreatePaymentMethodTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		paymentMethod string
+		currency      string
+		want          []string
+		errIs         error
+	}{
+		{"Card In IDR", "credit_card", "IDR", []string{"card"}, nil},
+		{"E-Wallet In USD", "e_wallet", "USD", []string{"link", "alipay"}, nil},
+		{"E-Wallet In SGD", "e_wallet", "SGD", []string{"link", "alipay", "grabpay"}, nil},
+		{"Failed E-Wallet In IDR", "e_wallet", "IDR", nil, domain.ErrPaymentMethodNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				assert.NoError(t, r.ParseForm())
+				assert.Equal(t, tt.want, r.PostForm["payment_method_types[]"])
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":"cs_test_123","url":"https://checkout.stripe.com/c/pay/cs_test_123","status":"open","payment_status":"unpaid"}`))
+			}))
+			defer server.Close()
+
+			stripeGateway := gateway.NewStripeGateway(gateway.StripeConfig{
+				SecretKey:  "sk_test_123",
+				BaseURL:    server.URL,
+				SuccessURL: "https://example.com/success",
+			})
+
+			_, err := stripeGateway.CreatePayment(context.Background(), &domain.CreatePaymentRequest{
+				OrderID:       "ORDER-TEST-123",
+				Amount:        100000,
+				PaymentMethod: tt.paymentMethod,
+				Currency:      tt.currency,
+				ExpiryMinutes: 2,
+				Customer:      domain.Customer{Name: "user", Email: "user@example.com"},
+			})
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.False(t, called, "an unsupported pair never reaches Stripe")
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, called)
+			}
+		})
+	}
+}
+
+func TestStripeGateway_CheckStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name:       "Paid Session",
+			body:       `{"id":"cs_test_123","status":"complete","payment_status":"paid"}`,
+			statusCode: http.StatusOK,
+			want:       "PAID",
+		},
+		{
+			name:       "Open Session",
+			body:       `{"id":"cs_test_123","status":"open","payment_status":"unpaid"}`,
+			statusCode: http.StatusOK,
+			want:       "PENDING",
+		},
+		{
+			name:       "Expired Session",
+			body:       `{"id":"cs_test_123","status":"expired","payment_status":"unpaid"}`,
+			statusCode: http.StatusOK,
+			want:       "EXPIRED",
+		},
+		{
+			name:       "Session Not Found",
+			body:       `{"error":{"type":"invalid_request_error","message":"No such checkout.session"}}`,
+			statusCode: http.StatusNotFound,
+			wantErr:    true,
+			errIs:      domain.ErrPaymentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/v1/checkout/sessions/cs_test_123", r.URL.Path)
+
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			stripeGateway := gateway.NewStripeGateway(gateway.StripeConfig{
+				SecretKey: "sk_test_123",
+				BaseURL:   server.URL,
+			})
+
+			status, err := stripeGateway.CheckStatus(context.Background(), &domain.CheckStatusRequest{
+				OrderID:    "ORDER-TEST-123",
+				ExternalID: "cs_test_123",
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, status)
+			}
+		})
+	}
+}
+
+func TestStripeGateway_CheckStatusTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	stripeGateway := gateway.NewStripeGateway(gateway.StripeConfig{
+		SecretKey: "sk_test_123",
+		BaseURL:   server.URL,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := stripeGateway.CheckStatus(ctx, &domain.CheckStatusRequest{
+		OrderID:    "ORDER-TEST-123",
+		ExternalID: "cs_test_123",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrGatewayTimeout)
+}
+
+func TestStripeGateway_Cancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/checkout/sessions/cs_test_123/expire", r.URL.Path)
+
+		w.Write([]byte(`{"id":"cs_test_123","status":"expired","payment_status":"unpaid"}`))
+	}))
+	defer server.Close()
+
+	stripeGateway := gateway.NewStripeGateway(gateway.StripeConfig{
+		SecretKey: "sk_test_123",
+		BaseURL:   server.URL,
+	})
+
+	err := stripeGateway.Cancel(context.Background(), &domain.CancelPaymentRequest{
+		OrderID:    "ORDER-TEST-123",
+		ExternalID: "cs_test_123",
+	})
+
+	assert.NoError(t, err)
+}
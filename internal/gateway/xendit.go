@@ -2,11 +2,13 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg"
 
 	"github.com/xendit/xendit-go/v7"
 	"github.com/xendit/xendit-go/v7/invoice"
+	"github.com/xendit/xendit-go/v7/refund"
 )
 
 type XenditConfig struct {
@@ -46,7 +48,10 @@ func mapPaymentMethodToXendit(method string) []string {
 	return []string{}
 }
 
-func (x *XenditGateway) CreatePayment(req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+func (x *XenditGateway) CreatePayment(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapGatewayError(ctx, "xendit", err)
+	}
 
 	invoiceDurationSeconds := float32(req.ExpiryMinutes * 60)
 
@@ -76,10 +81,9 @@ func (x *XenditGateway) CreatePayment(req *domain.CreatePaymentRequest) (*domain
 		InvoiceDuration: &invoiceDurationSeconds,
 	}
 
-	ctx := context.Background()
 	inv, _, err := x.xenditClient.InvoiceApi.CreateInvoice(ctx).CreateInvoiceRequest(reqInvoice).Execute()
 	if err != nil {
-		return nil, err
+		return nil, wrapGatewayError(ctx, "xendit", err)
 	}
 
 	return &domain.PaymentResponse{
@@ -88,14 +92,66 @@ func (x *XenditGateway) CreatePayment(req *domain.CreatePaymentRequest) (*domain
 	}, nil
 }
 
-func (x *XenditGateway) CheckStatus(orderID string) (string, error) {
-	ctx := context.Background()
-	inv, _, err := x.xenditClient.InvoiceApi.GetInvoiceById(ctx, orderID).Execute()
+func (x *XenditGateway) CheckStatus(ctx context.Context, req *domain.CheckStatusRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", wrapGatewayError(ctx, "xendit", err)
+	}
+
+	inv, _, err := x.xenditClient.InvoiceApi.GetInvoiceById(ctx, req.ExternalID).Execute()
 	if err != nil {
-		return "", err
+		if err.ErrorCode() == "INVOICE_NOT_FOUND_ERROR" {
+			return "", fmt.Errorf("xendit: %w", domain.ErrPaymentNotFound)
+		}
+		return "", wrapGatewayError(ctx, "xendit", err)
 	}
 
 	status := pkg.MapXenditStatus(inv.Status.String())
 
 	return status, nil
 }
+
+func (x *XenditGateway) Refund(ctx context.Context, req *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapGatewayError(ctx, "xendit", err)
+	}
+
+	amount := float64(req.Amount)
+	// Xendit only accepts a fixed set of reasons, the merchant's own text travels as metadata
+	reason := "REQUESTED_BY_CUSTOMER"
+
+	reqRefund := refund.CreateRefund{
+		InvoiceId:   &req.ExternalID,
+		ReferenceId: &req.RefundID,
+		Amount:      &amount,
+		Currency:    &req.Currency,
+		Reason:      &reason,
+		Metadata: map[string]interface{}{
+			"order_id": req.OrderID,
+			"reason":   req.Reason,
+		},
+	}
+
+	ref, _, err := x.xenditClient.RefundApi.CreateRefund(ctx).IdempotencyKey(req.RefundID).CreateRefund(reqRefund).Execute()
+	if err != nil {
+		return nil, wrapGatewayError(ctx, "xendit", err)
+	}
+
+	// Xendit settles refunds asynchronously
+	return &domain.RefundPaymentResponse{
+		RefundID: ref.GetId(),
+		Status:   domain.RefundStatusPending,
+	}, nil
+}
+
+func (x *XenditGateway) Cancel(ctx context.Context, req *domain.CancelPaymentRequest) error {
+	if err := ctx.Err(); err != nil {
+		return wrapGatewayError(ctx, "xendit", err)
+	}
+
+	// Xendit has no cancel for invoices, expiring it closes the payment page immediately
+	if _, _, err := x.xenditClient.InvoiceApi.ExpireInvoice(ctx, req.ExternalID).Execute(); err != nil {
+		return wrapGatewayError(ctx, "xendit", err)
+	}
+
+	return nil
+}
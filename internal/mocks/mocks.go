@@ -7,18 +7,19 @@ package mocks
 import (
 	"context"
 	"go-payment-aggregator/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockPaymentGateway creates a new instance of MockPaymentGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockApiKeyRepository creates a new instance of MockApiKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockPaymentGateway(t interface {
+func NewMockApiKeyRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockPaymentGateway {
-	mock := &MockPaymentGateway{}
+}) *MockApiKeyRepository {
+	mock := &MockApiKeyRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,217 +27,125 @@ func NewMockPaymentGateway(t interface {
 	return mock
 }
 
-// MockPaymentGateway is an autogenerated mock type for the PaymentGateway type
-type MockPaymentGateway struct {
+// MockApiKeyRepository is an autogenerated mock type for the ApiKeyRepository type
+type MockApiKeyRepository struct {
 	mock.Mock
 }
 
-type MockPaymentGateway_Expecter struct {
+type MockApiKeyRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockPaymentGateway) EXPECT() *MockPaymentGateway_Expecter {
-	return &MockPaymentGateway_Expecter{mock: &_m.Mock}
+func (_m *MockApiKeyRepository) EXPECT() *MockApiKeyRepository_Expecter {
+	return &MockApiKeyRepository_Expecter{mock: &_m.Mock}
 }
 
-// CheckStatus provides a mock function for the type MockPaymentGateway
-func (_mock *MockPaymentGateway) CheckStatus(orderID string) (string, error) {
-	ret := _mock.Called(orderID)
+// Create provides a mock function for the type MockApiKeyRepository
+func (_mock *MockApiKeyRepository) Create(ctx context.Context, key *domain.ApiKey) error {
+	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckStatus")
+		panic("no return value specified for Create")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(orderID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(orderID)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(orderID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ApiKey) error); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockPaymentGateway_CheckStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckStatus'
-type MockPaymentGateway_CheckStatus_Call struct {
+// MockApiKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockApiKeyRepository_Create_Call struct {
 	*mock.Call
 }
 
-// CheckStatus is a helper method to define mock.On call
-//   - orderID string
-func (_e *MockPaymentGateway_Expecter) CheckStatus(orderID interface{}) *MockPaymentGateway_CheckStatus_Call {
-	return &MockPaymentGateway_CheckStatus_Call{Call: _e.mock.On("CheckStatus", orderID)}
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *domain.ApiKey
+func (_e *MockApiKeyRepository_Expecter) Create(ctx interface{}, key interface{}) *MockApiKeyRepository_Create_Call {
+	return &MockApiKeyRepository_Create_Call{Call: _e.mock.On("Create", ctx, key)}
 }
 
-func (_c *MockPaymentGateway_CheckStatus_Call) Run(run func(orderID string)) *MockPaymentGateway_CheckStatus_Call {
+func (_c *MockApiKeyRepository_Create_Call) Run(run func(ctx context.Context, key *domain.ApiKey)) *MockApiKeyRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
-		}
-		run(
-			arg0,
-		)
-	})
-	return _c
-}
-
-func (_c *MockPaymentGateway_CheckStatus_Call) Return(s string, err error) *MockPaymentGateway_CheckStatus_Call {
-	_c.Call.Return(s, err)
-	return _c
-}
-
-func (_c *MockPaymentGateway_CheckStatus_Call) RunAndReturn(run func(orderID string) (string, error)) *MockPaymentGateway_CheckStatus_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// CreatePayment provides a mock function for the type MockPaymentGateway
-func (_mock *MockPaymentGateway) CreatePayment(tx *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
-	ret := _mock.Called(tx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CreatePayment")
-	}
-
-	var r0 *domain.PaymentResponse
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*domain.CreatePaymentRequest) (*domain.PaymentResponse, error)); ok {
-		return returnFunc(tx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.CreatePaymentRequest) *domain.PaymentResponse); ok {
-		r0 = returnFunc(tx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.PaymentResponse)
+			arg0 = args[0].(context.Context)
 		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.CreatePaymentRequest) error); ok {
-		r1 = returnFunc(tx)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockPaymentGateway_CreatePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePayment'
-type MockPaymentGateway_CreatePayment_Call struct {
-	*mock.Call
-}
-
-// CreatePayment is a helper method to define mock.On call
-//   - tx *domain.CreatePaymentRequest
-func (_e *MockPaymentGateway_Expecter) CreatePayment(tx interface{}) *MockPaymentGateway_CreatePayment_Call {
-	return &MockPaymentGateway_CreatePayment_Call{Call: _e.mock.On("CreatePayment", tx)}
-}
-
-func (_c *MockPaymentGateway_CreatePayment_Call) Run(run func(tx *domain.CreatePaymentRequest)) *MockPaymentGateway_CreatePayment_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.CreatePaymentRequest
-		if args[0] != nil {
-			arg0 = args[0].(*domain.CreatePaymentRequest)
+		var arg1 *domain.ApiKey
+		if args[1] != nil {
+			arg1 = args[1].(*domain.ApiKey)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPaymentGateway_CreatePayment_Call) Return(paymentResponse *domain.PaymentResponse, err error) *MockPaymentGateway_CreatePayment_Call {
-	_c.Call.Return(paymentResponse, err)
+func (_c *MockApiKeyRepository_Create_Call) Return(err error) *MockApiKeyRepository_Create_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPaymentGateway_CreatePayment_Call) RunAndReturn(run func(tx *domain.CreatePaymentRequest) (*domain.PaymentResponse, error)) *MockPaymentGateway_CreatePayment_Call {
+func (_c *MockApiKeyRepository_Create_Call) RunAndReturn(run func(ctx context.Context, key *domain.ApiKey) error) *MockApiKeyRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMerchantRepository creates a new instance of MockMerchantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockMerchantRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockMerchantRepository {
-	mock := &MockMerchantRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockMerchantRepository is an autogenerated mock type for the MerchantRepository type
-type MockMerchantRepository struct {
-	mock.Mock
-}
-
-type MockMerchantRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockMerchantRepository) EXPECT() *MockMerchantRepository_Expecter {
-	return &MockMerchantRepository_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockMerchantRepository
-func (_mock *MockMerchantRepository) Create(ctx context.Context, m *domain.Merchant) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, m)
+// FindByHash provides a mock function for the type MockApiKeyRepository
+func (_mock *MockApiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.ApiKey, error) {
+	ret := _mock.Called(ctx, keyHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for FindByHash")
 	}
 
-	var r0 *domain.Merchant
+	var r0 *domain.ApiKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Merchant) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, m)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.ApiKey, error)); ok {
+		return returnFunc(ctx, keyHash)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Merchant) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, m)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.ApiKey); ok {
+		r0 = returnFunc(ctx, keyHash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Merchant)
+			r0 = ret.Get(0).(*domain.ApiKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Merchant) error); ok {
-		r1 = returnFunc(ctx, m)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, keyHash)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMerchantRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockMerchantRepository_Create_Call struct {
+// MockApiKeyRepository_FindByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHash'
+type MockApiKeyRepository_FindByHash_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// FindByHash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - m *domain.Merchant
-func (_e *MockMerchantRepository_Expecter) Create(ctx interface{}, m interface{}) *MockMerchantRepository_Create_Call {
-	return &MockMerchantRepository_Create_Call{Call: _e.mock.On("Create", ctx, m)}
+//   - keyHash string
+func (_e *MockApiKeyRepository_Expecter) FindByHash(ctx interface{}, keyHash interface{}) *MockApiKeyRepository_FindByHash_Call {
+	return &MockApiKeyRepository_FindByHash_Call{Call: _e.mock.On("FindByHash", ctx, keyHash)}
 }
 
-func (_c *MockMerchantRepository_Create_Call) Run(run func(ctx context.Context, m *domain.Merchant)) *MockMerchantRepository_Create_Call {
+func (_c *MockApiKeyRepository_FindByHash_Call) Run(run func(ctx context.Context, keyHash string)) *MockApiKeyRepository_FindByHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Merchant
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Merchant)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -246,125 +155,131 @@ func (_c *MockMerchantRepository_Create_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockMerchantRepository_Create_Call) Return(merchant *domain.Merchant, err error) *MockMerchantRepository_Create_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockApiKeyRepository_FindByHash_Call) Return(apiKey *domain.ApiKey, err error) *MockApiKeyRepository_FindByHash_Call {
+	_c.Call.Return(apiKey, err)
 	return _c
 }
 
-func (_c *MockMerchantRepository_Create_Call) RunAndReturn(run func(ctx context.Context, m *domain.Merchant) (*domain.Merchant, error)) *MockMerchantRepository_Create_Call {
+func (_c *MockApiKeyRepository_FindByHash_Call) RunAndReturn(run func(ctx context.Context, keyHash string) (*domain.ApiKey, error)) *MockApiKeyRepository_FindByHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByApiKey provides a mock function for the type MockMerchantRepository
-func (_mock *MockMerchantRepository) FindByApiKey(ctx context.Context, apiKey string) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, apiKey)
+// GetByMerchant provides a mock function for the type MockApiKeyRepository
+func (_mock *MockApiKeyRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.ApiKey, error) {
+	ret := _mock.Called(ctx, merchantID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByApiKey")
+		panic("no return value specified for GetByMerchant")
 	}
 
-	var r0 *domain.Merchant
+	var r0 *domain.ApiKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, apiKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.ApiKey, error)); ok {
+		return returnFunc(ctx, merchantID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, apiKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.ApiKey); ok {
+		r0 = returnFunc(ctx, merchantID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Merchant)
+			r0 = ret.Get(0).(*domain.ApiKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, apiKey)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMerchantRepository_FindByApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByApiKey'
-type MockMerchantRepository_FindByApiKey_Call struct {
+// MockApiKeyRepository_GetByMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByMerchant'
+type MockApiKeyRepository_GetByMerchant_Call struct {
 	*mock.Call
 }
 
-// FindByApiKey is a helper method to define mock.On call
+// GetByMerchant is a helper method to define mock.On call
 //   - ctx context.Context
-//   - apiKey string
-func (_e *MockMerchantRepository_Expecter) FindByApiKey(ctx interface{}, apiKey interface{}) *MockMerchantRepository_FindByApiKey_Call {
-	return &MockMerchantRepository_FindByApiKey_Call{Call: _e.mock.On("FindByApiKey", ctx, apiKey)}
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockApiKeyRepository_Expecter) GetByMerchant(ctx interface{}, merchantID interface{}, id interface{}) *MockApiKeyRepository_GetByMerchant_Call {
+	return &MockApiKeyRepository_GetByMerchant_Call{Call: _e.mock.On("GetByMerchant", ctx, merchantID, id)}
 }
 
-func (_c *MockMerchantRepository_FindByApiKey_Call) Run(run func(ctx context.Context, apiKey string)) *MockMerchantRepository_FindByApiKey_Call {
+func (_c *MockApiKeyRepository_GetByMerchant_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockApiKeyRepository_GetByMerchant_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMerchantRepository_FindByApiKey_Call) Return(merchant *domain.Merchant, err error) *MockMerchantRepository_FindByApiKey_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockApiKeyRepository_GetByMerchant_Call) Return(apiKey *domain.ApiKey, err error) *MockApiKeyRepository_GetByMerchant_Call {
+	_c.Call.Return(apiKey, err)
 	return _c
 }
 
-func (_c *MockMerchantRepository_FindByApiKey_Call) RunAndReturn(run func(ctx context.Context, apiKey string) (*domain.Merchant, error)) *MockMerchantRepository_FindByApiKey_Call {
+func (_c *MockApiKeyRepository_GetByMerchant_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.ApiKey, error)) *MockApiKeyRepository_GetByMerchant_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByID provides a mock function for the type MockMerchantRepository
-func (_mock *MockMerchantRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, id)
+// ListByMerchant provides a mock function for the type MockApiKeyRepository
+func (_mock *MockApiKeyRepository) ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]*domain.ApiKey, error) {
+	ret := _mock.Called(ctx, merchantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByID")
+		panic("no return value specified for ListByMerchant")
 	}
 
-	var r0 *domain.Merchant
+	var r0 []*domain.ApiKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.ApiKey, error)); ok {
+		return returnFunc(ctx, merchantID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.ApiKey); ok {
+		r0 = returnFunc(ctx, merchantID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Merchant)
+			r0 = ret.Get(0).([]*domain.ApiKey)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, merchantID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMerchantRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
-type MockMerchantRepository_FindByID_Call struct {
+// MockApiKeyRepository_ListByMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMerchant'
+type MockApiKeyRepository_ListByMerchant_Call struct {
 	*mock.Call
 }
 
-// FindByID is a helper method to define mock.On call
+// ListByMerchant is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockMerchantRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockMerchantRepository_FindByID_Call {
-	return &MockMerchantRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+//   - merchantID uuid.UUID
+func (_e *MockApiKeyRepository_Expecter) ListByMerchant(ctx interface{}, merchantID interface{}) *MockApiKeyRepository_ListByMerchant_Call {
+	return &MockApiKeyRepository_ListByMerchant_Call{Call: _e.mock.On("ListByMerchant", ctx, merchantID)}
 }
 
-func (_c *MockMerchantRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMerchantRepository_FindByID_Call {
+func (_c *MockApiKeyRepository_ListByMerchant_Call) Run(run func(ctx context.Context, merchantID uuid.UUID)) *MockApiKeyRepository_ListByMerchant_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -382,47 +297,48 @@ func (_c *MockMerchantRepository_FindByID_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockMerchantRepository_FindByID_Call) Return(merchant *domain.Merchant, err error) *MockMerchantRepository_FindByID_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockApiKeyRepository_ListByMerchant_Call) Return(apiKeys []*domain.ApiKey, err error) *MockApiKeyRepository_ListByMerchant_Call {
+	_c.Call.Return(apiKeys, err)
 	return _c
 }
 
-func (_c *MockMerchantRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Merchant, error)) *MockMerchantRepository_FindByID_Call {
+func (_c *MockApiKeyRepository_ListByMerchant_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID) ([]*domain.ApiKey, error)) *MockApiKeyRepository_ListByMerchant_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegenerateApiKey provides a mock function for the type MockMerchantRepository
-func (_mock *MockMerchantRepository) RegenerateApiKey(ctx context.Context, id uuid.UUID, newApiKey string) error {
-	ret := _mock.Called(ctx, id, newApiKey)
+// Revoke provides a mock function for the type MockApiKeyRepository
+func (_mock *MockApiKeyRepository) Revoke(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, revokedAt time.Time) error {
+	ret := _mock.Called(ctx, merchantID, id, revokedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RegenerateApiKey")
+		panic("no return value specified for Revoke")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
-		r0 = returnFunc(ctx, id, newApiKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, merchantID, id, revokedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockMerchantRepository_RegenerateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateApiKey'
-type MockMerchantRepository_RegenerateApiKey_Call struct {
+// MockApiKeyRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockApiKeyRepository_Revoke_Call struct {
 	*mock.Call
 }
 
-// RegenerateApiKey is a helper method to define mock.On call
+// Revoke is a helper method to define mock.On call
 //   - ctx context.Context
+//   - merchantID uuid.UUID
 //   - id uuid.UUID
-//   - newApiKey string
-func (_e *MockMerchantRepository_Expecter) RegenerateApiKey(ctx interface{}, id interface{}, newApiKey interface{}) *MockMerchantRepository_RegenerateApiKey_Call {
-	return &MockMerchantRepository_RegenerateApiKey_Call{Call: _e.mock.On("RegenerateApiKey", ctx, id, newApiKey)}
+//   - revokedAt time.Time
+func (_e *MockApiKeyRepository_Expecter) Revoke(ctx interface{}, merchantID interface{}, id interface{}, revokedAt interface{}) *MockApiKeyRepository_Revoke_Call {
+	return &MockApiKeyRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, merchantID, id, revokedAt)}
 }
 
-func (_c *MockMerchantRepository_RegenerateApiKey_Call) Run(run func(ctx context.Context, id uuid.UUID, newApiKey string)) *MockMerchantRepository_RegenerateApiKey_Call {
+func (_c *MockApiKeyRepository_Revoke_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, revokedAt time.Time)) *MockApiKeyRepository_Revoke_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -432,93 +348,104 @@ func (_c *MockMerchantRepository_RegenerateApiKey_Call) Run(run func(ctx context
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 string
+		var arg2 uuid.UUID
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMerchantRepository_RegenerateApiKey_Call) Return(err error) *MockMerchantRepository_RegenerateApiKey_Call {
+func (_c *MockApiKeyRepository_Revoke_Call) Return(err error) *MockApiKeyRepository_Revoke_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMerchantRepository_RegenerateApiKey_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, newApiKey string) error) *MockMerchantRepository_RegenerateApiKey_Call {
+func (_c *MockApiKeyRepository_Revoke_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, revokedAt time.Time) error) *MockApiKeyRepository_Revoke_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockMerchantRepository
-func (_mock *MockMerchantRepository) Update(ctx context.Context, m *domain.Merchant) error {
-	ret := _mock.Called(ctx, m)
+// TouchLastUsed provides a mock function for the type MockApiKeyRepository
+func (_mock *MockApiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	ret := _mock.Called(ctx, id, usedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for TouchLastUsed")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Merchant) error); ok {
-		r0 = returnFunc(ctx, m)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, usedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockMerchantRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockMerchantRepository_Update_Call struct {
+// MockApiKeyRepository_TouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastUsed'
+type MockApiKeyRepository_TouchLastUsed_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// TouchLastUsed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - m *domain.Merchant
-func (_e *MockMerchantRepository_Expecter) Update(ctx interface{}, m interface{}) *MockMerchantRepository_Update_Call {
-	return &MockMerchantRepository_Update_Call{Call: _e.mock.On("Update", ctx, m)}
+//   - id uuid.UUID
+//   - usedAt time.Time
+func (_e *MockApiKeyRepository_Expecter) TouchLastUsed(ctx interface{}, id interface{}, usedAt interface{}) *MockApiKeyRepository_TouchLastUsed_Call {
+	return &MockApiKeyRepository_TouchLastUsed_Call{Call: _e.mock.On("TouchLastUsed", ctx, id, usedAt)}
 }
 
-func (_c *MockMerchantRepository_Update_Call) Run(run func(ctx context.Context, m *domain.Merchant)) *MockMerchantRepository_Update_Call {
+func (_c *MockApiKeyRepository_TouchLastUsed_Call) Run(run func(ctx context.Context, id uuid.UUID, usedAt time.Time)) *MockApiKeyRepository_TouchLastUsed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Merchant
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Merchant)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMerchantRepository_Update_Call) Return(err error) *MockMerchantRepository_Update_Call {
+func (_c *MockApiKeyRepository_TouchLastUsed_Call) Return(err error) *MockApiKeyRepository_TouchLastUsed_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMerchantRepository_Update_Call) RunAndReturn(run func(ctx context.Context, m *domain.Merchant) error) *MockMerchantRepository_Update_Call {
+func (_c *MockApiKeyRepository_TouchLastUsed_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, usedAt time.Time) error) *MockApiKeyRepository_TouchLastUsed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMerchantUC creates a new instance of MockMerchantUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockApiKeyUC creates a new instance of MockApiKeyUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockMerchantUC(t interface {
+func NewMockApiKeyUC(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockMerchantUC {
-	mock := &MockMerchantUC{}
+}) *MockApiKeyUC {
+	mock := &MockApiKeyUC{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -526,68 +453,5456 @@ func NewMockMerchantUC(t interface {
 	return mock
 }
 
-// MockMerchantUC is an autogenerated mock type for the MerchantUC type
-type MockMerchantUC struct {
+// MockApiKeyUC is an autogenerated mock type for the ApiKeyUC type
+type MockApiKeyUC struct {
 	mock.Mock
 }
 
-type MockMerchantUC_Expecter struct {
+type MockApiKeyUC_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockMerchantUC) EXPECT() *MockMerchantUC_Expecter {
-	return &MockMerchantUC_Expecter{mock: &_m.Mock}
+func (_m *MockApiKeyUC) EXPECT() *MockApiKeyUC_Expecter {
+	return &MockApiKeyUC_Expecter{mock: &_m.Mock}
 }
 
-// GetProfile provides a mock function for the type MockMerchantUC
-func (_mock *MockMerchantUC) GetProfile(ctx context.Context, id uuid.UUID) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, id)
+// Authenticate provides a mock function for the type MockApiKeyUC
+func (_mock *MockApiKeyUC) Authenticate(ctx context.Context, apiKey string) (*domain.Merchant, *domain.ApiKey, error) {
+	ret := _mock.Called(ctx, apiKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProfile")
+		panic("no return value specified for Authenticate")
 	}
 
 	var r0 *domain.Merchant
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, id)
+	var r1 *domain.ApiKey
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Merchant, *domain.ApiKey, error)); ok {
+		return returnFunc(ctx, apiKey)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, apiKey)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Merchant)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) *domain.ApiKey); ok {
+		r1 = returnFunc(ctx, apiKey)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ApiKey)
+		}
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, apiKey)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockMerchantUC_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
-type MockMerchantUC_GetProfile_Call struct {
+// MockApiKeyUC_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockApiKeyUC_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey string
+func (_e *MockApiKeyUC_Expecter) Authenticate(ctx interface{}, apiKey interface{}) *MockApiKeyUC_Authenticate_Call {
+	return &MockApiKeyUC_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, apiKey)}
+}
+
+func (_c *MockApiKeyUC_Authenticate_Call) Run(run func(ctx context.Context, apiKey string)) *MockApiKeyUC_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApiKeyUC_Authenticate_Call) Return(merchant *domain.Merchant, apiKey1 *domain.ApiKey, err error) *MockApiKeyUC_Authenticate_Call {
+	_c.Call.Return(merchant, apiKey1, err)
+	return _c
+}
+
+func (_c *MockApiKeyUC_Authenticate_Call) RunAndReturn(run func(ctx context.Context, apiKey string) (*domain.Merchant, *domain.ApiKey, error)) *MockApiKeyUC_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockApiKeyUC
+func (_mock *MockApiKeyUC) Create(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateApiKeyRequest) (*domain.ApiKey, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.ApiKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, *domain.CreateApiKeyRequest) (*domain.ApiKey, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, *domain.CreateApiKeyRequest) *domain.ApiKey); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ApiKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, *domain.CreateApiKeyRequest) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApiKeyUC_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockApiKeyUC_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - req *domain.CreateApiKeyRequest
+func (_e *MockApiKeyUC_Expecter) Create(ctx interface{}, merchantID interface{}, livemode interface{}, req interface{}) *MockApiKeyUC_Create_Call {
+	return &MockApiKeyUC_Create_Call{Call: _e.mock.On("Create", ctx, merchantID, livemode, req)}
+}
+
+func (_c *MockApiKeyUC_Create_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateApiKeyRequest)) *MockApiKeyUC_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 *domain.CreateApiKeyRequest
+		if args[3] != nil {
+			arg3 = args[3].(*domain.CreateApiKeyRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApiKeyUC_Create_Call) Return(apiKey *domain.ApiKey, err error) *MockApiKeyUC_Create_Call {
+	_c.Call.Return(apiKey, err)
+	return _c
+}
+
+func (_c *MockApiKeyUC_Create_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateApiKeyRequest) (*domain.ApiKey, error)) *MockApiKeyUC_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockApiKeyUC
+func (_mock *MockApiKeyUC) List(ctx context.Context, merchantID uuid.UUID, livemode bool) ([]*domain.ApiKey, error) {
+	ret := _mock.Called(ctx, merchantID, livemode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.ApiKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) ([]*domain.ApiKey, error)); ok {
+		return returnFunc(ctx, merchantID, livemode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) []*domain.ApiKey); ok {
+		r0 = returnFunc(ctx, merchantID, livemode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ApiKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApiKeyUC_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockApiKeyUC_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+func (_e *MockApiKeyUC_Expecter) List(ctx interface{}, merchantID interface{}, livemode interface{}) *MockApiKeyUC_List_Call {
+	return &MockApiKeyUC_List_Call{Call: _e.mock.On("List", ctx, merchantID, livemode)}
+}
+
+func (_c *MockApiKeyUC_List_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool)) *MockApiKeyUC_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApiKeyUC_List_Call) Return(apiKeys []*domain.ApiKey, err error) *MockApiKeyUC_List_Call {
+	_c.Call.Return(apiKeys, err)
+	return _c
+}
+
+func (_c *MockApiKeyUC_List_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool) ([]*domain.ApiKey, error)) *MockApiKeyUC_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function for the type MockApiKeyUC
+func (_mock *MockApiKeyUC) Revoke(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.ApiKey, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 *domain.ApiKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) (*domain.ApiKey, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) *domain.ApiKey); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ApiKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApiKeyUC_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockApiKeyUC_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - id uuid.UUID
+func (_e *MockApiKeyUC_Expecter) Revoke(ctx interface{}, merchantID interface{}, livemode interface{}, id interface{}) *MockApiKeyUC_Revoke_Call {
+	return &MockApiKeyUC_Revoke_Call{Call: _e.mock.On("Revoke", ctx, merchantID, livemode, id)}
+}
+
+func (_c *MockApiKeyUC_Revoke_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID)) *MockApiKeyUC_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApiKeyUC_Revoke_Call) Return(apiKey *domain.ApiKey, err error) *MockApiKeyUC_Revoke_Call {
+	_c.Call.Return(apiKey, err)
+	return _c
+}
+
+func (_c *MockApiKeyUC_Revoke_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.ApiKey, error)) *MockApiKeyUC_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentGateway creates a new instance of MockPaymentGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentGateway(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentGateway {
+	mock := &MockPaymentGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPaymentGateway is an autogenerated mock type for the PaymentGateway type
+type MockPaymentGateway struct {
+	mock.Mock
+}
+
+type MockPaymentGateway_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentGateway) EXPECT() *MockPaymentGateway_Expecter {
+	return &MockPaymentGateway_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function for the type MockPaymentGateway
+func (_mock *MockPaymentGateway) Cancel(ctx context.Context, req *domain.CancelPaymentRequest) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.CancelPaymentRequest) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPaymentGateway_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockPaymentGateway_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.CancelPaymentRequest
+func (_e *MockPaymentGateway_Expecter) Cancel(ctx interface{}, req interface{}) *MockPaymentGateway_Cancel_Call {
+	return &MockPaymentGateway_Cancel_Call{Call: _e.mock.On("Cancel", ctx, req)}
+}
+
+func (_c *MockPaymentGateway_Cancel_Call) Run(run func(ctx context.Context, req *domain.CancelPaymentRequest)) *MockPaymentGateway_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.CancelPaymentRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.CancelPaymentRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPaymentGateway_Cancel_Call) Return(err error) *MockPaymentGateway_Cancel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPaymentGateway_Cancel_Call) RunAndReturn(run func(ctx context.Context, req *domain.CancelPaymentRequest) error) *MockPaymentGateway_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckStatus provides a mock function for the type MockPaymentGateway
+func (_mock *MockPaymentGateway) CheckStatus(ctx context.Context, req *domain.CheckStatusRequest) (string, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckStatus")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.CheckStatusRequest) (string, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.CheckStatusRequest) string); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.CheckStatusRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPaymentGateway_CheckStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckStatus'
+type MockPaymentGateway_CheckStatus_Call struct {
+	*mock.Call
+}
+
+// CheckStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.CheckStatusRequest
+func (_e *MockPaymentGateway_Expecter) CheckStatus(ctx interface{}, req interface{}) *MockPaymentGateway_CheckStatus_Call {
+	return &MockPaymentGateway_CheckStatus_Call{Call: _e.mock.On("CheckStatus", ctx, req)}
+}
+
+func (_c *MockPaymentGateway_CheckStatus_Call) Run(run func(ctx context.Context, req *domain.CheckStatusRequest)) *MockPaymentGateway_CheckStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.CheckStatusRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.CheckStatusRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPaymentGateway_CheckStatus_Call) Return(s string, err error) *MockPaymentGateway_CheckStatus_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockPaymentGateway_CheckStatus_Call) RunAndReturn(run func(ctx context.Context, req *domain.CheckStatusRequest) (string, error)) *MockPaymentGateway_CheckStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePayment provides a mock function for the type MockPaymentGateway
+func (_mock *MockPaymentGateway) CreatePayment(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayment")
+	}
+
+	var r0 *domain.PaymentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.CreatePaymentRequest) (*domain.PaymentResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.CreatePaymentRequest) *domain.PaymentResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PaymentResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.CreatePaymentRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPaymentGateway_CreatePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePayment'
+type MockPaymentGateway_CreatePayment_Call struct {
+	*mock.Call
+}
+
+// CreatePayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.CreatePaymentRequest
+func (_e *MockPaymentGateway_Expecter) CreatePayment(ctx interface{}, req interface{}) *MockPaymentGateway_CreatePayment_Call {
+	return &MockPaymentGateway_CreatePayment_Call{Call: _e.mock.On("CreatePayment", ctx, req)}
+}
+
+func (_c *MockPaymentGateway_CreatePayment_Call) Run(run func(ctx context.Context, req *domain.CreatePaymentRequest)) *MockPaymentGateway_CreatePayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.CreatePaymentRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.CreatePaymentRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPaymentGateway_CreatePayment_Call) Return(paymentResponse *domain.PaymentResponse, err error) *MockPaymentGateway_CreatePayment_Call {
+	_c.Call.Return(paymentResponse, err)
+	return _c
+}
+
+func (_c *MockPaymentGateway_CreatePayment_Call) RunAndReturn(run func(ctx context.Context, req *domain.CreatePaymentRequest) (*domain.PaymentResponse, error)) *MockPaymentGateway_CreatePayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refund provides a mock function for the type MockPaymentGateway
+func (_mock *MockPaymentGateway) Refund(ctx context.Context, req *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 *domain.RefundPaymentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RefundPaymentRequest) *domain.RefundPaymentResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RefundPaymentResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.RefundPaymentRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPaymentGateway_Refund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refund'
+type MockPaymentGateway_Refund_Call struct {
+	*mock.Call
+}
+
+// Refund is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.RefundPaymentRequest
+func (_e *MockPaymentGateway_Expecter) Refund(ctx interface{}, req interface{}) *MockPaymentGateway_Refund_Call {
+	return &MockPaymentGateway_Refund_Call{Call: _e.mock.On("Refund", ctx, req)}
+}
+
+func (_c *MockPaymentGateway_Refund_Call) Run(run func(ctx context.Context, req *domain.RefundPaymentRequest)) *MockPaymentGateway_Refund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.RefundPaymentRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.RefundPaymentRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPaymentGateway_Refund_Call) Return(refundPaymentResponse *domain.RefundPaymentResponse, err error) *MockPaymentGateway_Refund_Call {
+	_c.Call.Return(refundPaymentResponse, err)
+	return _c
+}
+
+func (_c *MockPaymentGateway_Refund_Call) RunAndReturn(run func(ctx context.Context, req *domain.RefundPaymentRequest) (*domain.RefundPaymentResponse, error)) *MockPaymentGateway_Refund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyStore creates a new instance of MockIdempotencyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIdempotencyStore is an autogenerated mock type for the IdempotencyStore type
+type MockIdempotencyStore struct {
+	mock.Mock
+}
+
+type MockIdempotencyStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyStore) EXPECT() *MockIdempotencyStore_Expecter {
+	return &MockIdempotencyStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockIdempotencyStore
+func (_mock *MockIdempotencyStore) Delete(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIdempotencyStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIdempotencyStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) Delete(ctx interface{}, key interface{}) *MockIdempotencyStore_Delete_Call {
+	return &MockIdempotencyStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_Delete_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Delete_Call) Return(err error) *MockIdempotencyStore_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Delete_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockIdempotencyStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockIdempotencyStore
+func (_mock *MockIdempotencyStore) Get(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.IdempotencyRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.IdempotencyRecord, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.IdempotencyRecord); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIdempotencyStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIdempotencyStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) Get(ctx interface{}, key interface{}) *MockIdempotencyStore_Get_Call {
+	return &MockIdempotencyStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Get_Call) Return(idempotencyRecord *domain.IdempotencyRecord, err error) *MockIdempotencyStore_Get_Call {
+	_c.Call.Return(idempotencyRecord, err)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Get_Call) RunAndReturn(run func(ctx context.Context, key string) (*domain.IdempotencyRecord, error)) *MockIdempotencyStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function for the type MockIdempotencyStore
+func (_mock *MockIdempotencyStore) Reserve(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	ret := _mock.Called(ctx, key, record, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.IdempotencyRecord, time.Duration) (bool, error)); ok {
+		return returnFunc(ctx, key, record, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.IdempotencyRecord, time.Duration) bool); ok {
+		r0 = returnFunc(ctx, key, record, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.IdempotencyRecord, time.Duration) error); ok {
+		r1 = returnFunc(ctx, key, record, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIdempotencyStore_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type MockIdempotencyStore_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - record *domain.IdempotencyRecord
+//   - ttl time.Duration
+func (_e *MockIdempotencyStore_Expecter) Reserve(ctx interface{}, key interface{}, record interface{}, ttl interface{}) *MockIdempotencyStore_Reserve_Call {
+	return &MockIdempotencyStore_Reserve_Call{Call: _e.mock.On("Reserve", ctx, key, record, ttl)}
+}
+
+func (_c *MockIdempotencyStore_Reserve_Call) Run(run func(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration)) *MockIdempotencyStore_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.IdempotencyRecord
+		if args[2] != nil {
+			arg2 = args[2].(*domain.IdempotencyRecord)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Reserve_Call) Return(b bool, err error) *MockIdempotencyStore_Reserve_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Reserve_Call) RunAndReturn(run func(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error)) *MockIdempotencyStore_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockIdempotencyStore
+func (_mock *MockIdempotencyStore) Save(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) error {
+	ret := _mock.Called(ctx, key, record, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.IdempotencyRecord, time.Duration) error); ok {
+		r0 = returnFunc(ctx, key, record, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIdempotencyStore_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockIdempotencyStore_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - record *domain.IdempotencyRecord
+//   - ttl time.Duration
+func (_e *MockIdempotencyStore_Expecter) Save(ctx interface{}, key interface{}, record interface{}, ttl interface{}) *MockIdempotencyStore_Save_Call {
+	return &MockIdempotencyStore_Save_Call{Call: _e.mock.On("Save", ctx, key, record, ttl)}
+}
+
+func (_c *MockIdempotencyStore_Save_Call) Run(run func(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration)) *MockIdempotencyStore_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.IdempotencyRecord
+		if args[2] != nil {
+			arg2 = args[2].(*domain.IdempotencyRecord)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Save_Call) Return(err error) *MockIdempotencyStore_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Save_Call) RunAndReturn(run func(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) error) *MockIdempotencyStore_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyUC creates a new instance of MockIdempotencyUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyUC {
+	mock := &MockIdempotencyUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIdempotencyUC is an autogenerated mock type for the IdempotencyUC type
+type MockIdempotencyUC struct {
+	mock.Mock
+}
+
+type MockIdempotencyUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyUC) EXPECT() *MockIdempotencyUC_Expecter {
+	return &MockIdempotencyUC_Expecter{mock: &_m.Mock}
+}
+
+// Begin provides a mock function for the type MockIdempotencyUC
+func (_mock *MockIdempotencyUC) Begin(ctx context.Context, merchantID uuid.UUID, key string, requestHash string) (*domain.IdempotencyRecord, error) {
+	ret := _mock.Called(ctx, merchantID, key, requestHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 *domain.IdempotencyRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (*domain.IdempotencyRecord, error)); ok {
+		return returnFunc(ctx, merchantID, key, requestHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) *domain.IdempotencyRecord); ok {
+		r0 = returnFunc(ctx, merchantID, key, requestHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = returnFunc(ctx, merchantID, key, requestHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIdempotencyUC_Begin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Begin'
+type MockIdempotencyUC_Begin_Call struct {
+	*mock.Call
+}
+
+// Begin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - key string
+//   - requestHash string
+func (_e *MockIdempotencyUC_Expecter) Begin(ctx interface{}, merchantID interface{}, key interface{}, requestHash interface{}) *MockIdempotencyUC_Begin_Call {
+	return &MockIdempotencyUC_Begin_Call{Call: _e.mock.On("Begin", ctx, merchantID, key, requestHash)}
+}
+
+func (_c *MockIdempotencyUC_Begin_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, key string, requestHash string)) *MockIdempotencyUC_Begin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyUC_Begin_Call) Return(idempotencyRecord *domain.IdempotencyRecord, err error) *MockIdempotencyUC_Begin_Call {
+	_c.Call.Return(idempotencyRecord, err)
+	return _c
+}
+
+func (_c *MockIdempotencyUC_Begin_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, key string, requestHash string) (*domain.IdempotencyRecord, error)) *MockIdempotencyUC_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function for the type MockIdempotencyUC
+func (_mock *MockIdempotencyUC) Complete(ctx context.Context, merchantID uuid.UUID, key string, record *domain.IdempotencyRecord) error {
+	ret := _mock.Called(ctx, merchantID, key, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *domain.IdempotencyRecord) error); ok {
+		r0 = returnFunc(ctx, merchantID, key, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIdempotencyUC_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockIdempotencyUC_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - key string
+//   - record *domain.IdempotencyRecord
+func (_e *MockIdempotencyUC_Expecter) Complete(ctx interface{}, merchantID interface{}, key interface{}, record interface{}) *MockIdempotencyUC_Complete_Call {
+	return &MockIdempotencyUC_Complete_Call{Call: _e.mock.On("Complete", ctx, merchantID, key, record)}
+}
+
+func (_c *MockIdempotencyUC_Complete_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, key string, record *domain.IdempotencyRecord)) *MockIdempotencyUC_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.IdempotencyRecord
+		if args[3] != nil {
+			arg3 = args[3].(*domain.IdempotencyRecord)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyUC_Complete_Call) Return(err error) *MockIdempotencyUC_Complete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIdempotencyUC_Complete_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, key string, record *domain.IdempotencyRecord) error) *MockIdempotencyUC_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function for the type MockIdempotencyUC
+func (_mock *MockIdempotencyUC) Release(ctx context.Context, merchantID uuid.UUID, key string) error {
+	ret := _mock.Called(ctx, merchantID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, merchantID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIdempotencyUC_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockIdempotencyUC_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - key string
+func (_e *MockIdempotencyUC_Expecter) Release(ctx interface{}, merchantID interface{}, key interface{}) *MockIdempotencyUC_Release_Call {
+	return &MockIdempotencyUC_Release_Call{Call: _e.mock.On("Release", ctx, merchantID, key)}
+}
+
+func (_c *MockIdempotencyUC_Release_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, key string)) *MockIdempotencyUC_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyUC_Release_Call) Return(err error) *MockIdempotencyUC_Release_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIdempotencyUC_Release_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, key string) error) *MockIdempotencyUC_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMerchantRepository creates a new instance of MockMerchantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMerchantRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMerchantRepository {
+	mock := &MockMerchantRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMerchantRepository is an autogenerated mock type for the MerchantRepository type
+type MockMerchantRepository struct {
+	mock.Mock
+}
+
+type MockMerchantRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMerchantRepository) EXPECT() *MockMerchantRepository_Expecter {
+	return &MockMerchantRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockMerchantRepository
+func (_mock *MockMerchantRepository) Create(ctx context.Context, m *domain.Merchant) (*domain.Merchant, error) {
+	ret := _mock.Called(ctx, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Merchant) (*domain.Merchant, error)); ok {
+		return returnFunc(ctx, m)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Merchant) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Merchant) error); ok {
+		r1 = returnFunc(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockMerchantRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - m *domain.Merchant
+func (_e *MockMerchantRepository_Expecter) Create(ctx interface{}, m interface{}) *MockMerchantRepository_Create_Call {
+	return &MockMerchantRepository_Create_Call{Call: _e.mock.On("Create", ctx, m)}
+}
+
+func (_c *MockMerchantRepository_Create_Call) Run(run func(ctx context.Context, m *domain.Merchant)) *MockMerchantRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Merchant
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Merchant)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantRepository_Create_Call) Return(merchant *domain.Merchant, err error) *MockMerchantRepository_Create_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantRepository_Create_Call) RunAndReturn(run func(ctx context.Context, m *domain.Merchant) (*domain.Merchant, error)) *MockMerchantRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByApiKey provides a mock function for the type MockMerchantRepository
+func (_mock *MockMerchantRepository) FindByApiKey(ctx context.Context, apiKey string) (*domain.Merchant, error) {
+	ret := _mock.Called(ctx, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByApiKey")
+	}
+
+	var r0 *domain.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Merchant, error)); ok {
+		return returnFunc(ctx, apiKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, apiKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantRepository_FindByApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByApiKey'
+type MockMerchantRepository_FindByApiKey_Call struct {
+	*mock.Call
+}
+
+// FindByApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey string
+func (_e *MockMerchantRepository_Expecter) FindByApiKey(ctx interface{}, apiKey interface{}) *MockMerchantRepository_FindByApiKey_Call {
+	return &MockMerchantRepository_FindByApiKey_Call{Call: _e.mock.On("FindByApiKey", ctx, apiKey)}
+}
+
+func (_c *MockMerchantRepository_FindByApiKey_Call) Run(run func(ctx context.Context, apiKey string)) *MockMerchantRepository_FindByApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantRepository_FindByApiKey_Call) Return(merchant *domain.Merchant, err error) *MockMerchantRepository_FindByApiKey_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantRepository_FindByApiKey_Call) RunAndReturn(run func(ctx context.Context, apiKey string) (*domain.Merchant, error)) *MockMerchantRepository_FindByApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockMerchantRepository
+func (_mock *MockMerchantRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Merchant, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Merchant, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockMerchantRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockMerchantRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockMerchantRepository_FindByID_Call {
+	return &MockMerchantRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockMerchantRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMerchantRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantRepository_FindByID_Call) Return(merchant *domain.Merchant, err error) *MockMerchantRepository_FindByID_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Merchant, error)) *MockMerchantRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateApiKey provides a mock function for the type MockMerchantRepository
+func (_mock *MockMerchantRepository) RegenerateApiKey(ctx context.Context, id uuid.UUID, livemode bool, newApiKey string, previousExpiresAt *time.Time) error {
+	ret := _mock.Called(ctx, id, livemode, newApiKey, previousExpiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateApiKey")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string, *time.Time) error); ok {
+		r0 = returnFunc(ctx, id, livemode, newApiKey, previousExpiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMerchantRepository_RegenerateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateApiKey'
+type MockMerchantRepository_RegenerateApiKey_Call struct {
+	*mock.Call
+}
+
+// RegenerateApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - livemode bool
+//   - newApiKey string
+//   - previousExpiresAt *time.Time
+func (_e *MockMerchantRepository_Expecter) RegenerateApiKey(ctx interface{}, id interface{}, livemode interface{}, newApiKey interface{}, previousExpiresAt interface{}) *MockMerchantRepository_RegenerateApiKey_Call {
+	return &MockMerchantRepository_RegenerateApiKey_Call{Call: _e.mock.On("RegenerateApiKey", ctx, id, livemode, newApiKey, previousExpiresAt)}
+}
+
+func (_c *MockMerchantRepository_RegenerateApiKey_Call) Run(run func(ctx context.Context, id uuid.UUID, livemode bool, newApiKey string, previousExpiresAt *time.Time)) *MockMerchantRepository_RegenerateApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 *time.Time
+		if args[4] != nil {
+			arg4 = args[4].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantRepository_RegenerateApiKey_Call) Return(err error) *MockMerchantRepository_RegenerateApiKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMerchantRepository_RegenerateApiKey_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, livemode bool, newApiKey string, previousExpiresAt *time.Time) error) *MockMerchantRepository_RegenerateApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateWebhookSecret provides a mock function for the type MockMerchantRepository
+func (_mock *MockMerchantRepository) RotateWebhookSecret(ctx context.Context, id uuid.UUID, newSecret string) error {
+	ret := _mock.Called(ctx, id, newSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateWebhookSecret")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, id, newSecret)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMerchantRepository_RotateWebhookSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateWebhookSecret'
+type MockMerchantRepository_RotateWebhookSecret_Call struct {
+	*mock.Call
+}
+
+// RotateWebhookSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - newSecret string
+func (_e *MockMerchantRepository_Expecter) RotateWebhookSecret(ctx interface{}, id interface{}, newSecret interface{}) *MockMerchantRepository_RotateWebhookSecret_Call {
+	return &MockMerchantRepository_RotateWebhookSecret_Call{Call: _e.mock.On("RotateWebhookSecret", ctx, id, newSecret)}
+}
+
+func (_c *MockMerchantRepository_RotateWebhookSecret_Call) Run(run func(ctx context.Context, id uuid.UUID, newSecret string)) *MockMerchantRepository_RotateWebhookSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantRepository_RotateWebhookSecret_Call) Return(err error) *MockMerchantRepository_RotateWebhookSecret_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMerchantRepository_RotateWebhookSecret_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, newSecret string) error) *MockMerchantRepository_RotateWebhookSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockMerchantRepository
+func (_mock *MockMerchantRepository) Update(ctx context.Context, m *domain.Merchant) error {
+	ret := _mock.Called(ctx, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Merchant) error); ok {
+		r0 = returnFunc(ctx, m)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMerchantRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockMerchantRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - m *domain.Merchant
+func (_e *MockMerchantRepository_Expecter) Update(ctx interface{}, m interface{}) *MockMerchantRepository_Update_Call {
+	return &MockMerchantRepository_Update_Call{Call: _e.mock.On("Update", ctx, m)}
+}
+
+func (_c *MockMerchantRepository_Update_Call) Run(run func(ctx context.Context, m *domain.Merchant)) *MockMerchantRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Merchant
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Merchant)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantRepository_Update_Call) Return(err error) *MockMerchantRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMerchantRepository_Update_Call) RunAndReturn(run func(ctx context.Context, m *domain.Merchant) error) *MockMerchantRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMerchantUC creates a new instance of MockMerchantUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMerchantUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMerchantUC {
+	mock := &MockMerchantUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMerchantUC is an autogenerated mock type for the MerchantUC type
+type MockMerchantUC struct {
+	mock.Mock
+}
+
+type MockMerchantUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMerchantUC) EXPECT() *MockMerchantUC_Expecter {
+	return &MockMerchantUC_Expecter{mock: &_m.Mock}
+}
+
+// GetProfile provides a mock function for the type MockMerchantUC
+func (_mock *MockMerchantUC) GetProfile(ctx context.Context, id uuid.UUID) (*domain.Merchant, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *domain.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Merchant, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantUC_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type MockMerchantUC_GetProfile_Call struct {
 	*mock.Call
 }
 
 // GetProfile is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockMerchantUC_Expecter) GetProfile(ctx interface{}, id interface{}) *MockMerchantUC_GetProfile_Call {
-	return &MockMerchantUC_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, id)}
+//   - id uuid.UUID
+func (_e *MockMerchantUC_Expecter) GetProfile(ctx interface{}, id interface{}) *MockMerchantUC_GetProfile_Call {
+	return &MockMerchantUC_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, id)}
+}
+
+func (_c *MockMerchantUC_GetProfile_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMerchantUC_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantUC_GetProfile_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_GetProfile_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantUC_GetProfile_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Merchant, error)) *MockMerchantUC_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateApiKey provides a mock function for the type MockMerchantUC
+func (_mock *MockMerchantUC) RegenerateApiKey(ctx context.Context, id uuid.UUID, livemode bool, gracePeriod time.Duration) (string, *time.Time, error) {
+	ret := _mock.Called(ctx, id, livemode, gracePeriod)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateApiKey")
+	}
+
+	var r0 string
+	var r1 *time.Time
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, time.Duration) (string, *time.Time, error)); ok {
+		return returnFunc(ctx, id, livemode, gracePeriod)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, time.Duration) string); ok {
+		r0 = returnFunc(ctx, id, livemode, gracePeriod)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, time.Duration) *time.Time); ok {
+		r1 = returnFunc(ctx, id, livemode, gracePeriod)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*time.Time)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uuid.UUID, bool, time.Duration) error); ok {
+		r2 = returnFunc(ctx, id, livemode, gracePeriod)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockMerchantUC_RegenerateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateApiKey'
+type MockMerchantUC_RegenerateApiKey_Call struct {
+	*mock.Call
+}
+
+// RegenerateApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - livemode bool
+//   - gracePeriod time.Duration
+func (_e *MockMerchantUC_Expecter) RegenerateApiKey(ctx interface{}, id interface{}, livemode interface{}, gracePeriod interface{}) *MockMerchantUC_RegenerateApiKey_Call {
+	return &MockMerchantUC_RegenerateApiKey_Call{Call: _e.mock.On("RegenerateApiKey", ctx, id, livemode, gracePeriod)}
+}
+
+func (_c *MockMerchantUC_RegenerateApiKey_Call) Run(run func(ctx context.Context, id uuid.UUID, livemode bool, gracePeriod time.Duration)) *MockMerchantUC_RegenerateApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantUC_RegenerateApiKey_Call) Return(s string, time *time.Time, err error) *MockMerchantUC_RegenerateApiKey_Call {
+	_c.Call.Return(s, time, err)
+	return _c
+}
+
+func (_c *MockMerchantUC_RegenerateApiKey_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, livemode bool, gracePeriod time.Duration) (string, *time.Time, error)) *MockMerchantUC_RegenerateApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function for the type MockMerchantUC
+func (_mock *MockMerchantUC) Register(ctx context.Context, req *domain.RegisterMerchantRequest) (*domain.Merchant, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 *domain.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RegisterMerchantRequest) (*domain.Merchant, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RegisterMerchantRequest) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.RegisterMerchantRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantUC_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockMerchantUC_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.RegisterMerchantRequest
+func (_e *MockMerchantUC_Expecter) Register(ctx interface{}, req interface{}) *MockMerchantUC_Register_Call {
+	return &MockMerchantUC_Register_Call{Call: _e.mock.On("Register", ctx, req)}
+}
+
+func (_c *MockMerchantUC_Register_Call) Run(run func(ctx context.Context, req *domain.RegisterMerchantRequest)) *MockMerchantUC_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.RegisterMerchantRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.RegisterMerchantRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantUC_Register_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_Register_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantUC_Register_Call) RunAndReturn(run func(ctx context.Context, req *domain.RegisterMerchantRequest) (*domain.Merchant, error)) *MockMerchantUC_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateWebhookSecret provides a mock function for the type MockMerchantUC
+func (_mock *MockMerchantUC) RotateWebhookSecret(ctx context.Context, id uuid.UUID) (string, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateWebhookSecret")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantUC_RotateWebhookSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateWebhookSecret'
+type MockMerchantUC_RotateWebhookSecret_Call struct {
+	*mock.Call
+}
+
+// RotateWebhookSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockMerchantUC_Expecter) RotateWebhookSecret(ctx interface{}, id interface{}) *MockMerchantUC_RotateWebhookSecret_Call {
+	return &MockMerchantUC_RotateWebhookSecret_Call{Call: _e.mock.On("RotateWebhookSecret", ctx, id)}
+}
+
+func (_c *MockMerchantUC_RotateWebhookSecret_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMerchantUC_RotateWebhookSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantUC_RotateWebhookSecret_Call) Return(s string, err error) *MockMerchantUC_RotateWebhookSecret_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockMerchantUC_RotateWebhookSecret_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (string, error)) *MockMerchantUC_RotateWebhookSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function for the type MockMerchantUC
+func (_mock *MockMerchantUC) UpdateProfile(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest) (*domain.Merchant, error) {
+	ret := _mock.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *domain.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.UpdateMerchantRequest) (*domain.Merchant, error)); ok {
+		return returnFunc(ctx, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.UpdateMerchantRequest) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *domain.UpdateMerchantRequest) error); ok {
+		r1 = returnFunc(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantUC_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockMerchantUC_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - req *domain.UpdateMerchantRequest
+func (_e *MockMerchantUC_Expecter) UpdateProfile(ctx interface{}, id interface{}, req interface{}) *MockMerchantUC_UpdateProfile_Call {
+	return &MockMerchantUC_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, id, req)}
+}
+
+func (_c *MockMerchantUC_UpdateProfile_Call) Run(run func(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest)) *MockMerchantUC_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *domain.UpdateMerchantRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.UpdateMerchantRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantUC_UpdateProfile_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_UpdateProfile_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantUC_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest) (*domain.Merchant, error)) *MockMerchantUC_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateApiKey provides a mock function for the type MockMerchantUC
+func (_mock *MockMerchantUC) ValidateApiKey(ctx context.Context, apiKey string) (*domain.Merchant, *time.Time, error) {
+	ret := _mock.Called(ctx, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateApiKey")
+	}
+
+	var r0 *domain.Merchant
+	var r1 *time.Time
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Merchant, *time.Time, error)); ok {
+		return returnFunc(ctx, apiKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Merchant); ok {
+		r0 = returnFunc(ctx, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) *time.Time); ok {
+		r1 = returnFunc(ctx, apiKey)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*time.Time)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, apiKey)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockMerchantUC_ValidateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateApiKey'
+type MockMerchantUC_ValidateApiKey_Call struct {
+	*mock.Call
+}
+
+// ValidateApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey string
+func (_e *MockMerchantUC_Expecter) ValidateApiKey(ctx interface{}, apiKey interface{}) *MockMerchantUC_ValidateApiKey_Call {
+	return &MockMerchantUC_ValidateApiKey_Call{Call: _e.mock.On("ValidateApiKey", ctx, apiKey)}
+}
+
+func (_c *MockMerchantUC_ValidateApiKey_Call) Run(run func(ctx context.Context, apiKey string)) *MockMerchantUC_ValidateApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantUC_ValidateApiKey_Call) Return(merchant *domain.Merchant, time *time.Time, err error) *MockMerchantUC_ValidateApiKey_Call {
+	_c.Call.Return(merchant, time, err)
+	return _c
+}
+
+func (_c *MockMerchantUC_ValidateApiKey_Call) RunAndReturn(run func(ctx context.Context, apiKey string) (*domain.Merchant, *time.Time, error)) *MockMerchantUC_ValidateApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxRepository creates a new instance of MockOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRepository {
+	mock := &MockOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxRepository is an autogenerated mock type for the OutboxRepository type
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+type MockOutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRepository) EXPECT() *MockOutboxRepository_Expecter {
+	return &MockOutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// PublishPending provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) PublishPending(ctx context.Context, limit int, publish domain.OutboxPublishFunc) (int, error) {
+	ret := _mock.Called(ctx, limit, publish)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishPending")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.OutboxPublishFunc) (int, error)); ok {
+		return returnFunc(ctx, limit, publish)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.OutboxPublishFunc) int); ok {
+		r0 = returnFunc(ctx, limit, publish)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.OutboxPublishFunc) error); ok {
+		r1 = returnFunc(ctx, limit, publish)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_PublishPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishPending'
+type MockOutboxRepository_PublishPending_Call struct {
+	*mock.Call
+}
+
+// PublishPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - publish domain.OutboxPublishFunc
+func (_e *MockOutboxRepository_Expecter) PublishPending(ctx interface{}, limit interface{}, publish interface{}) *MockOutboxRepository_PublishPending_Call {
+	return &MockOutboxRepository_PublishPending_Call{Call: _e.mock.On("PublishPending", ctx, limit, publish)}
+}
+
+func (_c *MockOutboxRepository_PublishPending_Call) Run(run func(ctx context.Context, limit int, publish domain.OutboxPublishFunc)) *MockOutboxRepository_PublishPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.OutboxPublishFunc
+		if args[2] != nil {
+			arg2 = args[2].(domain.OutboxPublishFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_PublishPending_Call) Return(n int, err error) *MockOutboxRepository_PublishPending_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_PublishPending_Call) RunAndReturn(run func(ctx context.Context, limit int, publish domain.OutboxPublishFunc) (int, error)) *MockOutboxRepository_PublishPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxRelayUC creates a new instance of MockOutboxRelayUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRelayUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRelayUC {
+	mock := &MockOutboxRelayUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxRelayUC is an autogenerated mock type for the OutboxRelayUC type
+type MockOutboxRelayUC struct {
+	mock.Mock
+}
+
+type MockOutboxRelayUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRelayUC) EXPECT() *MockOutboxRelayUC_Expecter {
+	return &MockOutboxRelayUC_Expecter{mock: &_m.Mock}
+}
+
+// Relay provides a mock function for the type MockOutboxRelayUC
+func (_mock *MockOutboxRelayUC) Relay(ctx context.Context, limit int) (int, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Relay")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRelayUC_Relay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Relay'
+type MockOutboxRelayUC_Relay_Call struct {
+	*mock.Call
+}
+
+// Relay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockOutboxRelayUC_Expecter) Relay(ctx interface{}, limit interface{}) *MockOutboxRelayUC_Relay_Call {
+	return &MockOutboxRelayUC_Relay_Call{Call: _e.mock.On("Relay", ctx, limit)}
+}
+
+func (_c *MockOutboxRelayUC_Relay_Call) Run(run func(ctx context.Context, limit int)) *MockOutboxRelayUC_Relay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRelayUC_Relay_Call) Return(n int, err error) *MockOutboxRelayUC_Relay_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockOutboxRelayUC_Relay_Call) RunAndReturn(run func(ctx context.Context, limit int) (int, error)) *MockOutboxRelayUC_Relay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReconciliationUC creates a new instance of MockReconciliationUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationUC {
+	mock := &MockReconciliationUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReconciliationUC is an autogenerated mock type for the ReconciliationUC type
+type MockReconciliationUC struct {
+	mock.Mock
+}
+
+type MockReconciliationUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReconciliationUC) EXPECT() *MockReconciliationUC_Expecter {
+	return &MockReconciliationUC_Expecter{mock: &_m.Mock}
+}
+
+// ReconcilePending provides a mock function for the type MockReconciliationUC
+func (_mock *MockReconciliationUC) ReconcilePending(ctx context.Context) ([]*domain.StatusCorrection, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcilePending")
+	}
+
+	var r0 []*domain.StatusCorrection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*domain.StatusCorrection, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*domain.StatusCorrection); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.StatusCorrection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReconciliationUC_ReconcilePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcilePending'
+type MockReconciliationUC_ReconcilePending_Call struct {
+	*mock.Call
+}
+
+// ReconcilePending is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockReconciliationUC_Expecter) ReconcilePending(ctx interface{}) *MockReconciliationUC_ReconcilePending_Call {
+	return &MockReconciliationUC_ReconcilePending_Call{Call: _e.mock.On("ReconcilePending", ctx)}
+}
+
+func (_c *MockReconciliationUC_ReconcilePending_Call) Run(run func(ctx context.Context)) *MockReconciliationUC_ReconcilePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReconciliationUC_ReconcilePending_Call) Return(statusCorrections []*domain.StatusCorrection, err error) *MockReconciliationUC_ReconcilePending_Call {
+	_c.Call.Return(statusCorrections, err)
+	return _c
+}
+
+func (_c *MockReconciliationUC_ReconcilePending_Call) RunAndReturn(run func(ctx context.Context) ([]*domain.StatusCorrection, error)) *MockReconciliationUC_ReconcilePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRefundRepository creates a new instance of MockRefundRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRefundRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRefundRepository {
+	mock := &MockRefundRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRefundRepository is an autogenerated mock type for the RefundRepository type
+type MockRefundRepository struct {
+	mock.Mock
+}
+
+type MockRefundRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRefundRepository) EXPECT() *MockRefundRepository_Expecter {
+	return &MockRefundRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockRefundRepository
+func (_mock *MockRefundRepository) Create(ctx context.Context, r *domain.Refund) (*domain.Refund, error) {
+	ret := _mock.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Refund) (*domain.Refund, error)); ok {
+		return returnFunc(ctx, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Refund) *domain.Refund); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Refund) error); ok {
+		r1 = returnFunc(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRefundRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRefundRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.Refund
+func (_e *MockRefundRepository_Expecter) Create(ctx interface{}, r interface{}) *MockRefundRepository_Create_Call {
+	return &MockRefundRepository_Create_Call{Call: _e.mock.On("Create", ctx, r)}
+}
+
+func (_c *MockRefundRepository_Create_Call) Run(run func(ctx context.Context, r *domain.Refund)) *MockRefundRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Refund
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Refund)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundRepository_Create_Call) Return(refund *domain.Refund, err error) *MockRefundRepository_Create_Call {
+	_c.Call.Return(refund, err)
+	return _c
+}
+
+func (_c *MockRefundRepository_Create_Call) RunAndReturn(run func(ctx context.Context, r *domain.Refund) (*domain.Refund, error)) *MockRefundRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByExternalID provides a mock function for the type MockRefundRepository
+func (_mock *MockRefundRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Refund, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExternalID")
+	}
+
+	var r0 *domain.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Refund, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Refund); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRefundRepository_FindByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByExternalID'
+type MockRefundRepository_FindByExternalID_Call struct {
+	*mock.Call
+}
+
+// FindByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockRefundRepository_Expecter) FindByExternalID(ctx interface{}, externalID interface{}) *MockRefundRepository_FindByExternalID_Call {
+	return &MockRefundRepository_FindByExternalID_Call{Call: _e.mock.On("FindByExternalID", ctx, externalID)}
+}
+
+func (_c *MockRefundRepository_FindByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockRefundRepository_FindByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundRepository_FindByExternalID_Call) Return(refund *domain.Refund, err error) *MockRefundRepository_FindByExternalID_Call {
+	_c.Call.Return(refund, err)
+	return _c
+}
+
+func (_c *MockRefundRepository_FindByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*domain.Refund, error)) *MockRefundRepository_FindByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockRefundRepository
+func (_mock *MockRefundRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Refund, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Refund, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Refund); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRefundRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockRefundRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockRefundRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockRefundRepository_FindByID_Call {
+	return &MockRefundRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockRefundRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockRefundRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundRepository_FindByID_Call) Return(refund *domain.Refund, err error) *MockRefundRepository_FindByID_Call {
+	_c.Call.Return(refund, err)
+	return _c
+}
+
+func (_c *MockRefundRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Refund, error)) *MockRefundRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByTransactionID provides a mock function for the type MockRefundRepository
+func (_mock *MockRefundRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*domain.Refund, error) {
+	ret := _mock.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTransactionID")
+	}
+
+	var r0 []*domain.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.Refund, error)); ok {
+		return returnFunc(ctx, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.Refund); ok {
+		r0 = returnFunc(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRefundRepository_FindByTransactionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByTransactionID'
+type MockRefundRepository_FindByTransactionID_Call struct {
+	*mock.Call
+}
+
+// FindByTransactionID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionID uuid.UUID
+func (_e *MockRefundRepository_Expecter) FindByTransactionID(ctx interface{}, transactionID interface{}) *MockRefundRepository_FindByTransactionID_Call {
+	return &MockRefundRepository_FindByTransactionID_Call{Call: _e.mock.On("FindByTransactionID", ctx, transactionID)}
+}
+
+func (_c *MockRefundRepository_FindByTransactionID_Call) Run(run func(ctx context.Context, transactionID uuid.UUID)) *MockRefundRepository_FindByTransactionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundRepository_FindByTransactionID_Call) Return(refunds []*domain.Refund, err error) *MockRefundRepository_FindByTransactionID_Call {
+	_c.Call.Return(refunds, err)
+	return _c
+}
+
+func (_c *MockRefundRepository_FindByTransactionID_Call) RunAndReturn(run func(ctx context.Context, transactionID uuid.UUID) ([]*domain.Refund, error)) *MockRefundRepository_FindByTransactionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePending provides a mock function for the type MockRefundRepository
+func (_mock *MockRefundRepository) UpdatePending(ctx context.Context, r *domain.Refund, event *domain.OutboxEvent) error {
+	ret := _mock.Called(ctx, r, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePending")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Refund, *domain.OutboxEvent) error); ok {
+		r0 = returnFunc(ctx, r, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRefundRepository_UpdatePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePending'
+type MockRefundRepository_UpdatePending_Call struct {
+	*mock.Call
+}
+
+// UpdatePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.Refund
+//   - event *domain.OutboxEvent
+func (_e *MockRefundRepository_Expecter) UpdatePending(ctx interface{}, r interface{}, event interface{}) *MockRefundRepository_UpdatePending_Call {
+	return &MockRefundRepository_UpdatePending_Call{Call: _e.mock.On("UpdatePending", ctx, r, event)}
+}
+
+func (_c *MockRefundRepository_UpdatePending_Call) Run(run func(ctx context.Context, r *domain.Refund, event *domain.OutboxEvent)) *MockRefundRepository_UpdatePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Refund
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Refund)
+		}
+		var arg2 *domain.OutboxEvent
+		if args[2] != nil {
+			arg2 = args[2].(*domain.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundRepository_UpdatePending_Call) Return(err error) *MockRefundRepository_UpdatePending_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRefundRepository_UpdatePending_Call) RunAndReturn(run func(ctx context.Context, r *domain.Refund, event *domain.OutboxEvent) error) *MockRefundRepository_UpdatePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRefundUC creates a new instance of MockRefundUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRefundUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRefundUC {
+	mock := &MockRefundUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRefundUC is an autogenerated mock type for the RefundUC type
+type MockRefundUC struct {
+	mock.Mock
+}
+
+type MockRefundUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRefundUC) EXPECT() *MockRefundUC_Expecter {
+	return &MockRefundUC_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockRefundUC
+func (_mock *MockRefundUC) Create(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID, req *domain.CreateRefundRequest) (*domain.Refund, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, transactionID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID, *domain.CreateRefundRequest) (*domain.Refund, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, transactionID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID, *domain.CreateRefundRequest) *domain.Refund); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, transactionID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, uuid.UUID, *domain.CreateRefundRequest) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, transactionID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRefundUC_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRefundUC_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - transactionID uuid.UUID
+//   - req *domain.CreateRefundRequest
+func (_e *MockRefundUC_Expecter) Create(ctx interface{}, merchantID interface{}, livemode interface{}, transactionID interface{}, req interface{}) *MockRefundUC_Create_Call {
+	return &MockRefundUC_Create_Call{Call: _e.mock.On("Create", ctx, merchantID, livemode, transactionID, req)}
+}
+
+func (_c *MockRefundUC_Create_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID, req *domain.CreateRefundRequest)) *MockRefundUC_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		var arg4 *domain.CreateRefundRequest
+		if args[4] != nil {
+			arg4 = args[4].(*domain.CreateRefundRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundUC_Create_Call) Return(refund *domain.Refund, err error) *MockRefundUC_Create_Call {
+	_c.Call.Return(refund, err)
+	return _c
+}
+
+func (_c *MockRefundUC_Create_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID, req *domain.CreateRefundRequest) (*domain.Refund, error)) *MockRefundUC_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleNotification provides a mock function for the type MockRefundUC
+func (_mock *MockRefundUC) HandleNotification(ctx context.Context, req *domain.UpdateRefundStatusRequest) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleNotification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UpdateRefundStatusRequest) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRefundUC_HandleNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleNotification'
+type MockRefundUC_HandleNotification_Call struct {
+	*mock.Call
+}
+
+// HandleNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.UpdateRefundStatusRequest
+func (_e *MockRefundUC_Expecter) HandleNotification(ctx interface{}, req interface{}) *MockRefundUC_HandleNotification_Call {
+	return &MockRefundUC_HandleNotification_Call{Call: _e.mock.On("HandleNotification", ctx, req)}
+}
+
+func (_c *MockRefundUC_HandleNotification_Call) Run(run func(ctx context.Context, req *domain.UpdateRefundStatusRequest)) *MockRefundUC_HandleNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.UpdateRefundStatusRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.UpdateRefundStatusRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundUC_HandleNotification_Call) Return(err error) *MockRefundUC_HandleNotification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRefundUC_HandleNotification_Call) RunAndReturn(run func(ctx context.Context, req *domain.UpdateRefundStatusRequest) error) *MockRefundUC_HandleNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockRefundUC
+func (_mock *MockRefundUC) List(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID) ([]*domain.Refund, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) ([]*domain.Refund, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) []*domain.Refund); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRefundUC_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockRefundUC_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - transactionID uuid.UUID
+func (_e *MockRefundUC_Expecter) List(ctx interface{}, merchantID interface{}, livemode interface{}, transactionID interface{}) *MockRefundUC_List_Call {
+	return &MockRefundUC_List_Call{Call: _e.mock.On("List", ctx, merchantID, livemode, transactionID)}
+}
+
+func (_c *MockRefundUC_List_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID)) *MockRefundUC_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRefundUC_List_Call) Return(refunds []*domain.Refund, err error) *MockRefundUC_List_Call {
+	_c.Call.Return(refunds, err)
+	return _c
+}
+
+func (_c *MockRefundUC_List_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID) ([]*domain.Refund, error)) *MockRefundUC_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactionRepository creates a new instance of MockTransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionRepository {
+	mock := &MockTransactionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTransactionRepository is an autogenerated mock type for the TransactionRepository type
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+type MockTransactionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionRepository) EXPECT() *MockTransactionRepository_Expecter {
+	return &MockTransactionRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddRefundedAmount provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) AddRefundedAmount(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, amount int64, event *domain.OutboxEvent) error {
+	ret := _mock.Called(ctx, id, from, to, amount, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRefundedAmount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.TransactionStatus, domain.TransactionStatus, int64, *domain.OutboxEvent) error); ok {
+		r0 = returnFunc(ctx, id, from, to, amount, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactionRepository_AddRefundedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRefundedAmount'
+type MockTransactionRepository_AddRefundedAmount_Call struct {
+	*mock.Call
+}
+
+// AddRefundedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - from domain.TransactionStatus
+//   - to domain.TransactionStatus
+//   - amount int64
+//   - event *domain.OutboxEvent
+func (_e *MockTransactionRepository_Expecter) AddRefundedAmount(ctx interface{}, id interface{}, from interface{}, to interface{}, amount interface{}, event interface{}) *MockTransactionRepository_AddRefundedAmount_Call {
+	return &MockTransactionRepository_AddRefundedAmount_Call{Call: _e.mock.On("AddRefundedAmount", ctx, id, from, to, amount, event)}
+}
+
+func (_c *MockTransactionRepository_AddRefundedAmount_Call) Run(run func(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, amount int64, event *domain.OutboxEvent)) *MockTransactionRepository_AddRefundedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.TransactionStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.TransactionStatus)
+		}
+		var arg3 domain.TransactionStatus
+		if args[3] != nil {
+			arg3 = args[3].(domain.TransactionStatus)
+		}
+		var arg4 int64
+		if args[4] != nil {
+			arg4 = args[4].(int64)
+		}
+		var arg5 *domain.OutboxEvent
+		if args[5] != nil {
+			arg5 = args[5].(*domain.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddRefundedAmount_Call) Return(err error) *MockTransactionRepository_AddRefundedAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddRefundedAmount_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, amount int64, event *domain.OutboxEvent) error) *MockTransactionRepository_AddRefundedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, tx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Transaction) error); ok {
+		r1 = returnFunc(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTransactionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *domain.Transaction
+func (_e *MockTransactionRepository_Expecter) Create(ctx interface{}, tx interface{}) *MockTransactionRepository_Create_Call {
+	return &MockTransactionRepository_Create_Call{Call: _e.mock.On("Create", ctx, tx)}
+}
+
+func (_c *MockTransactionRepository_Create_Call) Run(run func(ctx context.Context, tx *domain.Transaction)) *MockTransactionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Transaction
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Transaction)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_Create_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_Create_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_Create_Call) RunAndReturn(run func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error)) *MockTransactionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUnstarted provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) DeleteUnstarted(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnstarted")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactionRepository_DeleteUnstarted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnstarted'
+type MockTransactionRepository_DeleteUnstarted_Call struct {
+	*mock.Call
+}
+
+// DeleteUnstarted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockTransactionRepository_Expecter) DeleteUnstarted(ctx interface{}, id interface{}) *MockTransactionRepository_DeleteUnstarted_Call {
+	return &MockTransactionRepository_DeleteUnstarted_Call{Call: _e.mock.On("DeleteUnstarted", ctx, id)}
+}
+
+func (_c *MockTransactionRepository_DeleteUnstarted_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTransactionRepository_DeleteUnstarted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_DeleteUnstarted_Call) Return(err error) *MockTransactionRepository_DeleteUnstarted_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_DeleteUnstarted_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockTransactionRepository_DeleteUnstarted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByMerchantAndExternalID provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) FindByMerchantAndExternalID(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByMerchantAndExternalID")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, string) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByMerchantAndExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByMerchantAndExternalID'
+type MockTransactionRepository_FindByMerchantAndExternalID_Call struct {
+	*mock.Call
+}
+
+// FindByMerchantAndExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - externalID string
+func (_e *MockTransactionRepository_Expecter) FindByMerchantAndExternalID(ctx interface{}, merchantID interface{}, livemode interface{}, externalID interface{}) *MockTransactionRepository_FindByMerchantAndExternalID_Call {
+	return &MockTransactionRepository_FindByMerchantAndExternalID_Call{Call: _e.mock.On("FindByMerchantAndExternalID", ctx, merchantID, livemode, externalID)}
+}
+
+func (_c *MockTransactionRepository_FindByMerchantAndExternalID_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string)) *MockTransactionRepository_FindByMerchantAndExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByMerchantAndExternalID_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_FindByMerchantAndExternalID_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByMerchantAndExternalID_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*domain.Transaction, error)) *MockTransactionRepository_FindByMerchantAndExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByMerchantAndOrderID provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) FindByMerchantAndOrderID(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByMerchantAndOrderID")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, string) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByMerchantAndOrderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByMerchantAndOrderID'
+type MockTransactionRepository_FindByMerchantAndOrderID_Call struct {
+	*mock.Call
+}
+
+// FindByMerchantAndOrderID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - orderID string
+func (_e *MockTransactionRepository_Expecter) FindByMerchantAndOrderID(ctx interface{}, merchantID interface{}, livemode interface{}, orderID interface{}) *MockTransactionRepository_FindByMerchantAndOrderID_Call {
+	return &MockTransactionRepository_FindByMerchantAndOrderID_Call{Call: _e.mock.On("FindByMerchantAndOrderID", ctx, merchantID, livemode, orderID)}
+}
+
+func (_c *MockTransactionRepository_FindByMerchantAndOrderID_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string)) *MockTransactionRepository_FindByMerchantAndOrderID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByMerchantAndOrderID_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_FindByMerchantAndOrderID_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByMerchantAndOrderID_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*domain.Transaction, error)) *MockTransactionRepository_FindByMerchantAndOrderID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByProviderOrderID provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) FindByProviderOrderID(ctx context.Context, providerOrderID string) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, providerOrderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByProviderOrderID")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, providerOrderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, providerOrderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, providerOrderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByProviderOrderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByProviderOrderID'
+type MockTransactionRepository_FindByProviderOrderID_Call struct {
+	*mock.Call
+}
+
+// FindByProviderOrderID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - providerOrderID string
+func (_e *MockTransactionRepository_Expecter) FindByProviderOrderID(ctx interface{}, providerOrderID interface{}) *MockTransactionRepository_FindByProviderOrderID_Call {
+	return &MockTransactionRepository_FindByProviderOrderID_Call{Call: _e.mock.On("FindByProviderOrderID", ctx, providerOrderID)}
+}
+
+func (_c *MockTransactionRepository_FindByProviderOrderID_Call) Run(run func(ctx context.Context, providerOrderID string)) *MockTransactionRepository_FindByProviderOrderID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByProviderOrderID_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_FindByProviderOrderID_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByProviderOrderID_Call) RunAndReturn(run func(ctx context.Context, providerOrderID string) (*domain.Transaction, error)) *MockTransactionRepository_FindByProviderOrderID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDueForReconciliation provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) FindDueForReconciliation(ctx context.Context, pendingSince time.Time, expiredSince time.Time, now time.Time, limit int) ([]*domain.Transaction, error) {
+	ret := _mock.Called(ctx, pendingSince, expiredSince, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDueForReconciliation")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Time, int) ([]*domain.Transaction, error)); ok {
+		return returnFunc(ctx, pendingSince, expiredSince, now, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Time, int) []*domain.Transaction); ok {
+		r0 = returnFunc(ctx, pendingSince, expiredSince, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, pendingSince, expiredSince, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_FindDueForReconciliation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDueForReconciliation'
+type MockTransactionRepository_FindDueForReconciliation_Call struct {
+	*mock.Call
+}
+
+// FindDueForReconciliation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pendingSince time.Time
+//   - expiredSince time.Time
+//   - now time.Time
+//   - limit int
+func (_e *MockTransactionRepository_Expecter) FindDueForReconciliation(ctx interface{}, pendingSince interface{}, expiredSince interface{}, now interface{}, limit interface{}) *MockTransactionRepository_FindDueForReconciliation_Call {
+	return &MockTransactionRepository_FindDueForReconciliation_Call{Call: _e.mock.On("FindDueForReconciliation", ctx, pendingSince, expiredSince, now, limit)}
+}
+
+func (_c *MockTransactionRepository_FindDueForReconciliation_Call) Run(run func(ctx context.Context, pendingSince time.Time, expiredSince time.Time, now time.Time, limit int)) *MockTransactionRepository_FindDueForReconciliation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindDueForReconciliation_Call) Return(transactions []*domain.Transaction, err error) *MockTransactionRepository_FindDueForReconciliation_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindDueForReconciliation_Call) RunAndReturn(run func(ctx context.Context, pendingSince time.Time, expiredSince time.Time, now time.Time, limit int) ([]*domain.Transaction, error)) *MockTransactionRepository_FindDueForReconciliation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindExpiredPending provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*domain.Transaction, error) {
+	ret := _mock.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExpiredPending")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*domain.Transaction, error)); ok {
+		return returnFunc(ctx, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []*domain.Transaction); ok {
+		r0 = returnFunc(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_FindExpiredPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExpiredPending'
+type MockTransactionRepository_FindExpiredPending_Call struct {
+	*mock.Call
+}
+
+// FindExpiredPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockTransactionRepository_Expecter) FindExpiredPending(ctx interface{}, before interface{}, limit interface{}) *MockTransactionRepository_FindExpiredPending_Call {
+	return &MockTransactionRepository_FindExpiredPending_Call{Call: _e.mock.On("FindExpiredPending", ctx, before, limit)}
+}
+
+func (_c *MockTransactionRepository_FindExpiredPending_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockTransactionRepository_FindExpiredPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindExpiredPending_Call) Return(transactions []*domain.Transaction, err error) *MockTransactionRepository_FindExpiredPending_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindExpiredPending_Call) RunAndReturn(run func(ctx context.Context, before time.Time, limit int) ([]*domain.Transaction, error)) *MockTransactionRepository_FindExpiredPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByMerchant provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByMerchant")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_GetByMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByMerchant'
+type MockTransactionRepository_GetByMerchant_Call struct {
+	*mock.Call
+}
+
+// GetByMerchant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockTransactionRepository_Expecter) GetByMerchant(ctx interface{}, merchantID interface{}, id interface{}) *MockTransactionRepository_GetByMerchant_Call {
+	return &MockTransactionRepository_GetByMerchant_Call{Call: _e.mock.On("GetByMerchant", ctx, merchantID, id)}
+}
+
+func (_c *MockTransactionRepository_GetByMerchant_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockTransactionRepository_GetByMerchant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_GetByMerchant_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_GetByMerchant_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_GetByMerchant_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.Transaction, error)) *MockTransactionRepository_GetByMerchant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) List(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransactionFilter) ([]*domain.Transaction, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransactionFilter) []*domain.Transaction); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.TransactionFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTransactionRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *domain.TransactionFilter
+func (_e *MockTransactionRepository_Expecter) List(ctx interface{}, filter interface{}) *MockTransactionRepository_List_Call {
+	return &MockTransactionRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *MockTransactionRepository_List_Call) Run(run func(ctx context.Context, filter *domain.TransactionFilter)) *MockTransactionRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.TransactionFilter
+		if args[1] != nil {
+			arg1 = args[1].(*domain.TransactionFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_List_Call) Return(transactions []*domain.Transaction, err error) *MockTransactionRepository_List_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error)) *MockTransactionRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseRefundedAmount provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) ReleaseRefundedAmount(ctx context.Context, id uuid.UUID, amount int64, event *domain.OutboxEvent) error {
+	ret := _mock.Called(ctx, id, amount, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseRefundedAmount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, *domain.OutboxEvent) error); ok {
+		r0 = returnFunc(ctx, id, amount, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactionRepository_ReleaseRefundedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseRefundedAmount'
+type MockTransactionRepository_ReleaseRefundedAmount_Call struct {
+	*mock.Call
+}
+
+// ReleaseRefundedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - amount int64
+//   - event *domain.OutboxEvent
+func (_e *MockTransactionRepository_Expecter) ReleaseRefundedAmount(ctx interface{}, id interface{}, amount interface{}, event interface{}) *MockTransactionRepository_ReleaseRefundedAmount_Call {
+	return &MockTransactionRepository_ReleaseRefundedAmount_Call{Call: _e.mock.On("ReleaseRefundedAmount", ctx, id, amount, event)}
+}
+
+func (_c *MockTransactionRepository_ReleaseRefundedAmount_Call) Run(run func(ctx context.Context, id uuid.UUID, amount int64, event *domain.OutboxEvent)) *MockTransactionRepository_ReleaseRefundedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 *domain.OutboxEvent
+		if args[3] != nil {
+			arg3 = args[3].(*domain.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_ReleaseRefundedAmount_Call) Return(err error) *MockTransactionRepository_ReleaseRefundedAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_ReleaseRefundedAmount_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, amount int64, event *domain.OutboxEvent) error) *MockTransactionRepository_ReleaseRefundedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleReconciliation provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) ScheduleReconciliation(ctx context.Context, id uuid.UUID, attempts int, nextAt time.Time) error {
+	ret := _mock.Called(ctx, id, attempts, nextAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleReconciliation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, attempts, nextAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactionRepository_ScheduleReconciliation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleReconciliation'
+type MockTransactionRepository_ScheduleReconciliation_Call struct {
+	*mock.Call
+}
+
+// ScheduleReconciliation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - attempts int
+//   - nextAt time.Time
+func (_e *MockTransactionRepository_Expecter) ScheduleReconciliation(ctx interface{}, id interface{}, attempts interface{}, nextAt interface{}) *MockTransactionRepository_ScheduleReconciliation_Call {
+	return &MockTransactionRepository_ScheduleReconciliation_Call{Call: _e.mock.On("ScheduleReconciliation", ctx, id, attempts, nextAt)}
+}
+
+func (_c *MockTransactionRepository_ScheduleReconciliation_Call) Run(run func(ctx context.Context, id uuid.UUID, attempts int, nextAt time.Time)) *MockTransactionRepository_ScheduleReconciliation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_ScheduleReconciliation_Call) Return(err error) *MockTransactionRepository_ScheduleReconciliation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_ScheduleReconciliation_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, attempts int, nextAt time.Time) error) *MockTransactionRepository_ScheduleReconciliation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) Update(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, tx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Transaction) error); ok {
+		r1 = returnFunc(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTransactionRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *domain.Transaction
+func (_e *MockTransactionRepository_Expecter) Update(ctx interface{}, tx interface{}) *MockTransactionRepository_Update_Call {
+	return &MockTransactionRepository_Update_Call{Call: _e.mock.On("Update", ctx, tx)}
+}
+
+func (_c *MockTransactionRepository_Update_Call) Run(run func(ctx context.Context, tx *domain.Transaction)) *MockTransactionRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Transaction
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Transaction)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_Update_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_Update_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_Update_Call) RunAndReturn(run func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error)) *MockTransactionRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, event *domain.OutboxEvent) error {
+	ret := _mock.Called(ctx, id, from, to, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.TransactionStatus, domain.TransactionStatus, *domain.OutboxEvent) error); ok {
+		r0 = returnFunc(ctx, id, from, to, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactionRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockTransactionRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - from domain.TransactionStatus
+//   - to domain.TransactionStatus
+//   - event *domain.OutboxEvent
+func (_e *MockTransactionRepository_Expecter) UpdateStatus(ctx interface{}, id interface{}, from interface{}, to interface{}, event interface{}) *MockTransactionRepository_UpdateStatus_Call {
+	return &MockTransactionRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, from, to, event)}
+}
+
+func (_c *MockTransactionRepository_UpdateStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, event *domain.OutboxEvent)) *MockTransactionRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.TransactionStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.TransactionStatus)
+		}
+		var arg3 domain.TransactionStatus
+		if args[3] != nil {
+			arg3 = args[3].(domain.TransactionStatus)
+		}
+		var arg4 *domain.OutboxEvent
+		if args[4] != nil {
+			arg4 = args[4].(*domain.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_UpdateStatus_Call) Return(err error) *MockTransactionRepository_UpdateStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_UpdateStatus_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, event *domain.OutboxEvent) error) *MockTransactionRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactionUC creates a new instance of MockTransactionUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionUC {
+	mock := &MockTransactionUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTransactionUC is an autogenerated mock type for the TransactionUC type
+type MockTransactionUC struct {
+	mock.Mock
+}
+
+type MockTransactionUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionUC) EXPECT() *MockTransactionUC_Expecter {
+	return &MockTransactionUC_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) Cancel(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockTransactionUC_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - id uuid.UUID
+func (_e *MockTransactionUC_Expecter) Cancel(ctx interface{}, merchantID interface{}, livemode interface{}, id interface{}) *MockTransactionUC_Cancel_Call {
+	return &MockTransactionUC_Cancel_Call{Call: _e.mock.On("Cancel", ctx, merchantID, livemode, id)}
+}
+
+func (_c *MockTransactionUC_Cancel_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID)) *MockTransactionUC_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_Cancel_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_Cancel_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_Cancel_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error)) *MockTransactionUC_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) Create(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, *domain.CreateTransactionRequest) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, *domain.CreateTransactionRequest) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, *domain.CreateTransactionRequest) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTransactionUC_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - req *domain.CreateTransactionRequest
+func (_e *MockTransactionUC_Expecter) Create(ctx interface{}, merchantID interface{}, livemode interface{}, req interface{}) *MockTransactionUC_Create_Call {
+	return &MockTransactionUC_Create_Call{Call: _e.mock.On("Create", ctx, merchantID, livemode, req)}
+}
+
+func (_c *MockTransactionUC_Create_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateTransactionRequest)) *MockTransactionUC_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 *domain.CreateTransactionRequest
+		if args[3] != nil {
+			arg3 = args[3].(*domain.CreateTransactionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_Create_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_Create_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_Create_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateTransactionRequest) (*domain.Transaction, error)) *MockTransactionUC_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpirePending provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) ExpirePending(ctx context.Context, limit int) (int, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePending")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_ExpirePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpirePending'
+type MockTransactionUC_ExpirePending_Call struct {
+	*mock.Call
+}
+
+// ExpirePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockTransactionUC_Expecter) ExpirePending(ctx interface{}, limit interface{}) *MockTransactionUC_ExpirePending_Call {
+	return &MockTransactionUC_ExpirePending_Call{Call: _e.mock.On("ExpirePending", ctx, limit)}
+}
+
+func (_c *MockTransactionUC_ExpirePending_Call) Run(run func(ctx context.Context, limit int)) *MockTransactionUC_ExpirePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_ExpirePending_Call) Return(n int, err error) *MockTransactionUC_ExpirePending_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_ExpirePending_Call) RunAndReturn(run func(ctx context.Context, limit int) (int, error)) *MockTransactionUC_ExpirePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) Get(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockTransactionUC_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - id uuid.UUID
+func (_e *MockTransactionUC_Expecter) Get(ctx interface{}, merchantID interface{}, livemode interface{}, id interface{}) *MockTransactionUC_Get_Call {
+	return &MockTransactionUC_Get_Call{Call: _e.mock.On("Get", ctx, merchantID, livemode, id)}
+}
+
+func (_c *MockTransactionUC_Get_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID)) *MockTransactionUC_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_Get_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_Get_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_Get_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error)) *MockTransactionUC_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByExternalID provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) GetByExternalID(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByExternalID")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, string) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_GetByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByExternalID'
+type MockTransactionUC_GetByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - externalID string
+func (_e *MockTransactionUC_Expecter) GetByExternalID(ctx interface{}, merchantID interface{}, livemode interface{}, externalID interface{}) *MockTransactionUC_GetByExternalID_Call {
+	return &MockTransactionUC_GetByExternalID_Call{Call: _e.mock.On("GetByExternalID", ctx, merchantID, livemode, externalID)}
+}
+
+func (_c *MockTransactionUC_GetByExternalID_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string)) *MockTransactionUC_GetByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_GetByExternalID_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_GetByExternalID_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_GetByExternalID_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*domain.Transaction, error)) *MockTransactionUC_GetByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByOrderID provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) GetByOrderID(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*domain.Transaction, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOrderID")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) (*domain.Transaction, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, string) *domain.Transaction); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, string) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_GetByOrderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByOrderID'
+type MockTransactionUC_GetByOrderID_Call struct {
+	*mock.Call
+}
+
+// GetByOrderID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - orderID string
+func (_e *MockTransactionUC_Expecter) GetByOrderID(ctx interface{}, merchantID interface{}, livemode interface{}, orderID interface{}) *MockTransactionUC_GetByOrderID_Call {
+	return &MockTransactionUC_GetByOrderID_Call{Call: _e.mock.On("GetByOrderID", ctx, merchantID, livemode, orderID)}
+}
+
+func (_c *MockTransactionUC_GetByOrderID_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string)) *MockTransactionUC_GetByOrderID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_GetByOrderID_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_GetByOrderID_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_GetByOrderID_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*domain.Transaction, error)) *MockTransactionUC_GetByOrderID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleNotification provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) HandleNotification(ctx context.Context, req *domain.UpdateStatusRequest) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleNotification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UpdateStatusRequest) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactionUC_HandleNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleNotification'
+type MockTransactionUC_HandleNotification_Call struct {
+	*mock.Call
+}
+
+// HandleNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.UpdateStatusRequest
+func (_e *MockTransactionUC_Expecter) HandleNotification(ctx interface{}, req interface{}) *MockTransactionUC_HandleNotification_Call {
+	return &MockTransactionUC_HandleNotification_Call{Call: _e.mock.On("HandleNotification", ctx, req)}
+}
+
+func (_c *MockTransactionUC_HandleNotification_Call) Run(run func(ctx context.Context, req *domain.UpdateStatusRequest)) *MockTransactionUC_HandleNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.UpdateStatusRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.UpdateStatusRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_HandleNotification_Call) Return(err error) *MockTransactionUC_HandleNotification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionUC_HandleNotification_Call) RunAndReturn(run func(ctx context.Context, req *domain.UpdateStatusRequest) error) *MockTransactionUC_HandleNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockTransactionUC
+func (_mock *MockTransactionUC) List(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.ListTransactionsRequest) (*domain.TransactionPage, error) {
+	ret := _mock.Called(ctx, merchantID, livemode, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.TransactionPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, *domain.ListTransactionsRequest) (*domain.TransactionPage, error)); ok {
+		return returnFunc(ctx, merchantID, livemode, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, *domain.ListTransactionsRequest) *domain.TransactionPage); ok {
+		r0 = returnFunc(ctx, merchantID, livemode, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, *domain.ListTransactionsRequest) error); ok {
+		r1 = returnFunc(ctx, merchantID, livemode, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionUC_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTransactionUC_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - livemode bool
+//   - req *domain.ListTransactionsRequest
+func (_e *MockTransactionUC_Expecter) List(ctx interface{}, merchantID interface{}, livemode interface{}, req interface{}) *MockTransactionUC_List_Call {
+	return &MockTransactionUC_List_Call{Call: _e.mock.On("List", ctx, merchantID, livemode, req)}
+}
+
+func (_c *MockTransactionUC_List_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.ListTransactionsRequest)) *MockTransactionUC_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 *domain.ListTransactionsRequest
+		if args[3] != nil {
+			arg3 = args[3].(*domain.ListTransactionsRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionUC_List_Call) Return(transactionPage *domain.TransactionPage, err error) *MockTransactionUC_List_Call {
+	_c.Call.Return(transactionPage, err)
+	return _c
+}
+
+func (_c *MockTransactionUC_List_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.ListTransactionsRequest) (*domain.TransactionPage, error)) *MockTransactionUC_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookPublisher creates a new instance of MockWebhookPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookPublisher {
+	mock := &MockWebhookPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookPublisher is an autogenerated mock type for the WebhookPublisher type
+type MockWebhookPublisher struct {
+	mock.Mock
+}
+
+type MockWebhookPublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookPublisher) EXPECT() *MockWebhookPublisher_Expecter {
+	return &MockWebhookPublisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type MockWebhookPublisher
+func (_mock *MockWebhookPublisher) Publish(ctx context.Context, payload *domain.WebhookPayload) error {
+	ret := _mock.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookPayload) error); ok {
+		r0 = returnFunc(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookPublisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockWebhookPublisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload *domain.WebhookPayload
+func (_e *MockWebhookPublisher_Expecter) Publish(ctx interface{}, payload interface{}) *MockWebhookPublisher_Publish_Call {
+	return &MockWebhookPublisher_Publish_Call{Call: _e.mock.On("Publish", ctx, payload)}
+}
+
+func (_c *MockWebhookPublisher_Publish_Call) Run(run func(ctx context.Context, payload *domain.WebhookPayload)) *MockWebhookPublisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.WebhookPayload
+		if args[1] != nil {
+			arg1 = args[1].(*domain.WebhookPayload)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookPublisher_Publish_Call) Return(err error) *MockWebhookPublisher_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookPublisher_Publish_Call) RunAndReturn(run func(ctx context.Context, payload *domain.WebhookPayload) error) *MockWebhookPublisher_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookRetryQueue creates a new instance of MockWebhookRetryQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookRetryQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookRetryQueue {
+	mock := &MockWebhookRetryQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookRetryQueue is an autogenerated mock type for the WebhookRetryQueue type
+type MockWebhookRetryQueue struct {
+	mock.Mock
+}
+
+type MockWebhookRetryQueue_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookRetryQueue) EXPECT() *MockWebhookRetryQueue_Expecter {
+	return &MockWebhookRetryQueue_Expecter{mock: &_m.Mock}
+}
+
+// DeadLetter provides a mock function for the type MockWebhookRetryQueue
+func (_mock *MockWebhookRetryQueue) DeadLetter(ctx context.Context, payload *domain.WebhookPayload) error {
+	ret := _mock.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookPayload) error); ok {
+		r0 = returnFunc(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRetryQueue_DeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeadLetter'
+type MockWebhookRetryQueue_DeadLetter_Call struct {
+	*mock.Call
+}
+
+// DeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload *domain.WebhookPayload
+func (_e *MockWebhookRetryQueue_Expecter) DeadLetter(ctx interface{}, payload interface{}) *MockWebhookRetryQueue_DeadLetter_Call {
+	return &MockWebhookRetryQueue_DeadLetter_Call{Call: _e.mock.On("DeadLetter", ctx, payload)}
+}
+
+func (_c *MockWebhookRetryQueue_DeadLetter_Call) Run(run func(ctx context.Context, payload *domain.WebhookPayload)) *MockWebhookRetryQueue_DeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.WebhookPayload
+		if args[1] != nil {
+			arg1 = args[1].(*domain.WebhookPayload)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_DeadLetter_Call) Return(err error) *MockWebhookRetryQueue_DeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_DeadLetter_Call) RunAndReturn(run func(ctx context.Context, payload *domain.WebhookPayload) error) *MockWebhookRetryQueue_DeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeadLetters provides a mock function for the type MockWebhookRetryQueue
+func (_mock *MockWebhookRetryQueue) ListDeadLetters(ctx context.Context, offset int, limit int) ([]*domain.WebhookPayload, error) {
+	ret := _mock.Called(ctx, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 []*domain.WebhookPayload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*domain.WebhookPayload, error)); ok {
+		return returnFunc(ctx, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*domain.WebhookPayload); ok {
+		r0 = returnFunc(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WebhookPayload)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRetryQueue_ListDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetters'
+type MockWebhookRetryQueue_ListDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - offset int
+//   - limit int
+func (_e *MockWebhookRetryQueue_Expecter) ListDeadLetters(ctx interface{}, offset interface{}, limit interface{}) *MockWebhookRetryQueue_ListDeadLetters_Call {
+	return &MockWebhookRetryQueue_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, offset, limit)}
+}
+
+func (_c *MockWebhookRetryQueue_ListDeadLetters_Call) Run(run func(ctx context.Context, offset int, limit int)) *MockWebhookRetryQueue_ListDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_ListDeadLetters_Call) Return(webhookPayloads []*domain.WebhookPayload, err error) *MockWebhookRetryQueue_ListDeadLetters_Call {
+	_c.Call.Return(webhookPayloads, err)
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, offset int, limit int) ([]*domain.WebhookPayload, error)) *MockWebhookRetryQueue_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PromoteDue provides a mock function for the type MockWebhookRetryQueue
+func (_mock *MockWebhookRetryQueue) PromoteDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	ret := _mock.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PromoteDue")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) (int, error)); ok {
+		return returnFunc(ctx, now, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = returnFunc(ctx, now, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRetryQueue_PromoteDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PromoteDue'
+type MockWebhookRetryQueue_PromoteDue_Call struct {
+	*mock.Call
+}
+
+// PromoteDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *MockWebhookRetryQueue_Expecter) PromoteDue(ctx interface{}, now interface{}, limit interface{}) *MockWebhookRetryQueue_PromoteDue_Call {
+	return &MockWebhookRetryQueue_PromoteDue_Call{Call: _e.mock.On("PromoteDue", ctx, now, limit)}
+}
+
+func (_c *MockWebhookRetryQueue_PromoteDue_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *MockWebhookRetryQueue_PromoteDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_PromoteDue_Call) Return(n int, err error) *MockWebhookRetryQueue_PromoteDue_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_PromoteDue_Call) RunAndReturn(run func(ctx context.Context, now time.Time, limit int) (int, error)) *MockWebhookRetryQueue_PromoteDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplayDeadLetters provides a mock function for the type MockWebhookRetryQueue
+func (_mock *MockWebhookRetryQueue) ReplayDeadLetters(ctx context.Context, limit int) (int, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDeadLetters")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRetryQueue_ReplayDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayDeadLetters'
+type MockWebhookRetryQueue_ReplayDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ReplayDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockWebhookRetryQueue_Expecter) ReplayDeadLetters(ctx interface{}, limit interface{}) *MockWebhookRetryQueue_ReplayDeadLetters_Call {
+	return &MockWebhookRetryQueue_ReplayDeadLetters_Call{Call: _e.mock.On("ReplayDeadLetters", ctx, limit)}
+}
+
+func (_c *MockWebhookRetryQueue_ReplayDeadLetters_Call) Run(run func(ctx context.Context, limit int)) *MockWebhookRetryQueue_ReplayDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_ReplayDeadLetters_Call) Return(n int, err error) *MockWebhookRetryQueue_ReplayDeadLetters_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_ReplayDeadLetters_Call) RunAndReturn(run func(ctx context.Context, limit int) (int, error)) *MockWebhookRetryQueue_ReplayDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Schedule provides a mock function for the type MockWebhookRetryQueue
+func (_mock *MockWebhookRetryQueue) Schedule(ctx context.Context, payload *domain.WebhookPayload, at time.Time) error {
+	ret := _mock.Called(ctx, payload, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Schedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookPayload, time.Time) error); ok {
+		r0 = returnFunc(ctx, payload, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRetryQueue_Schedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedule'
+type MockWebhookRetryQueue_Schedule_Call struct {
+	*mock.Call
+}
+
+// Schedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload *domain.WebhookPayload
+//   - at time.Time
+func (_e *MockWebhookRetryQueue_Expecter) Schedule(ctx interface{}, payload interface{}, at interface{}) *MockWebhookRetryQueue_Schedule_Call {
+	return &MockWebhookRetryQueue_Schedule_Call{Call: _e.mock.On("Schedule", ctx, payload, at)}
+}
+
+func (_c *MockWebhookRetryQueue_Schedule_Call) Run(run func(ctx context.Context, payload *domain.WebhookPayload, at time.Time)) *MockWebhookRetryQueue_Schedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.WebhookPayload
+		if args[1] != nil {
+			arg1 = args[1].(*domain.WebhookPayload)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_Schedule_Call) Return(err error) *MockWebhookRetryQueue_Schedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRetryQueue_Schedule_Call) RunAndReturn(run func(ctx context.Context, payload *domain.WebhookPayload, at time.Time) error) *MockWebhookRetryQueue_Schedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookConsumer creates a new instance of MockWebhookConsumer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookConsumer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookConsumer {
+	mock := &MockWebhookConsumer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookConsumer is an autogenerated mock type for the WebhookConsumer type
+type MockWebhookConsumer struct {
+	mock.Mock
+}
+
+type MockWebhookConsumer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookConsumer) EXPECT() *MockWebhookConsumer_Expecter {
+	return &MockWebhookConsumer_Expecter{mock: &_m.Mock}
+}
+
+// Ack provides a mock function for the type MockWebhookConsumer
+func (_mock *MockWebhookConsumer) Ack(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ack")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookConsumer_Ack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ack'
+type MockWebhookConsumer_Ack_Call struct {
+	*mock.Call
+}
+
+// Ack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookConsumer_Expecter) Ack(ctx interface{}, id interface{}) *MockWebhookConsumer_Ack_Call {
+	return &MockWebhookConsumer_Ack_Call{Call: _e.mock.On("Ack", ctx, id)}
+}
+
+func (_c *MockWebhookConsumer_Ack_Call) Run(run func(ctx context.Context, id string)) *MockWebhookConsumer_Ack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookConsumer_Ack_Call) Return(err error) *MockWebhookConsumer_Ack_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookConsumer_Ack_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockWebhookConsumer_Ack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimStale provides a mock function for the type MockWebhookConsumer
+func (_mock *MockWebhookConsumer) ClaimStale(ctx context.Context, minIdle time.Duration, count int) ([]*domain.WebhookMessage, error) {
+	ret := _mock.Called(ctx, minIdle, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimStale")
+	}
+
+	var r0 []*domain.WebhookMessage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, int) ([]*domain.WebhookMessage, error)); ok {
+		return returnFunc(ctx, minIdle, count)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, int) []*domain.WebhookMessage); ok {
+		r0 = returnFunc(ctx, minIdle, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WebhookMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration, int) error); ok {
+		r1 = returnFunc(ctx, minIdle, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookConsumer_ClaimStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimStale'
+type MockWebhookConsumer_ClaimStale_Call struct {
+	*mock.Call
+}
+
+// ClaimStale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - minIdle time.Duration
+//   - count int
+func (_e *MockWebhookConsumer_Expecter) ClaimStale(ctx interface{}, minIdle interface{}, count interface{}) *MockWebhookConsumer_ClaimStale_Call {
+	return &MockWebhookConsumer_ClaimStale_Call{Call: _e.mock.On("ClaimStale", ctx, minIdle, count)}
+}
+
+func (_c *MockWebhookConsumer_ClaimStale_Call) Run(run func(ctx context.Context, minIdle time.Duration, count int)) *MockWebhookConsumer_ClaimStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookConsumer_ClaimStale_Call) Return(webhookMessages []*domain.WebhookMessage, err error) *MockWebhookConsumer_ClaimStale_Call {
+	_c.Call.Return(webhookMessages, err)
+	return _c
+}
+
+func (_c *MockWebhookConsumer_ClaimStale_Call) RunAndReturn(run func(ctx context.Context, minIdle time.Duration, count int) ([]*domain.WebhookMessage, error)) *MockWebhookConsumer_ClaimStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGroup provides a mock function for the type MockWebhookConsumer
+func (_mock *MockWebhookConsumer) CreateGroup(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookConsumer_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type MockWebhookConsumer_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookConsumer_Expecter) CreateGroup(ctx interface{}) *MockWebhookConsumer_CreateGroup_Call {
+	return &MockWebhookConsumer_CreateGroup_Call{Call: _e.mock.On("CreateGroup", ctx)}
+}
+
+func (_c *MockWebhookConsumer_CreateGroup_Call) Run(run func(ctx context.Context)) *MockWebhookConsumer_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookConsumer_CreateGroup_Call) Return(err error) *MockWebhookConsumer_CreateGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookConsumer_CreateGroup_Call) RunAndReturn(run func(ctx context.Context) error) *MockWebhookConsumer_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveLegacyQueue provides a mock function for the type MockWebhookConsumer
+func (_mock *MockWebhookConsumer) MoveLegacyQueue(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveLegacyQueue")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookConsumer_MoveLegacyQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveLegacyQueue'
+type MockWebhookConsumer_MoveLegacyQueue_Call struct {
+	*mock.Call
+}
+
+// MoveLegacyQueue is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookConsumer_Expecter) MoveLegacyQueue(ctx interface{}) *MockWebhookConsumer_MoveLegacyQueue_Call {
+	return &MockWebhookConsumer_MoveLegacyQueue_Call{Call: _e.mock.On("MoveLegacyQueue", ctx)}
+}
+
+func (_c *MockWebhookConsumer_MoveLegacyQueue_Call) Run(run func(ctx context.Context)) *MockWebhookConsumer_MoveLegacyQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookConsumer_MoveLegacyQueue_Call) Return(n int, err error) *MockWebhookConsumer_MoveLegacyQueue_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockWebhookConsumer_MoveLegacyQueue_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockWebhookConsumer_MoveLegacyQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Read provides a mock function for the type MockWebhookConsumer
+func (_mock *MockWebhookConsumer) Read(ctx context.Context, block time.Duration) (*domain.WebhookMessage, error) {
+	ret := _mock.Called(ctx, block)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 *domain.WebhookMessage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration) (*domain.WebhookMessage, error)); ok {
+		return returnFunc(ctx, block)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration) *domain.WebhookMessage); ok {
+		r0 = returnFunc(ctx, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = returnFunc(ctx, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookConsumer_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockWebhookConsumer_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+//   - block time.Duration
+func (_e *MockWebhookConsumer_Expecter) Read(ctx interface{}, block interface{}) *MockWebhookConsumer_Read_Call {
+	return &MockWebhookConsumer_Read_Call{Call: _e.mock.On("Read", ctx, block)}
+}
+
+func (_c *MockWebhookConsumer_Read_Call) Run(run func(ctx context.Context, block time.Duration)) *MockWebhookConsumer_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookConsumer_Read_Call) Return(webhookMessage *domain.WebhookMessage, err error) *MockWebhookConsumer_Read_Call {
+	_c.Call.Return(webhookMessage, err)
+	return _c
+}
+
+func (_c *MockWebhookConsumer_Read_Call) RunAndReturn(run func(ctx context.Context, block time.Duration) (*domain.WebhookMessage, error)) *MockWebhookConsumer_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookDeliveryRepository creates a new instance of MockWebhookDeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookDeliveryRepository is an autogenerated mock type for the WebhookDeliveryRepository type
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+type MockWebhookDeliveryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepository_Expecter {
+	return &MockWebhookDeliveryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockWebhookDeliveryRepository
+func (_mock *MockWebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ret := _mock.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookDelivery) error); ok {
+		r0 = returnFunc(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookDeliveryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookDeliveryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *domain.WebhookDelivery
+func (_e *MockWebhookDeliveryRepository_Expecter) Create(ctx interface{}, delivery interface{}) *MockWebhookDeliveryRepository_Create_Call {
+	return &MockWebhookDeliveryRepository_Create_Call{Call: _e.mock.On("Create", ctx, delivery)}
 }
 
-func (_c *MockMerchantUC_GetProfile_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMerchantUC_GetProfile_Call {
+func (_c *MockWebhookDeliveryRepository_Create_Call) Run(run func(ctx context.Context, delivery *domain.WebhookDelivery)) *MockWebhookDeliveryRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 *domain.WebhookDelivery
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(*domain.WebhookDelivery)
 		}
 		run(
 			arg0,
@@ -597,55 +5912,58 @@ func (_c *MockMerchantUC_GetProfile_Call) Run(run func(ctx context.Context, id u
 	return _c
 }
 
-func (_c *MockMerchantUC_GetProfile_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_GetProfile_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockWebhookDeliveryRepository_Create_Call) Return(err error) *MockWebhookDeliveryRepository_Create_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMerchantUC_GetProfile_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Merchant, error)) *MockMerchantUC_GetProfile_Call {
+func (_c *MockWebhookDeliveryRepository_Create_Call) RunAndReturn(run func(ctx context.Context, delivery *domain.WebhookDelivery) error) *MockWebhookDeliveryRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegenerateApiKey provides a mock function for the type MockMerchantUC
-func (_mock *MockMerchantUC) RegenerateApiKey(ctx context.Context, id uuid.UUID) (string, error) {
-	ret := _mock.Called(ctx, id)
+// GetByMerchant provides a mock function for the type MockWebhookDeliveryRepository
+func (_mock *MockWebhookDeliveryRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, merchantID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RegenerateApiKey")
+		panic("no return value specified for GetByMerchant")
 	}
 
-	var r0 string
+	var r0 *domain.WebhookDelivery
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, merchantID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, merchantID, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMerchantUC_RegenerateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateApiKey'
-type MockMerchantUC_RegenerateApiKey_Call struct {
+// MockWebhookDeliveryRepository_GetByMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByMerchant'
+type MockWebhookDeliveryRepository_GetByMerchant_Call struct {
 	*mock.Call
 }
 
-// RegenerateApiKey is a helper method to define mock.On call
+// GetByMerchant is a helper method to define mock.On call
 //   - ctx context.Context
+//   - merchantID uuid.UUID
 //   - id uuid.UUID
-func (_e *MockMerchantUC_Expecter) RegenerateApiKey(ctx interface{}, id interface{}) *MockMerchantUC_RegenerateApiKey_Call {
-	return &MockMerchantUC_RegenerateApiKey_Call{Call: _e.mock.On("RegenerateApiKey", ctx, id)}
+func (_e *MockWebhookDeliveryRepository_Expecter) GetByMerchant(ctx interface{}, merchantID interface{}, id interface{}) *MockWebhookDeliveryRepository_GetByMerchant_Call {
+	return &MockWebhookDeliveryRepository_GetByMerchant_Call{Call: _e.mock.On("GetByMerchant", ctx, merchantID, id)}
 }
 
-func (_c *MockMerchantUC_RegenerateApiKey_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMerchantUC_RegenerateApiKey_Call {
+func (_c *MockWebhookDeliveryRepository_GetByMerchant_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockWebhookDeliveryRepository_GetByMerchant_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -655,73 +5973,78 @@ func (_c *MockMerchantUC_RegenerateApiKey_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMerchantUC_RegenerateApiKey_Call) Return(s string, err error) *MockMerchantUC_RegenerateApiKey_Call {
-	_c.Call.Return(s, err)
+func (_c *MockWebhookDeliveryRepository_GetByMerchant_Call) Return(webhookDelivery *domain.WebhookDelivery, err error) *MockWebhookDeliveryRepository_GetByMerchant_Call {
+	_c.Call.Return(webhookDelivery, err)
 	return _c
 }
 
-func (_c *MockMerchantUC_RegenerateApiKey_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (string, error)) *MockMerchantUC_RegenerateApiKey_Call {
+func (_c *MockWebhookDeliveryRepository_GetByMerchant_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error)) *MockWebhookDeliveryRepository_GetByMerchant_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function for the type MockMerchantUC
-func (_mock *MockMerchantUC) Register(ctx context.Context, req *domain.RegisterMerchantRequest) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, req)
+// List provides a mock function for the type MockWebhookDeliveryRepository
+func (_mock *MockWebhookDeliveryRepository) List(ctx context.Context, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Register")
+		panic("no return value specified for List")
 	}
 
-	var r0 *domain.Merchant
+	var r0 []*domain.WebhookDelivery
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RegisterMerchantRequest) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RegisterMerchantRequest) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookDeliveryFilter) []*domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Merchant)
+			r0 = ret.Get(0).([]*domain.WebhookDelivery)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.RegisterMerchantRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.WebhookDeliveryFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMerchantUC_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
-type MockMerchantUC_Register_Call struct {
+// MockWebhookDeliveryRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookDeliveryRepository_List_Call struct {
 	*mock.Call
 }
 
-// Register is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.RegisterMerchantRequest
-func (_e *MockMerchantUC_Expecter) Register(ctx interface{}, req interface{}) *MockMerchantUC_Register_Call {
-	return &MockMerchantUC_Register_Call{Call: _e.mock.On("Register", ctx, req)}
+//   - filter *domain.WebhookDeliveryFilter
+func (_e *MockWebhookDeliveryRepository_Expecter) List(ctx interface{}, filter interface{}) *MockWebhookDeliveryRepository_List_Call {
+	return &MockWebhookDeliveryRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
 }
 
-func (_c *MockMerchantUC_Register_Call) Run(run func(ctx context.Context, req *domain.RegisterMerchantRequest)) *MockMerchantUC_Register_Call {
+func (_c *MockWebhookDeliveryRepository_List_Call) Run(run func(ctx context.Context, filter *domain.WebhookDeliveryFilter)) *MockWebhookDeliveryRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.RegisterMerchantRequest
+		var arg1 *domain.WebhookDeliveryFilter
 		if args[1] != nil {
-			arg1 = args[1].(*domain.RegisterMerchantRequest)
+			arg1 = args[1].(*domain.WebhookDeliveryFilter)
 		}
 		run(
 			arg0,
@@ -731,58 +6054,85 @@ func (_c *MockMerchantUC_Register_Call) Run(run func(ctx context.Context, req *d
 	return _c
 }
 
-func (_c *MockMerchantUC_Register_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_Register_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockWebhookDeliveryRepository_List_Call) Return(webhookDeliverys []*domain.WebhookDelivery, err error) *MockWebhookDeliveryRepository_List_Call {
+	_c.Call.Return(webhookDeliverys, err)
 	return _c
 }
 
-func (_c *MockMerchantUC_Register_Call) RunAndReturn(run func(ctx context.Context, req *domain.RegisterMerchantRequest) (*domain.Merchant, error)) *MockMerchantUC_Register_Call {
+func (_c *MockWebhookDeliveryRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)) *MockWebhookDeliveryRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateProfile provides a mock function for the type MockMerchantUC
-func (_mock *MockMerchantUC) UpdateProfile(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, id, req)
+// NewMockWebhookDeliveryUC creates a new instance of MockWebhookDeliveryUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookDeliveryUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookDeliveryUC {
+	mock := &MockWebhookDeliveryUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookDeliveryUC is an autogenerated mock type for the WebhookDeliveryUC type
+type MockWebhookDeliveryUC struct {
+	mock.Mock
+}
+
+type MockWebhookDeliveryUC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookDeliveryUC) EXPECT() *MockWebhookDeliveryUC_Expecter {
+	return &MockWebhookDeliveryUC_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function for the type MockWebhookDeliveryUC
+func (_mock *MockWebhookDeliveryUC) List(ctx context.Context, merchantID uuid.UUID, req *domain.ListWebhookDeliveriesRequest) (*domain.WebhookDeliveryPage, error) {
+	ret := _mock.Called(ctx, merchantID, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProfile")
+		panic("no return value specified for List")
 	}
 
-	var r0 *domain.Merchant
+	var r0 *domain.WebhookDeliveryPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.UpdateMerchantRequest) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.ListWebhookDeliveriesRequest) (*domain.WebhookDeliveryPage, error)); ok {
+		return returnFunc(ctx, merchantID, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.UpdateMerchantRequest) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.ListWebhookDeliveriesRequest) *domain.WebhookDeliveryPage); ok {
+		r0 = returnFunc(ctx, merchantID, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Merchant)
+			r0 = ret.Get(0).(*domain.WebhookDeliveryPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *domain.UpdateMerchantRequest) error); ok {
-		r1 = returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *domain.ListWebhookDeliveriesRequest) error); ok {
+		r1 = returnFunc(ctx, merchantID, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMerchantUC_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
-type MockMerchantUC_UpdateProfile_Call struct {
+// MockWebhookDeliveryUC_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookDeliveryUC_List_Call struct {
 	*mock.Call
 }
 
-// UpdateProfile is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-//   - req *domain.UpdateMerchantRequest
-func (_e *MockMerchantUC_Expecter) UpdateProfile(ctx interface{}, id interface{}, req interface{}) *MockMerchantUC_UpdateProfile_Call {
-	return &MockMerchantUC_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, id, req)}
+//   - merchantID uuid.UUID
+//   - req *domain.ListWebhookDeliveriesRequest
+func (_e *MockWebhookDeliveryUC_Expecter) List(ctx interface{}, merchantID interface{}, req interface{}) *MockWebhookDeliveryUC_List_Call {
+	return &MockWebhookDeliveryUC_List_Call{Call: _e.mock.On("List", ctx, merchantID, req)}
 }
 
-func (_c *MockMerchantUC_UpdateProfile_Call) Run(run func(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest)) *MockMerchantUC_UpdateProfile_Call {
+func (_c *MockWebhookDeliveryUC_List_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, req *domain.ListWebhookDeliveriesRequest)) *MockWebhookDeliveryUC_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -792,9 +6142,9 @@ func (_c *MockMerchantUC_UpdateProfile_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *domain.UpdateMerchantRequest
+		var arg2 *domain.ListWebhookDeliveriesRequest
 		if args[2] != nil {
-			arg2 = args[2].(*domain.UpdateMerchantRequest)
+			arg2 = args[2].(*domain.ListWebhookDeliveriesRequest)
 		}
 		run(
 			arg0,
@@ -805,91 +6155,86 @@ func (_c *MockMerchantUC_UpdateProfile_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockMerchantUC_UpdateProfile_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_UpdateProfile_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockWebhookDeliveryUC_List_Call) Return(webhookDeliveryPage *domain.WebhookDeliveryPage, err error) *MockWebhookDeliveryUC_List_Call {
+	_c.Call.Return(webhookDeliveryPage, err)
 	return _c
 }
 
-func (_c *MockMerchantUC_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest) (*domain.Merchant, error)) *MockMerchantUC_UpdateProfile_Call {
+func (_c *MockWebhookDeliveryUC_List_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, req *domain.ListWebhookDeliveriesRequest) (*domain.WebhookDeliveryPage, error)) *MockWebhookDeliveryUC_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateApiKey provides a mock function for the type MockMerchantUC
-func (_mock *MockMerchantUC) ValidateApiKey(ctx context.Context, apiKey string) (*domain.Merchant, error) {
-	ret := _mock.Called(ctx, apiKey)
+// Redeliver provides a mock function for the type MockWebhookDeliveryUC
+func (_mock *MockWebhookDeliveryUC) Redeliver(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, merchantID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ValidateApiKey")
+		panic("no return value specified for Redeliver")
 	}
 
-	var r0 *domain.Merchant
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Merchant, error)); ok {
-		return returnFunc(ctx, apiKey)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Merchant); ok {
-		r0 = returnFunc(ctx, apiKey)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Merchant)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, apiKey)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, merchantID, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMerchantUC_ValidateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateApiKey'
-type MockMerchantUC_ValidateApiKey_Call struct {
+// MockWebhookDeliveryUC_Redeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redeliver'
+type MockWebhookDeliveryUC_Redeliver_Call struct {
 	*mock.Call
 }
 
-// ValidateApiKey is a helper method to define mock.On call
+// Redeliver is a helper method to define mock.On call
 //   - ctx context.Context
-//   - apiKey string
-func (_e *MockMerchantUC_Expecter) ValidateApiKey(ctx interface{}, apiKey interface{}) *MockMerchantUC_ValidateApiKey_Call {
-	return &MockMerchantUC_ValidateApiKey_Call{Call: _e.mock.On("ValidateApiKey", ctx, apiKey)}
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockWebhookDeliveryUC_Expecter) Redeliver(ctx interface{}, merchantID interface{}, id interface{}) *MockWebhookDeliveryUC_Redeliver_Call {
+	return &MockWebhookDeliveryUC_Redeliver_Call{Call: _e.mock.On("Redeliver", ctx, merchantID, id)}
 }
 
-func (_c *MockMerchantUC_ValidateApiKey_Call) Run(run func(ctx context.Context, apiKey string)) *MockMerchantUC_ValidateApiKey_Call {
+func (_c *MockWebhookDeliveryUC_Redeliver_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockWebhookDeliveryUC_Redeliver_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMerchantUC_ValidateApiKey_Call) Return(merchant *domain.Merchant, err error) *MockMerchantUC_ValidateApiKey_Call {
-	_c.Call.Return(merchant, err)
+func (_c *MockWebhookDeliveryUC_Redeliver_Call) Return(err error) *MockWebhookDeliveryUC_Redeliver_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMerchantUC_ValidateApiKey_Call) RunAndReturn(run func(ctx context.Context, apiKey string) (*domain.Merchant, error)) *MockMerchantUC_ValidateApiKey_Call {
+func (_c *MockWebhookDeliveryUC_Redeliver_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error) *MockWebhookDeliveryUC_Redeliver_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockTransactionRepository creates a new instance of MockTransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockWebhookEndpointRepository creates a new instance of MockWebhookEndpointRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockTransactionRepository(t interface {
+func NewMockWebhookEndpointRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockTransactionRepository {
-	mock := &MockTransactionRepository{}
+}) *MockWebhookEndpointRepository {
+	mock := &MockWebhookEndpointRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -897,196 +6242,254 @@ func NewMockTransactionRepository(t interface {
 	return mock
 }
 
-// MockTransactionRepository is an autogenerated mock type for the TransactionRepository type
-type MockTransactionRepository struct {
+// MockWebhookEndpointRepository is an autogenerated mock type for the WebhookEndpointRepository type
+type MockWebhookEndpointRepository struct {
 	mock.Mock
 }
 
-type MockTransactionRepository_Expecter struct {
+type MockWebhookEndpointRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockTransactionRepository) EXPECT() *MockTransactionRepository_Expecter {
-	return &MockTransactionRepository_Expecter{mock: &_m.Mock}
+func (_m *MockWebhookEndpointRepository) EXPECT() *MockWebhookEndpointRepository_Expecter {
+	return &MockWebhookEndpointRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockTransactionRepository
-func (_mock *MockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
-	ret := _mock.Called(ctx, tx)
+// Create provides a mock function for the type MockWebhookEndpointRepository
+func (_mock *MockWebhookEndpointRepository) Create(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	ret := _mock.Called(ctx, endpoint)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 *domain.Transaction
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) (*domain.Transaction, error)); ok {
-		return returnFunc(ctx, tx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) *domain.Transaction); ok {
-		r0 = returnFunc(ctx, tx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookEndpoint) error); ok {
+		r0 = returnFunc(ctx, endpoint)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Transaction)
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookEndpointRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookEndpointRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - endpoint *domain.WebhookEndpoint
+func (_e *MockWebhookEndpointRepository_Expecter) Create(ctx interface{}, endpoint interface{}) *MockWebhookEndpointRepository_Create_Call {
+	return &MockWebhookEndpointRepository_Create_Call{Call: _e.mock.On("Create", ctx, endpoint)}
+}
+
+func (_c *MockWebhookEndpointRepository_Create_Call) Run(run func(ctx context.Context, endpoint *domain.WebhookEndpoint)) *MockWebhookEndpointRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
+		var arg1 *domain.WebhookEndpoint
+		if args[1] != nil {
+			arg1 = args[1].(*domain.WebhookEndpoint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookEndpointRepository_Create_Call) Return(err error) *MockWebhookEndpointRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookEndpointRepository_Create_Call) RunAndReturn(run func(ctx context.Context, endpoint *domain.WebhookEndpoint) error) *MockWebhookEndpointRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockWebhookEndpointRepository
+func (_mock *MockWebhookEndpointRepository) Delete(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, merchantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Transaction) error); ok {
-		r1 = returnFunc(ctx, tx)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, merchantID, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTransactionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockTransactionRepository_Create_Call struct {
+// MockWebhookEndpointRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookEndpointRepository_Delete_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tx *domain.Transaction
-func (_e *MockTransactionRepository_Expecter) Create(ctx interface{}, tx interface{}) *MockTransactionRepository_Create_Call {
-	return &MockTransactionRepository_Create_Call{Call: _e.mock.On("Create", ctx, tx)}
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockWebhookEndpointRepository_Expecter) Delete(ctx interface{}, merchantID interface{}, id interface{}) *MockWebhookEndpointRepository_Delete_Call {
+	return &MockWebhookEndpointRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, merchantID, id)}
 }
 
-func (_c *MockTransactionRepository_Create_Call) Run(run func(ctx context.Context, tx *domain.Transaction)) *MockTransactionRepository_Create_Call {
+func (_c *MockWebhookEndpointRepository_Delete_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockWebhookEndpointRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Transaction
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Transaction)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepository_Create_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_Create_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockWebhookEndpointRepository_Delete_Call) Return(err error) *MockWebhookEndpointRepository_Delete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTransactionRepository_Create_Call) RunAndReturn(run func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error)) *MockTransactionRepository_Create_Call {
+func (_c *MockWebhookEndpointRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error) *MockWebhookEndpointRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByOrderID provides a mock function for the type MockTransactionRepository
-func (_mock *MockTransactionRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Transaction, error) {
-	ret := _mock.Called(ctx, orderID)
+// GetByMerchant provides a mock function for the type MockWebhookEndpointRepository
+func (_mock *MockWebhookEndpointRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	ret := _mock.Called(ctx, merchantID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByOrderID")
+		panic("no return value specified for GetByMerchant")
 	}
 
-	var r0 *domain.Transaction
+	var r0 *domain.WebhookEndpoint
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Transaction, error)); ok {
-		return returnFunc(ctx, orderID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.WebhookEndpoint, error)); ok {
+		return returnFunc(ctx, merchantID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Transaction); ok {
-		r0 = returnFunc(ctx, orderID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.WebhookEndpoint); ok {
+		r0 = returnFunc(ctx, merchantID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Transaction)
+			r0 = ret.Get(0).(*domain.WebhookEndpoint)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, orderID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTransactionRepository_FindByOrderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOrderID'
-type MockTransactionRepository_FindByOrderID_Call struct {
+// MockWebhookEndpointRepository_GetByMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByMerchant'
+type MockWebhookEndpointRepository_GetByMerchant_Call struct {
 	*mock.Call
 }
 
-// FindByOrderID is a helper method to define mock.On call
+// GetByMerchant is a helper method to define mock.On call
 //   - ctx context.Context
-//   - orderID string
-func (_e *MockTransactionRepository_Expecter) FindByOrderID(ctx interface{}, orderID interface{}) *MockTransactionRepository_FindByOrderID_Call {
-	return &MockTransactionRepository_FindByOrderID_Call{Call: _e.mock.On("FindByOrderID", ctx, orderID)}
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockWebhookEndpointRepository_Expecter) GetByMerchant(ctx interface{}, merchantID interface{}, id interface{}) *MockWebhookEndpointRepository_GetByMerchant_Call {
+	return &MockWebhookEndpointRepository_GetByMerchant_Call{Call: _e.mock.On("GetByMerchant", ctx, merchantID, id)}
 }
 
-func (_c *MockTransactionRepository_FindByOrderID_Call) Run(run func(ctx context.Context, orderID string)) *MockTransactionRepository_FindByOrderID_Call {
+func (_c *MockWebhookEndpointRepository_GetByMerchant_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockWebhookEndpointRepository_GetByMerchant_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByOrderID_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_FindByOrderID_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockWebhookEndpointRepository_GetByMerchant_Call) Return(webhookEndpoint *domain.WebhookEndpoint, err error) *MockWebhookEndpointRepository_GetByMerchant_Call {
+	_c.Call.Return(webhookEndpoint, err)
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByOrderID_Call) RunAndReturn(run func(ctx context.Context, orderID string) (*domain.Transaction, error)) *MockTransactionRepository_FindByOrderID_Call {
+func (_c *MockWebhookEndpointRepository_GetByMerchant_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookEndpoint, error)) *MockWebhookEndpointRepository_GetByMerchant_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockTransactionRepository
-func (_mock *MockTransactionRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
-	ret := _mock.Called(ctx, id)
+// ListByMerchant provides a mock function for the type MockWebhookEndpointRepository
+func (_mock *MockWebhookEndpointRepository) ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]*domain.WebhookEndpoint, error) {
+	ret := _mock.Called(ctx, merchantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for ListByMerchant")
 	}
 
-	var r0 *domain.Transaction
+	var r0 []*domain.WebhookEndpoint
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Transaction, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.WebhookEndpoint, error)); ok {
+		return returnFunc(ctx, merchantID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Transaction); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.WebhookEndpoint); ok {
+		r0 = returnFunc(ctx, merchantID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Transaction)
+			r0 = ret.Get(0).([]*domain.WebhookEndpoint)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, merchantID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTransactionRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockTransactionRepository_Get_Call struct {
+// MockWebhookEndpointRepository_ListByMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMerchant'
+type MockWebhookEndpointRepository_ListByMerchant_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// ListByMerchant is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockTransactionRepository_Expecter) Get(ctx interface{}, id interface{}) *MockTransactionRepository_Get_Call {
-	return &MockTransactionRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+//   - merchantID uuid.UUID
+func (_e *MockWebhookEndpointRepository_Expecter) ListByMerchant(ctx interface{}, merchantID interface{}) *MockWebhookEndpointRepository_ListByMerchant_Call {
+	return &MockWebhookEndpointRepository_ListByMerchant_Call{Call: _e.mock.On("ListByMerchant", ctx, merchantID)}
 }
 
-func (_c *MockTransactionRepository_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTransactionRepository_Get_Call {
+func (_c *MockWebhookEndpointRepository_ListByMerchant_Call) Run(run func(ctx context.Context, merchantID uuid.UUID)) *MockWebhookEndpointRepository_ListByMerchant_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1104,65 +6507,54 @@ func (_c *MockTransactionRepository_Get_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockTransactionRepository_Get_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_Get_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockWebhookEndpointRepository_ListByMerchant_Call) Return(webhookEndpoints []*domain.WebhookEndpoint, err error) *MockWebhookEndpointRepository_ListByMerchant_Call {
+	_c.Call.Return(webhookEndpoints, err)
 	return _c
 }
 
-func (_c *MockTransactionRepository_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)) *MockTransactionRepository_Get_Call {
+func (_c *MockWebhookEndpointRepository_ListByMerchant_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID) ([]*domain.WebhookEndpoint, error)) *MockWebhookEndpointRepository_ListByMerchant_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockTransactionRepository
-func (_mock *MockTransactionRepository) Update(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
-	ret := _mock.Called(ctx, tx)
+// Update provides a mock function for the type MockWebhookEndpointRepository
+func (_mock *MockWebhookEndpointRepository) Update(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	ret := _mock.Called(ctx, endpoint)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 *domain.Transaction
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) (*domain.Transaction, error)); ok {
-		return returnFunc(ctx, tx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Transaction) *domain.Transaction); ok {
-		r0 = returnFunc(ctx, tx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Transaction)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Transaction) error); ok {
-		r1 = returnFunc(ctx, tx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.WebhookEndpoint) error); ok {
+		r0 = returnFunc(ctx, endpoint)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTransactionRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockTransactionRepository_Update_Call struct {
+// MockWebhookEndpointRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockWebhookEndpointRepository_Update_Call struct {
 	*mock.Call
 }
 
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tx *domain.Transaction
-func (_e *MockTransactionRepository_Expecter) Update(ctx interface{}, tx interface{}) *MockTransactionRepository_Update_Call {
-	return &MockTransactionRepository_Update_Call{Call: _e.mock.On("Update", ctx, tx)}
+//   - endpoint *domain.WebhookEndpoint
+func (_e *MockWebhookEndpointRepository_Expecter) Update(ctx interface{}, endpoint interface{}) *MockWebhookEndpointRepository_Update_Call {
+	return &MockWebhookEndpointRepository_Update_Call{Call: _e.mock.On("Update", ctx, endpoint)}
 }
 
-func (_c *MockTransactionRepository_Update_Call) Run(run func(ctx context.Context, tx *domain.Transaction)) *MockTransactionRepository_Update_Call {
+func (_c *MockWebhookEndpointRepository_Update_Call) Run(run func(ctx context.Context, endpoint *domain.WebhookEndpoint)) *MockWebhookEndpointRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Transaction
+		var arg1 *domain.WebhookEndpoint
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Transaction)
+			arg1 = args[1].(*domain.WebhookEndpoint)
 		}
 		run(
 			arg0,
@@ -1172,23 +6564,23 @@ func (_c *MockTransactionRepository_Update_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockTransactionRepository_Update_Call) Return(transaction *domain.Transaction, err error) *MockTransactionRepository_Update_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockWebhookEndpointRepository_Update_Call) Return(err error) *MockWebhookEndpointRepository_Update_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTransactionRepository_Update_Call) RunAndReturn(run func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error)) *MockTransactionRepository_Update_Call {
+func (_c *MockWebhookEndpointRepository_Update_Call) RunAndReturn(run func(ctx context.Context, endpoint *domain.WebhookEndpoint) error) *MockWebhookEndpointRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockTransactionUC creates a new instance of MockTransactionUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockWebhookEndpointUC creates a new instance of MockWebhookEndpointUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockTransactionUC(t interface {
+func NewMockWebhookEndpointUC(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockTransactionUC {
-	mock := &MockTransactionUC{}
+}) *MockWebhookEndpointUC {
+	mock := &MockWebhookEndpointUC{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -1196,40 +6588,40 @@ func NewMockTransactionUC(t interface {
 	return mock
 }
 
-// MockTransactionUC is an autogenerated mock type for the TransactionUC type
-type MockTransactionUC struct {
+// MockWebhookEndpointUC is an autogenerated mock type for the WebhookEndpointUC type
+type MockWebhookEndpointUC struct {
 	mock.Mock
 }
 
-type MockTransactionUC_Expecter struct {
+type MockWebhookEndpointUC_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockTransactionUC) EXPECT() *MockTransactionUC_Expecter {
-	return &MockTransactionUC_Expecter{mock: &_m.Mock}
+func (_m *MockWebhookEndpointUC) EXPECT() *MockWebhookEndpointUC_Expecter {
+	return &MockWebhookEndpointUC_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockTransactionUC
-func (_mock *MockTransactionUC) Create(ctx context.Context, merchantID uuid.UUID, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+// Create provides a mock function for the type MockWebhookEndpointUC
+func (_mock *MockWebhookEndpointUC) Create(ctx context.Context, merchantID uuid.UUID, req *domain.CreateWebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
 	ret := _mock.Called(ctx, merchantID, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 *domain.Transaction
+	var r0 *domain.WebhookEndpoint
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.CreateTransactionRequest) (*domain.Transaction, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.CreateWebhookEndpointRequest) (*domain.WebhookEndpoint, error)); ok {
 		return returnFunc(ctx, merchantID, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.CreateTransactionRequest) *domain.Transaction); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *domain.CreateWebhookEndpointRequest) *domain.WebhookEndpoint); ok {
 		r0 = returnFunc(ctx, merchantID, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Transaction)
+			r0 = ret.Get(0).(*domain.WebhookEndpoint)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *domain.CreateTransactionRequest) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *domain.CreateWebhookEndpointRequest) error); ok {
 		r1 = returnFunc(ctx, merchantID, req)
 	} else {
 		r1 = ret.Error(1)
@@ -1237,20 +6629,20 @@ func (_mock *MockTransactionUC) Create(ctx context.Context, merchantID uuid.UUID
 	return r0, r1
 }
 
-// MockTransactionUC_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockTransactionUC_Create_Call struct {
+// MockWebhookEndpointUC_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookEndpointUC_Create_Call struct {
 	*mock.Call
 }
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
 //   - merchantID uuid.UUID
-//   - req *domain.CreateTransactionRequest
-func (_e *MockTransactionUC_Expecter) Create(ctx interface{}, merchantID interface{}, req interface{}) *MockTransactionUC_Create_Call {
-	return &MockTransactionUC_Create_Call{Call: _e.mock.On("Create", ctx, merchantID, req)}
+//   - req *domain.CreateWebhookEndpointRequest
+func (_e *MockWebhookEndpointUC_Expecter) Create(ctx interface{}, merchantID interface{}, req interface{}) *MockWebhookEndpointUC_Create_Call {
+	return &MockWebhookEndpointUC_Create_Call{Call: _e.mock.On("Create", ctx, merchantID, req)}
 }
 
-func (_c *MockTransactionUC_Create_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, req *domain.CreateTransactionRequest)) *MockTransactionUC_Create_Call {
+func (_c *MockWebhookEndpointUC_Create_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, req *domain.CreateWebhookEndpointRequest)) *MockWebhookEndpointUC_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1260,9 +6652,9 @@ func (_c *MockTransactionUC_Create_Call) Run(run func(ctx context.Context, merch
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *domain.CreateTransactionRequest
+		var arg2 *domain.CreateWebhookEndpointRequest
 		if args[2] != nil {
-			arg2 = args[2].(*domain.CreateTransactionRequest)
+			arg2 = args[2].(*domain.CreateWebhookEndpointRequest)
 		}
 		run(
 			arg0,
@@ -1273,57 +6665,121 @@ func (_c *MockTransactionUC_Create_Call) Run(run func(ctx context.Context, merch
 	return _c
 }
 
-func (_c *MockTransactionUC_Create_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_Create_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockWebhookEndpointUC_Create_Call) Return(webhookEndpoint *domain.WebhookEndpoint, err error) *MockWebhookEndpointUC_Create_Call {
+	_c.Call.Return(webhookEndpoint, err)
 	return _c
 }
 
-func (_c *MockTransactionUC_Create_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, req *domain.CreateTransactionRequest) (*domain.Transaction, error)) *MockTransactionUC_Create_Call {
+func (_c *MockWebhookEndpointUC_Create_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, req *domain.CreateWebhookEndpointRequest) (*domain.WebhookEndpoint, error)) *MockWebhookEndpointUC_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockTransactionUC
-func (_mock *MockTransactionUC) Get(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
-	ret := _mock.Called(ctx, id)
+// Delete provides a mock function for the type MockWebhookEndpointUC
+func (_mock *MockWebhookEndpointUC) Delete(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, merchantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, merchantID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookEndpointUC_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookEndpointUC_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockWebhookEndpointUC_Expecter) Delete(ctx interface{}, merchantID interface{}, id interface{}) *MockWebhookEndpointUC_Delete_Call {
+	return &MockWebhookEndpointUC_Delete_Call{Call: _e.mock.On("Delete", ctx, merchantID, id)}
+}
+
+func (_c *MockWebhookEndpointUC_Delete_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockWebhookEndpointUC_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookEndpointUC_Delete_Call) Return(err error) *MockWebhookEndpointUC_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookEndpointUC_Delete_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error) *MockWebhookEndpointUC_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockWebhookEndpointUC
+func (_mock *MockWebhookEndpointUC) Get(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	ret := _mock.Called(ctx, merchantID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 *domain.Transaction
+	var r0 *domain.WebhookEndpoint
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Transaction, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.WebhookEndpoint, error)); ok {
+		return returnFunc(ctx, merchantID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Transaction); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.WebhookEndpoint); ok {
+		r0 = returnFunc(ctx, merchantID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Transaction)
+			r0 = ret.Get(0).(*domain.WebhookEndpoint)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTransactionUC_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockTransactionUC_Get_Call struct {
+// MockWebhookEndpointUC_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockWebhookEndpointUC_Get_Call struct {
 	*mock.Call
 }
 
 // Get is a helper method to define mock.On call
 //   - ctx context.Context
+//   - merchantID uuid.UUID
 //   - id uuid.UUID
-func (_e *MockTransactionUC_Expecter) Get(ctx interface{}, id interface{}) *MockTransactionUC_Get_Call {
-	return &MockTransactionUC_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+func (_e *MockWebhookEndpointUC_Expecter) Get(ctx interface{}, merchantID interface{}, id interface{}) *MockWebhookEndpointUC_Get_Call {
+	return &MockWebhookEndpointUC_Get_Call{Call: _e.mock.On("Get", ctx, merchantID, id)}
 }
 
-func (_c *MockTransactionUC_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTransactionUC_Get_Call {
+func (_c *MockWebhookEndpointUC_Get_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID)) *MockWebhookEndpointUC_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1333,62 +6789,78 @@ func (_c *MockTransactionUC_Get_Call) Run(run func(ctx context.Context, id uuid.
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTransactionUC_Get_Call) Return(transaction *domain.Transaction, err error) *MockTransactionUC_Get_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockWebhookEndpointUC_Get_Call) Return(webhookEndpoint *domain.WebhookEndpoint, err error) *MockWebhookEndpointUC_Get_Call {
+	_c.Call.Return(webhookEndpoint, err)
 	return _c
 }
 
-func (_c *MockTransactionUC_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)) *MockTransactionUC_Get_Call {
+func (_c *MockWebhookEndpointUC_Get_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookEndpoint, error)) *MockWebhookEndpointUC_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HandleNotification provides a mock function for the type MockTransactionUC
-func (_mock *MockTransactionUC) HandleNotification(ctx context.Context, req *domain.UpdateStatusRequest) error {
-	ret := _mock.Called(ctx, req)
+// List provides a mock function for the type MockWebhookEndpointUC
+func (_mock *MockWebhookEndpointUC) List(ctx context.Context, merchantID uuid.UUID) ([]*domain.WebhookEndpoint, error) {
+	ret := _mock.Called(ctx, merchantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HandleNotification")
+		panic("no return value specified for List")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UpdateStatusRequest) error); ok {
-		r0 = returnFunc(ctx, req)
+	var r0 []*domain.WebhookEndpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.WebhookEndpoint, error)); ok {
+		return returnFunc(ctx, merchantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.WebhookEndpoint); ok {
+		r0 = returnFunc(ctx, merchantID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WebhookEndpoint)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, merchantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTransactionUC_HandleNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleNotification'
-type MockTransactionUC_HandleNotification_Call struct {
+// MockWebhookEndpointUC_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookEndpointUC_List_Call struct {
 	*mock.Call
 }
 
-// HandleNotification is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.UpdateStatusRequest
-func (_e *MockTransactionUC_Expecter) HandleNotification(ctx interface{}, req interface{}) *MockTransactionUC_HandleNotification_Call {
-	return &MockTransactionUC_HandleNotification_Call{Call: _e.mock.On("HandleNotification", ctx, req)}
+//   - merchantID uuid.UUID
+func (_e *MockWebhookEndpointUC_Expecter) List(ctx interface{}, merchantID interface{}) *MockWebhookEndpointUC_List_Call {
+	return &MockWebhookEndpointUC_List_Call{Call: _e.mock.On("List", ctx, merchantID)}
 }
 
-func (_c *MockTransactionUC_HandleNotification_Call) Run(run func(ctx context.Context, req *domain.UpdateStatusRequest)) *MockTransactionUC_HandleNotification_Call {
+func (_c *MockWebhookEndpointUC_List_Call) Run(run func(ctx context.Context, merchantID uuid.UUID)) *MockWebhookEndpointUC_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.UpdateStatusRequest
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(*domain.UpdateStatusRequest)
+			arg1 = args[1].(uuid.UUID)
 		}
 		run(
 			arg0,
@@ -1398,12 +6870,92 @@ func (_c *MockTransactionUC_HandleNotification_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockTransactionUC_HandleNotification_Call) Return(err error) *MockTransactionUC_HandleNotification_Call {
-	_c.Call.Return(err)
+func (_c *MockWebhookEndpointUC_List_Call) Return(webhookEndpoints []*domain.WebhookEndpoint, err error) *MockWebhookEndpointUC_List_Call {
+	_c.Call.Return(webhookEndpoints, err)
 	return _c
 }
 
-func (_c *MockTransactionUC_HandleNotification_Call) RunAndReturn(run func(ctx context.Context, req *domain.UpdateStatusRequest) error) *MockTransactionUC_HandleNotification_Call {
+func (_c *MockWebhookEndpointUC_List_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID) ([]*domain.WebhookEndpoint, error)) *MockWebhookEndpointUC_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockWebhookEndpointUC
+func (_mock *MockWebhookEndpointUC) Update(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, req *domain.UpdateWebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	ret := _mock.Called(ctx, merchantID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *domain.WebhookEndpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *domain.UpdateWebhookEndpointRequest) (*domain.WebhookEndpoint, error)); ok {
+		return returnFunc(ctx, merchantID, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *domain.UpdateWebhookEndpointRequest) *domain.WebhookEndpoint); ok {
+		r0 = returnFunc(ctx, merchantID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookEndpoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *domain.UpdateWebhookEndpointRequest) error); ok {
+		r1 = returnFunc(ctx, merchantID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookEndpointUC_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockWebhookEndpointUC_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merchantID uuid.UUID
+//   - id uuid.UUID
+//   - req *domain.UpdateWebhookEndpointRequest
+func (_e *MockWebhookEndpointUC_Expecter) Update(ctx interface{}, merchantID interface{}, id interface{}, req interface{}) *MockWebhookEndpointUC_Update_Call {
+	return &MockWebhookEndpointUC_Update_Call{Call: _e.mock.On("Update", ctx, merchantID, id, req)}
+}
+
+func (_c *MockWebhookEndpointUC_Update_Call) Run(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, req *domain.UpdateWebhookEndpointRequest)) *MockWebhookEndpointUC_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 *domain.UpdateWebhookEndpointRequest
+		if args[3] != nil {
+			arg3 = args[3].(*domain.UpdateWebhookEndpointRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookEndpointUC_Update_Call) Return(webhookEndpoint *domain.WebhookEndpoint, err error) *MockWebhookEndpointUC_Update_Call {
+	_c.Call.Return(webhookEndpoint, err)
+	return _c
+}
+
+func (_c *MockWebhookEndpointUC_Update_Call) RunAndReturn(run func(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, req *domain.UpdateWebhookEndpointRequest) (*domain.WebhookEndpoint, error)) *MockWebhookEndpointUC_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
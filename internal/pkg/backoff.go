@@ -0,0 +1,33 @@
+package pkg
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff doubles min for every attempt after the first and caps the result at max
+func Backoff(attempt int, min, max time.Duration) time.Duration {
+	if attempt <= 0 {
+		return min
+	}
+
+	delay := min
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= max || delay <= 0 {
+			return max
+		}
+	}
+	return delay
+}
+
+// BackoffWithJitter picks a random delay between half and all of Backoff, so deliveries that
+// failed together do not all retry at the same moment
+func BackoffWithJitter(attempt int, min, max time.Duration) time.Duration {
+	delay := Backoff(attempt, min, max)
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(delay-half+1)
+}
@@ -0,0 +1,63 @@
+package pkg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{5, 32 * time.Minute},
+		{6, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, pkg.Backoff(tt.attempt, time.Minute, time.Hour))
+	}
+}
+
+func TestBackoffWithJitter(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		full := pkg.Backoff(attempt, time.Second, time.Minute)
+		for i := 0; i < 20; i++ {
+			delay := pkg.BackoffWithJitter(attempt, time.Second, time.Minute)
+			assert.GreaterOrEqual(t, delay, full/2)
+			assert.LessOrEqual(t, delay, full)
+		}
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := pkg.NewRateLimiter(20)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Wait(ctx))
+	}
+
+	// the first call is free, the next two wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	limiter := pkg.NewRateLimiter(0.1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
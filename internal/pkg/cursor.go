@@ -0,0 +1,20 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor turns a pagination position into an opaque, URL-safe token
+func EncodeCursor(position interface{}) string {
+	return base64.RawURLEncoding.EncodeToString(ToJSON(position))
+}
+
+// DecodeCursor reads a token produced by EncodeCursor back into position
+func DecodeCursor(cursor string, position interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, position)
+}
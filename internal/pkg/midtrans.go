@@ -14,7 +14,7 @@ func MapMidtransStatus(midtransStatus, fraudStatus string) string {
 	case "deny", "failure", "cancel":
 		return "FAILED"
 	case "expire":
-		return "FAILED"
+		return "EXPIRED"
 	default:
 		return "PENDING"
 	}
@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces calls evenly so that no more than perSecond of them start each second
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter returns a limiter that never blocks when perSecond is zero or negative
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	var interval time.Duration
+	if perSecond > 0 {
+		interval = time.Duration(float64(time.Second) / perSecond)
+	}
+
+	return &RateLimiter{
+		interval: interval,
+	}
+}
+
+// Wait blocks until the caller may proceed or ctx is done
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
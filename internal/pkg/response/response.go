@@ -32,14 +32,17 @@ func Error(c *gin.Context, code int, status string, message string) {
 }
 
 type RegisterMerchantResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	Status      string    `json:"status"`
-	ApiKey      string    `json:"api_key"`
-	CallbackURL string    `json:"callback_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	Status        string    `json:"status"`
+	ApiKey        string    `json:"api_key"`
+	TestApiKey    string    `json:"test_api_key"`
+	WebhookSecret string    `json:"webhook_secret"`
+	CallbackURL   string    `json:"callback_url"`
+	APIVersion    string    `json:"api_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type GetMerchantResponse struct {
@@ -49,26 +52,102 @@ type GetMerchantResponse struct {
 	Status      string    `json:"status"`
 	Balance     int64     `json:"balance"`
 	CallbackURL string    `json:"callback_url"`
+	APIVersion  string    `json:"api_version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type GenerateApiKeyResponse struct {
-	ApiKey string `json:"api_key"`
+	ApiKey   string `json:"api_key"`
+	Livemode bool   `json:"livemode"`
+	// PreviousKeyExpiresAt is when the replaced key stops working, omitted when it was revoked right away
+	PreviousKeyExpiresAt *time.Time `json:"previous_key_expires_at,omitempty"`
+}
+
+type RotateWebhookSecretResponse struct {
+	WebhookSecret string `json:"webhook_secret"`
 }
 
 type CreateTransactionResponse struct {
+	ID              string    `json:"id"`
+	MerchantID      string    `json:"merchant_id"`
+	OrderID         string    `json:"order_id"`
+	ProviderOrderID string    `json:"provider_order_id"`
+	Provider        string    `json:"provider"`
+	Currency        string    `json:"currency"`
+	Amount          int64     `json:"amount"`
+	RefundedAmount  int64     `json:"refunded_amount"`
+	Status          string    `json:"status"`
+	PaymentMethod   string    `json:"payment_method"`
+	PaymentURL      string    `json:"payment_url"`
+	ExternalID      string    `json:"external_id"`
+	Livemode        bool      `json:"livemode"`
+	ExpiredAt       time.Time `json:"expired_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type TransactionListResponse struct {
+	Transactions []CreateTransactionResponse `json:"transactions"`
+	NextCursor   string                      `json:"next_cursor,omitempty"`
+	HasMore      bool                        `json:"has_more"`
+}
+
+type RefundResponse struct {
 	ID            string    `json:"id"`
-	MerchantID    string    `json:"merchant_id"`
-	OrderID       string    `json:"order_id"`
-	Provider      string    `json:"provider"`
-	Currency      string    `json:"currency"`
+	TransactionID string    `json:"transaction_id"`
 	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+	Reason        string    `json:"reason"`
 	Status        string    `json:"status"`
-	PaymentMethod string    `json:"payment_method"`
-	PaymentURL    string    `json:"payment_url"`
 	ExternalID    string    `json:"external_id"`
-	ExpiredAt     time.Time `json:"expired_at"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
+
+type WebhookDeliveryResponse struct {
+	ID             string    `json:"id"`
+	EndpointID     string    `json:"endpoint_id,omitempty"`
+	TransactionID  string    `json:"transaction_id"`
+	Event          string    `json:"event,omitempty"`
+	EventStatus    string    `json:"event_status"`
+	URL            string    `json:"url"`
+	Attempt        int       `json:"attempt"`
+	Succeeded      bool      `json:"succeeded"`
+	RequestBody    string    `json:"request_body"`
+	ResponseStatus int       `json:"response_status,omitempty"`
+	ResponseBody   string    `json:"response_body,omitempty"`
+	LatencyMs      int64     `json:"latency_ms"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type WebhookEndpointResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	Events      []string  `json:"events"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ApiKeyResponse carries the raw key only in the response to its creation
+type ApiKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Livemode   bool       `json:"livemode"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+	HasMore    bool                      `json:"has_more"`
+}
@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stripeZeroDecimalCurrencies lists currencies Stripe expects in their major unit
+var stripeZeroDecimalCurrencies = map[string]bool{
+	"BIF": true,
+	"CLP": true,
+	"DJF": true,
+	"GNF": true,
+	"JPY": true,
+	"KMF": true,
+	"KRW": true,
+	"MGA": true,
+	"PYG": true,
+	"RWF": true,
+	"UGX": true,
+	"VND": true,
+	"VUV": true,
+	"XAF": true,
+	"XOF": true,
+	"XPF": true,
+}
+
+// ToStripeAmount converts an amount in major units into Stripe's smallest currency unit
+func ToStripeAmount(amount int64, currency string) int64 {
+	if stripeZeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return amount
+	}
+	return amount * 100
+}
+
+func MapStripeStatus(sessionStatus, paymentStatus string) string {
+	switch sessionStatus {
+	case "complete":
+		if paymentStatus == "paid" || paymentStatus == "no_payment_required" {
+			return "PAID"
+		}
+		return "PENDING"
+	case "open":
+		return "PENDING"
+	case "expired":
+		return "EXPIRED"
+	default:
+		return "PENDING"
+	}
+}
+
+func MapStripeRefundStatus(refundStatus string) string {
+	switch refundStatus {
+	case "succeeded":
+		return "SUCCEEDED"
+	case "failed", "canceled":
+		return "FAILED"
+	default:
+		return "PENDING"
+	}
+}
+
+// VerifySignatureStripe checks a Stripe-Signature header of the form "t=<unix>,v1=<hex>[,v1=<hex>]"
+// against the raw request body and rejects events signed outside the tolerance window
+func VerifySignatureStripe(payload []byte, sigHeader, secret string, tolerance time.Duration) bool {
+	if secret == "" || sigHeader == "" {
+		return false
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(sigHeader, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return false
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := time.Since(time.Unix(unix, 0))
+	if tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		decoded, err := hex.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(expected, decoded) {
+			return true
+		}
+	}
+
+	return false
+}
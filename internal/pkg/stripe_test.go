@@ -0,0 +1,78 @@
+package pkg_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signStripePayload(payload []byte, secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, payload)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignatureStripe(t *testing.T) {
+	secret := "whsec_test_secret"
+	payload := []byte(`{"id":"evt_123","type":"payment_intent.succeeded"}`)
+	now := time.Now().Unix()
+	stale := time.Now().Add(-10 * time.Minute).Unix()
+
+	tests := []struct {
+		name      string
+		sigHeader string
+		secret    string
+		want      bool
+	}{
+		{
+			name:      "Valid Signature",
+			sigHeader: fmt.Sprintf("t=%d,v1=%s", now, signStripePayload(payload, secret, now)),
+			secret:    secret,
+			want:      true,
+		},
+		{
+			name:      "Valid Signature Among Rolled Secrets",
+			sigHeader: fmt.Sprintf("t=%d,v1=%s,v1=%s,v0=legacy", now, signStripePayload(payload, "whsec_old", now), signStripePayload(payload, secret, now)),
+			secret:    secret,
+			want:      true,
+		},
+		{
+			name:      "Wrong Secret",
+			sigHeader: fmt.Sprintf("t=%d,v1=%s", now, signStripePayload(payload, "whsec_other", now)),
+			secret:    secret,
+			want:      false,
+		},
+		{
+			name:      "Outside Tolerance",
+			sigHeader: fmt.Sprintf("t=%d,v1=%s", stale, signStripePayload(payload, secret, stale)),
+			secret:    secret,
+			want:      false,
+		},
+		{
+			name:      "Missing Timestamp",
+			sigHeader: "v1=" + signStripePayload(payload, secret, now),
+			secret:    secret,
+			want:      false,
+		},
+		{
+			name:      "Empty Secret",
+			sigHeader: fmt.Sprintf("t=%d,v1=%s", now, signStripePayload(payload, "", now)),
+			secret:    "",
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pkg.VerifySignatureStripe(payload, tt.sigHeader, tt.secret, 5*time.Minute)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,12 +1,28 @@
 package pkg
 
+import "crypto/subtle"
+
 func MapXenditStatus(xenditStatus string) string {
 	switch xenditStatus {
-	case "PAID":
+	case "PAID", "SETTLED":
 		return "PAID"
 	case "PENDING":
 		return "PENDING"
-	case "EXPIRED", "CANCELLED":
+	case "EXPIRED":
+		return "EXPIRED"
+	case "CANCELLED":
+		return "FAILED"
+	default:
+		return "PENDING"
+	}
+}
+
+// MapXenditRefundStatus maps the status of a Xendit refund callback, unknown statuses stay PENDING
+func MapXenditRefundStatus(xenditStatus string) string {
+	switch xenditStatus {
+	case "SUCCEEDED":
+		return "SUCCEEDED"
+	case "FAILED", "CANCELLED":
 		return "FAILED"
 	default:
 		return "PENDING"
@@ -19,3 +35,11 @@ func VerifySignature(orderID, statusCode, grossAmount, serverKey, signatureKey s
 
 	return expectedSignature == signatureKey
 }
+
+func VerifyCallbackTokenXendit(callbackToken, expectedToken string) bool {
+	if expectedToken == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(callbackToken), []byte(expectedToken)) == 1
+}
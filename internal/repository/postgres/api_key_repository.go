@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApiKeyModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key"`
+	MerchantID uuid.UUID `gorm:"type:uuid;not null"`
+	Name       string    `gorm:"size:100;not null"`
+	KeyHash    string    `gorm:"size:255;unique;not null"`
+	Prefix     string    `gorm:"size:32;not null"`
+	Scopes     []byte    `gorm:"type:jsonb;not null"`
+	Livemode   bool      `gorm:"not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (ApiKeyModel) TableName() string {
+	return "api_keys"
+}
+
+func toApiKeyModel(k *domain.ApiKey) (*ApiKeyModel, error) {
+	scopes, err := json.Marshal(k.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApiKeyModel{
+		ID:         k.ID,
+		MerchantID: k.MerchantID,
+		Name:       k.Name,
+		KeyHash:    k.KeyHash,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		Livemode:   k.Livemode,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}, nil
+}
+
+func (m *ApiKeyModel) toDomain() *domain.ApiKey {
+	var scopes []string
+	_ = json.Unmarshal(m.Scopes, &scopes)
+
+	return &domain.ApiKey{
+		ID:         m.ID,
+		MerchantID: m.MerchantID,
+		Name:       m.Name,
+		KeyHash:    m.KeyHash,
+		Prefix:     m.Prefix,
+		Scopes:     scopes,
+		Livemode:   m.Livemode,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		RevokedAt:  m.RevokedAt,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewApiKeyRepository(db *gorm.DB) domain.ApiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.ApiKey) error {
+	model, err := toApiKeyModel(key)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.ApiKey, error) {
+	var model ApiKeyModel
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrApiKeyNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+func (r *apiKeyRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.ApiKey, error) {
+	var model ApiKeyModel
+	if err := r.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrApiKeyNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// ListByMerchant returns every key of a merchant, revoked ones included, oldest first
+func (r *apiKeyRepository) ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]*domain.ApiKey, error) {
+	var models []ApiKeyModel
+	if err := r.db.WithContext(ctx).Where("merchant_id = ?", merchantID).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*domain.ApiKey, 0, len(models))
+	for i := range models {
+		keys = append(keys, models[i].toDomain())
+	}
+	return keys, nil
+}
+
+// Revoke keeps the row so the key still shows up in the merchant's list
+func (r *apiKeyRepository) Revoke(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, revokedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&ApiKeyModel{}).
+		Where("id = ? AND merchant_id = ? AND revoked_at IS NULL", id, merchantID).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrApiKeyNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&ApiKeyModel{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
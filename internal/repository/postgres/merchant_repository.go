@@ -15,10 +15,22 @@ type MerchantModel struct {
 	Email       string    `gorm:"size:255;unique;not null"`
 	ApiKey      string    `gorm:"size:255;unique"`
 	CallbackURL string    `gorm:"size:255"`
+	APIVersion  string    `gorm:"size:20;not null"`
 	Status      string    `gorm:"size:50;not null;default:'ACTIVE'"`
 	Balance     int64     `gorm:"default:0;not null"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// TestApiKey is NULL for merchants registered before test mode until they generate one
+	TestApiKey *string `gorm:"size:255;unique"`
+
+	// keys replaced with a grace period, NULL when there is none
+	PreviousApiKey              *string `gorm:"size:255;index"`
+	PreviousApiKeyExpiresAt     *time.Time
+	PreviousTestApiKey          *string `gorm:"size:255;index"`
+	PreviousTestApiKeyExpiresAt *time.Time
+
+	WebhookSecret string `gorm:"size:255;not null"`
 }
 
 func (MerchantModel) TableName() string {
@@ -27,31 +39,77 @@ func (MerchantModel) TableName() string {
 
 // toMerchantModel converts domain.Merchant to MerchantModel
 func toMerchantModel(d *domain.Merchant) *MerchantModel {
+	var testApiKey *string
+	if d.TestAPIKeyHash != "" {
+		testApiKey = &d.TestAPIKeyHash
+	}
+
+	var previousApiKey, previousTestApiKey *string
+	if d.PreviousAPIKeyHash != "" {
+		previousApiKey = &d.PreviousAPIKeyHash
+	}
+	if d.PreviousTestAPIKeyHash != "" {
+		previousTestApiKey = &d.PreviousTestAPIKeyHash
+	}
+
 	return &MerchantModel{
 		ID:          d.ID,
 		Name:        d.Name,
 		Email:       d.Email,
 		ApiKey:      d.APIKeyHash,
 		CallbackURL: d.CallbackURL,
+		APIVersion:  d.APIVersion,
 		Status:      string(d.Status),
 		Balance:     d.Balance,
 		CreatedAt:   d.CreatedAt,
 		UpdatedAt:   d.UpdatedAt,
+
+		TestApiKey: testApiKey,
+
+		PreviousApiKey:              previousApiKey,
+		PreviousApiKeyExpiresAt:     d.PreviousAPIKeyExpiresAt,
+		PreviousTestApiKey:          previousTestApiKey,
+		PreviousTestApiKeyExpiresAt: d.PreviousTestAPIKeyExpiresAt,
+
+		WebhookSecret: d.WebhookSecret,
 	}
 }
 
 // toDomain converts MerchantModel to domain.Merchant
 func (m *MerchantModel) toDomain() *domain.Merchant {
+	var testApiKeyHash string
+	if m.TestApiKey != nil {
+		testApiKeyHash = *m.TestApiKey
+	}
+
+	var previousApiKeyHash, previousTestApiKeyHash string
+	if m.PreviousApiKey != nil {
+		previousApiKeyHash = *m.PreviousApiKey
+	}
+	if m.PreviousTestApiKey != nil {
+		previousTestApiKeyHash = *m.PreviousTestApiKey
+	}
+
 	return &domain.Merchant{
 		ID:          m.ID,
 		Name:        m.Name,
 		Email:       m.Email,
 		APIKeyHash:  m.ApiKey,
 		CallbackURL: m.CallbackURL,
+		APIVersion:  m.APIVersion,
 		Status:      domain.MerchantStatus(m.Status),
 		Balance:     m.Balance,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
+
+		TestAPIKeyHash: testApiKeyHash,
+
+		PreviousAPIKeyHash:          previousApiKeyHash,
+		PreviousAPIKeyExpiresAt:     m.PreviousApiKeyExpiresAt,
+		PreviousTestAPIKeyHash:      previousTestApiKeyHash,
+		PreviousTestAPIKeyExpiresAt: m.PreviousTestApiKeyExpiresAt,
+
+		WebhookSecret: m.WebhookSecret,
 	}
 }
 
@@ -75,18 +133,27 @@ func (r *merchantRepository) Create(ctx context.Context, m *domain.Merchant) (*d
 }
 
 // Update modifies an existing merchant in the database
+// Update writes the profile fields only, so it never puts back keys or a webhook secret that were
+// rotated since m was loaded
 func (r *merchantRepository) Update(ctx context.Context, m *domain.Merchant) error {
-	model := toMerchantModel(m)
-	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&MerchantModel{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]interface{}{
+			"name":         m.Name,
+			"callback_url": m.CallbackURL,
+			"api_version":  m.APIVersion,
+			"updated_at":   m.UpdatedAt,
+		}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-// FindByApiKey retrieves a merchant by its API key
+// FindByApiKey retrieves a merchant by its live or test API key, replaced keys included whether
+// or not their grace period is over
 func (r *merchantRepository) FindByApiKey(ctx context.Context, apiKey string) (*domain.Merchant, error) {
 	var model MerchantModel
-	if err := r.db.WithContext(ctx).First(&model, "api_key = ?", apiKey).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&model, "api_key = ? OR test_api_key = ? OR previous_api_key = ? OR previous_test_api_key = ?", apiKey, apiKey, apiKey, apiKey).Error; err != nil {
 		return nil, err
 	}
 	return model.toDomain(), nil
@@ -101,9 +168,33 @@ func (r *merchantRepository) FindByID(ctx context.Context, id uuid.UUID) (*domai
 	return model.toDomain(), nil
 }
 
-// RegenerateApiKey updates the API key for a merchant
-func (r *merchantRepository) RegenerateApiKey(ctx context.Context, id uuid.UUID, newApiKey string) error {
-	if err := r.db.WithContext(ctx).Model(&MerchantModel{}).Where("id = ?", id).Update("api_key", newApiKey).Error; err != nil {
+// RegenerateApiKey replaces the live or the test API key of a merchant. The replaced key moves to
+// the previous column in the same statement, dropping any key that was still in its grace period.
+func (r *merchantRepository) RegenerateApiKey(ctx context.Context, id uuid.UUID, livemode bool, newApiKey string, previousExpiresAt *time.Time) error {
+	column := "test_api_key"
+	if livemode {
+		column = "api_key"
+	}
+
+	updateData := map[string]interface{}{
+		column:                               newApiKey,
+		"previous_" + column:                 nil,
+		"previous_" + column + "_expires_at": nil,
+	}
+	if previousExpiresAt != nil {
+		updateData["previous_"+column] = gorm.Expr(column)
+		updateData["previous_"+column+"_expires_at"] = *previousExpiresAt
+	}
+
+	if err := r.db.WithContext(ctx).Model(&MerchantModel{}).Where("id = ?", id).Updates(updateData).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RotateWebhookSecret replaces the secret used to sign the merchant's callbacks
+func (r *merchantRepository) RotateWebhookSecret(ctx context.Context, id uuid.UUID, newSecret string) error {
+	if err := r.db.WithContext(ctx).Model(&MerchantModel{}).Where("id = ?", id).Update("webhook_secret", newSecret).Error; err != nil {
 		return err
 	}
 	return nil
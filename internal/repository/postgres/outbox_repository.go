@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxEventModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key"`
+	AggregateID uuid.UUID `gorm:"type:uuid;not null"`
+	EventType   string    `gorm:"size:100;not null"`
+	Payload     []byte    `gorm:"type:jsonb;not null"`
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
+
+func toOutboxEventModel(e *domain.OutboxEvent) *OutboxEventModel {
+	return &OutboxEventModel{
+		ID:          e.ID,
+		AggregateID: e.AggregateID,
+		EventType:   e.EventType,
+		Payload:     e.Payload,
+		CreatedAt:   e.CreatedAt,
+		PublishedAt: e.PublishedAt,
+	}
+}
+
+func (m *OutboxEventModel) toDomain() *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		ID:          m.ID,
+		AggregateID: m.AggregateID,
+		EventType:   m.EventType,
+		Payload:     m.Payload,
+		CreatedAt:   m.CreatedAt,
+		PublishedAt: m.PublishedAt,
+	}
+}
+
+// createOutboxEvent inserts event as part of the caller's database transaction, a nil event is skipped
+func createOutboxEvent(db *gorm.DB, event *domain.OutboxEvent) error {
+	if event == nil {
+		return nil
+	}
+	return db.Create(toOutboxEventModel(event)).Error
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) domain.OutboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+// PublishPending holds the row locks until the batch is marked, so relays running on several
+// workers skip each other's events instead of publishing them twice
+func (r *outboxRepository) PublishPending(ctx context.Context, limit int, publish domain.OutboxPublishFunc) (int, error) {
+	published := 0
+	var publishErr error
+
+	err := r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		var models []OutboxEventModel
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("created_at ASC, id ASC").
+			Limit(limit).
+			Find(&models).Error; err != nil {
+			return err
+		}
+
+		for _, model := range models {
+			// keep what was already published committed, the failed event is retried next batch
+			if err := publish(ctx, model.toDomain()); err != nil {
+				publishErr = err
+				return nil
+			}
+
+			if err := db.Model(&OutboxEventModel{}).Where("id = ?", model.ID).Update("published_at", time.Now()).Error; err != nil {
+				return err
+			}
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, publishErr
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	TransactionID uuid.UUID `gorm:"type:uuid;not null"`
+	MerchantID    uuid.UUID `gorm:"type:uuid;not null"`
+	Amount        int64     `gorm:"not null"`
+	Currency      string    `gorm:"size:10;not null"`
+	Reason        string    `gorm:"size:255"`
+	Status        string    `gorm:"size:50;not null;default:'PENDING'"`
+	ExternalRef   string    `gorm:"size:255"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (RefundModel) TableName() string {
+	return "refunds"
+}
+
+func toRefundModel(r *domain.Refund) *RefundModel {
+	return &RefundModel{
+		ID:            r.ID,
+		TransactionID: r.TransactionID,
+		MerchantID:    r.MerchantID,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		Reason:        r.Reason,
+		Status:        string(r.Status),
+		ExternalRef:   r.ExternalID,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+func (m *RefundModel) toDomain() *domain.Refund {
+	return &domain.Refund{
+		ID:            m.ID,
+		TransactionID: m.TransactionID,
+		MerchantID:    m.MerchantID,
+		Amount:        m.Amount,
+		Currency:      m.Currency,
+		Reason:        m.Reason,
+		Status:        domain.RefundStatus(m.Status),
+		ExternalID:    m.ExternalRef,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) domain.RefundRepository {
+	return &refundRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new refund into the database
+func (r *refundRepository) Create(ctx context.Context, refund *domain.Refund) (*domain.Refund, error) {
+	model := toRefundModel(refund)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// UpdatePending persists the provider outcome of a refund, only while it is still PENDING so a
+// late callback cannot overwrite a settled refund
+func (r *refundRepository) UpdatePending(ctx context.Context, refund *domain.Refund, event *domain.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		updateData := map[string]interface{}{
+			"status":       string(refund.Status),
+			"external_ref": refund.ExternalID,
+			"updated_at":   refund.UpdatedAt,
+		}
+
+		result := db.Model(&RefundModel{}).
+			Where("id = ? AND status = ?", refund.ID, string(domain.RefundStatusPending)).
+			Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrStatusConflict
+		}
+
+		return createOutboxEvent(db, event)
+	})
+}
+
+func (r *refundRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Refund, error) {
+	var model RefundModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRefundNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// FindByExternalID finds a refund by the provider's refund ID
+func (r *refundRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Refund, error) {
+	var model RefundModel
+	if err := r.db.WithContext(ctx).Where("external_ref = ?", externalID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRefundNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// FindByTransactionID lists every refund of a transaction, oldest first
+func (r *refundRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*domain.Refund, error) {
+	var models []RefundModel
+	if err := r.db.WithContext(ctx).Where("transaction_id = ?", transactionID).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	refunds := make([]*domain.Refund, 0, len(models))
+	for i := range models {
+		refunds = append(refunds, models[i].toDomain())
+	}
+	return refunds, nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg"
 	"time"
@@ -11,20 +12,26 @@ import (
 )
 
 type TransactionModel struct {
-	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
-	MerchantID    uuid.UUID `gorm:"type:uuid;not null"`
-	OrderID       string    `gorm:"size:255;not null;unique"`
-	Provider      string    `gorm:"size:255"`
-	PaymentMethod string    `gorm:"size:255"`
-	Amount        int64     `gorm:"default:0;not null"`
-	Currency      string    `gorm:"size:10;not null;default:'IDR'"`
-	Status        string    `gorm:"size:50;not null;default:'PENDING'"`
-	ExternalRef   string    `gorm:"size:255;not null"`
-	RedirectURL   string    `gorm:"size:255"`
-	RawResponse   []byte    `gorm:"type:jsonb"`
-	ExpiredAt     time.Time
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID              uuid.UUID `gorm:"type:uuid;primary_key"`
+	MerchantID      uuid.UUID `gorm:"type:uuid;not null"`
+	OrderID         string    `gorm:"size:255;not null"`
+	ProviderOrderID string    `gorm:"size:255;not null;unique"`
+	Provider        string    `gorm:"size:255"`
+	PaymentMethod   string    `gorm:"size:255"`
+	Amount          int64     `gorm:"default:0;not null"`
+	RefundedAmount  int64     `gorm:"default:0;not null"`
+	Currency        string    `gorm:"size:10;not null;default:'IDR'"`
+	Status          string    `gorm:"size:50;not null;default:'PENDING'"`
+	ExternalRef     string    `gorm:"size:255;not null"`
+	RedirectURL     string    `gorm:"size:255"`
+	Livemode        bool      `gorm:"not null"`
+	RawResponse     []byte    `gorm:"type:jsonb"`
+	ExpiredAt       time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	ReconcileAttempts int `gorm:"default:0;not null"`
+	NextReconcileAt   *time.Time
 }
 
 func (TransactionModel) TableName() string {
@@ -33,39 +40,47 @@ func (TransactionModel) TableName() string {
 
 func toTransactionModel(tx *domain.Transaction) *TransactionModel {
 	return &TransactionModel{
-		ID:            tx.ID,
-		MerchantID:    tx.MerchantID,
-		OrderID:       tx.OrderID,
-		Provider:      tx.Provider,
-		Amount:        tx.Amount,
-		Currency:      tx.Currency,
-		Status:        string(tx.Status),
-		ExternalRef:   tx.ExternalID,
-		PaymentMethod: tx.PaymentMethod,
-		RedirectURL:   tx.PaymentURL,
-		RawResponse:   pkg.JsonToByte(tx.RawResponse),
-		ExpiredAt:     tx.ExpiredAt,
-		CreatedAt:     tx.CreatedAt,
-		UpdatedAt:     tx.UpdatedAt,
+		ID:              tx.ID,
+		MerchantID:      tx.MerchantID,
+		OrderID:         tx.OrderID,
+		ProviderOrderID: tx.ProviderOrderID,
+		Provider:        tx.Provider,
+		Amount:          tx.Amount,
+		RefundedAmount:  tx.RefundedAmount,
+		Currency:        tx.Currency,
+		Status:          string(tx.Status),
+		ExternalRef:     tx.ExternalID,
+		PaymentMethod:   tx.PaymentMethod,
+		RedirectURL:     tx.PaymentURL,
+		Livemode:        tx.Livemode,
+		RawResponse:     pkg.JsonToByte(tx.RawResponse),
+		ExpiredAt:       tx.ExpiredAt,
+		CreatedAt:       tx.CreatedAt,
+		UpdatedAt:       tx.UpdatedAt,
 	}
 }
 
 func (t *TransactionModel) toDomain() *domain.Transaction {
 	return &domain.Transaction{
-		ID:            t.ID,
-		MerchantID:    t.MerchantID,
-		OrderID:       t.OrderID,
-		Provider:      t.Provider,
-		Amount:        t.Amount,
-		Currency:      t.Currency,
-		Status:        domain.TransactionStatus(t.Status),
-		ExternalID:    t.ExternalRef,
-		PaymentMethod: t.PaymentMethod,
-		PaymentURL:    t.RedirectURL,
-		RawResponse:   string(t.RawResponse),
-		ExpiredAt:     t.ExpiredAt,
-		CreatedAt:     t.CreatedAt,
-		UpdatedAt:     t.UpdatedAt,
+		ID:              t.ID,
+		MerchantID:      t.MerchantID,
+		OrderID:         t.OrderID,
+		ProviderOrderID: t.ProviderOrderID,
+		Provider:        t.Provider,
+		Amount:          t.Amount,
+		RefundedAmount:  t.RefundedAmount,
+		Currency:        t.Currency,
+		Status:          domain.TransactionStatus(t.Status),
+		ExternalID:      t.ExternalRef,
+		PaymentMethod:   t.PaymentMethod,
+		PaymentURL:      t.RedirectURL,
+		Livemode:        t.Livemode,
+		RawResponse:     string(t.RawResponse),
+		ExpiredAt:       t.ExpiredAt,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+
+		ReconcileAttempts: t.ReconcileAttempts,
 	}
 }
 
@@ -83,6 +98,10 @@ func (t *transactionRepository) Create(ctx context.Context, tx *domain.Transacti
 	model := toTransactionModel(tx)
 	model.RawResponse = nil
 	if err := t.db.WithContext(ctx).Create(model).Error; err != nil {
+		// the only unique key a caller controls is (merchant_id, livemode, order_id)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrDuplicateOrderID
+		}
 		return nil, err
 	}
 	return model.toDomain(), nil
@@ -104,18 +123,238 @@ func (t *transactionRepository) Update(ctx context.Context, tx *domain.Transacti
 	return model.toDomain(), nil
 }
 
-func (t *transactionRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+// DeleteUnstarted only removes a transaction that is still PENDING without a provider reference,
+// so its order ID can be used again
+func (t *transactionRepository) DeleteUnstarted(ctx context.Context, id uuid.UUID) error {
+	return t.db.WithContext(ctx).
+		Where("id = ? AND status = ? AND external_ref = ''", id, string(domain.TransactionStatusPending)).
+		Delete(&TransactionModel{}).Error
+}
+
+// UpdateStatus moves a transaction from one status to another only if it is still in the expected status,
+// recording event in the outbox within the same database transaction
+func (t *transactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, event *domain.OutboxEvent) error {
+	return t.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		result := db.Model(&TransactionModel{}).
+			Where("id = ? AND status = ?", id, string(from)).
+			Updates(map[string]interface{}{
+				"status":     string(to),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrStatusConflict
+		}
+
+		return createOutboxEvent(db, event)
+	})
+}
+
+// AddRefundedAmount records a refund against the transaction, guarding both the expected status
+// and the remaining balance so concurrent refunds can never exceed the original amount, and
+// records event in the outbox within the same database transaction
+func (t *transactionRepository) AddRefundedAmount(ctx context.Context, id uuid.UUID, from domain.TransactionStatus, to domain.TransactionStatus, amount int64, event *domain.OutboxEvent) error {
+	return t.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		result := db.Model(&TransactionModel{}).
+			Where("id = ? AND status = ? AND refunded_amount + ? <= amount", id, string(from), amount).
+			Updates(map[string]interface{}{
+				"refunded_amount": gorm.Expr("refunded_amount + ?", amount),
+				"status":          string(to),
+				"updated_at":      time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrStatusConflict
+		}
+
+		return createOutboxEvent(db, event)
+	})
+}
+
+// ReleaseRefundedAmount undoes AddRefundedAmount for a refund the provider rejected. This is a
+// compensation rather than a status change, so it may move a REFUNDED transaction back to
+// PARTIALLY_REFUNDED or PAID, whichever matches the balance left refunded.
+func (t *transactionRepository) ReleaseRefundedAmount(ctx context.Context, id uuid.UUID, amount int64, event *domain.OutboxEvent) error {
+	return t.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		result := db.Model(&TransactionModel{}).
+			Where("id = ? AND status IN ? AND refunded_amount >= ?", id,
+				[]string{string(domain.TransactionStatusPartiallyRefunded), string(domain.TransactionStatusRefunded)}, amount).
+			Updates(map[string]interface{}{
+				"refunded_amount": gorm.Expr("refunded_amount - ?", amount),
+				"status": gorm.Expr("CASE WHEN refunded_amount - ? = 0 THEN ? ELSE ? END", amount,
+					string(domain.TransactionStatusPaid), string(domain.TransactionStatusPartiallyRefunded)),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrStatusConflict
+		}
+
+		return createOutboxEvent(db, event)
+	})
+}
+
+// GetByMerchant only finds transactions owned by the merchant, so foreign IDs look like missing ones
+func (t *transactionRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.Transaction, error) {
 	var model TransactionModel
-	if err := t.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+	if err := t.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTransactionNotFound
+		}
 		return nil, err
 	}
 	return model.toDomain(), nil
 }
 
-func (t *transactionRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Transaction, error) {
+func (t *transactionRepository) FindByProviderOrderID(ctx context.Context, providerOrderID string) (*domain.Transaction, error) {
 	var model TransactionModel
-	if err := t.db.WithContext(ctx).Where("order_id = ?", orderID).First(&model).Error; err != nil {
+	if err := t.db.WithContext(ctx).Where("provider_order_id = ?", providerOrderID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTransactionNotFound
+		}
 		return nil, err
 	}
 	return model.toDomain(), nil
 }
+
+func (t *transactionRepository) FindByMerchantAndOrderID(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*domain.Transaction, error) {
+	var model TransactionModel
+	if err := t.db.WithContext(ctx).Where("merchant_id = ? AND livemode = ? AND order_id = ?", merchantID, livemode, orderID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+func (t *transactionRepository) FindByMerchantAndExternalID(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*domain.Transaction, error) {
+	var model TransactionModel
+	if err := t.db.WithContext(ctx).Where("merchant_id = ? AND livemode = ? AND external_ref = ?", merchantID, livemode, externalID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// FindExpiredPending returns PENDING transactions whose expiry passed before the given time, oldest first
+func (t *transactionRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*domain.Transaction, error) {
+	var models []TransactionModel
+	if err := t.db.WithContext(ctx).
+		Where("status = ? AND expired_at < ?", string(domain.TransactionStatusPending), before).
+		Order("expired_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(models))
+	for i := range models {
+		transactions = append(transactions, models[i].toDomain())
+	}
+	return transactions, nil
+}
+
+// FindDueForReconciliation returns transactions that have been PENDING since before pendingSince, or
+// that expired after expiredSince, and whose next reconciliation time has come, never-polled
+// transactions first. Transactions without a provider reference have nothing to poll.
+func (t *transactionRepository) FindDueForReconciliation(ctx context.Context, pendingSince time.Time, expiredSince time.Time, now time.Time, limit int) ([]*domain.Transaction, error) {
+	var models []TransactionModel
+	if err := t.db.WithContext(ctx).
+		Where("(status = ? AND created_at < ?) OR (status = ? AND expired_at >= ?)",
+			string(domain.TransactionStatusPending), pendingSince, string(domain.TransactionStatusExpired), expiredSince).
+		Where("external_ref <> ''").
+		Where("next_reconcile_at IS NULL OR next_reconcile_at <= ?", now).
+		Order("next_reconcile_at ASC NULLS FIRST").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(models))
+	for i := range models {
+		transactions = append(transactions, models[i].toDomain())
+	}
+	return transactions, nil
+}
+
+// ScheduleReconciliation records how often a transaction has been polled and when to poll it again
+func (t *transactionRepository) ScheduleReconciliation(ctx context.Context, id uuid.UUID, attempts int, nextAt time.Time) error {
+	return t.db.WithContext(ctx).Model(&TransactionModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"reconcile_attempts": attempts,
+			"next_reconcile_at":  nextAt,
+		}).Error
+}
+
+// List returns the merchant's transactions matching the filter, reading up to filter.Limit rows past the cursor
+func (t *transactionRepository) List(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	query := t.db.WithContext(ctx).Model(&TransactionModel{}).Where("merchant_id = ? AND livemode = ?", filter.MerchantID, filter.Livemode)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.Provider != "" {
+		query = query.Where("provider = ?", filter.Provider)
+	}
+	if filter.PaymentMethod != "" {
+		query = query.Where("payment_method = ?", filter.PaymentMethod)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.MinAmount > 0 {
+		query = query.Where("amount >= ?", filter.MinAmount)
+	}
+	if filter.MaxAmount > 0 {
+		query = query.Where("amount <= ?", filter.MaxAmount)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo)
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	switch filter.SortBy {
+	case domain.TransactionSortAmount:
+		if filter.After != nil {
+			query = query.Where("(amount, id) "+comparison+" (?, ?)", filter.After.Amount, filter.After.ID)
+		}
+		query = query.Order("amount " + direction).Order("id " + direction)
+	default:
+		if filter.After != nil {
+			query = query.Where("id "+comparison+" ?", filter.After.ID)
+		}
+		query = query.Order("id " + direction)
+	}
+
+	var models []TransactionModel
+	if err := query.Limit(filter.Limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(models))
+	for i := range models {
+		transactions = append(transactions, models[i].toDomain())
+	}
+	return transactions, nil
+}
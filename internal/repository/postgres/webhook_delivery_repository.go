@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key"`
+	MerchantID     uuid.UUID  `gorm:"type:uuid;not null"`
+	EndpointID     *uuid.UUID `gorm:"type:uuid"`
+	TransactionID  uuid.UUID  `gorm:"type:uuid;not null"`
+	Event          string     `gorm:"size:100"`
+	EventStatus    string     `gorm:"size:50;not null"`
+	URL            string     `gorm:"type:text;not null"`
+	Attempt        int        `gorm:"not null"`
+	RequestBody    string     `gorm:"type:text"`
+	ResponseStatus int
+	ResponseBody   string `gorm:"type:text"`
+	LatencyMs      int64  `gorm:"not null"`
+	Error          string `gorm:"type:text"`
+	Succeeded      bool   `gorm:"not null"`
+	Payload        []byte `gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time
+}
+
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+func toWebhookDeliveryModel(d *domain.WebhookDelivery) (*WebhookDeliveryModel, error) {
+	payload, err := json.Marshal(d.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpointID *uuid.UUID
+	if d.EndpointID != uuid.Nil {
+		endpointID = &d.EndpointID
+	}
+
+	return &WebhookDeliveryModel{
+		ID:             d.ID,
+		MerchantID:     d.MerchantID,
+		EndpointID:     endpointID,
+		TransactionID:  d.TransactionID,
+		Event:          d.Event,
+		EventStatus:    d.EventStatus,
+		URL:            d.URL,
+		Attempt:        d.Attempt,
+		RequestBody:    d.RequestBody,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		LatencyMs:      d.Latency.Milliseconds(),
+		Error:          d.Error,
+		Succeeded:      d.Succeeded,
+		Payload:        payload,
+		CreatedAt:      d.CreatedAt,
+	}, nil
+}
+
+func (m *WebhookDeliveryModel) toDomain() *domain.WebhookDelivery {
+	var payload domain.WebhookPayload
+	_ = json.Unmarshal(m.Payload, &payload)
+
+	var endpointID uuid.UUID
+	if m.EndpointID != nil {
+		endpointID = *m.EndpointID
+	}
+
+	return &domain.WebhookDelivery{
+		ID:             m.ID,
+		MerchantID:     m.MerchantID,
+		EndpointID:     endpointID,
+		TransactionID:  m.TransactionID,
+		Event:          m.Event,
+		EventStatus:    m.EventStatus,
+		URL:            m.URL,
+		Attempt:        m.Attempt,
+		RequestBody:    m.RequestBody,
+		ResponseStatus: m.ResponseStatus,
+		ResponseBody:   m.ResponseBody,
+		Latency:        time.Duration(m.LatencyMs) * time.Millisecond,
+		Error:          m.Error,
+		Succeeded:      m.Succeeded,
+		Payload:        &payload,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) domain.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+// Create records a delivery attempt
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	model, err := toWebhookDeliveryModel(delivery)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+func (r *webhookDeliveryRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var model WebhookDeliveryModel
+	if err := r.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// List returns a merchant's delivery attempts, newest first
+func (r *webhookDeliveryRepository) List(ctx context.Context, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).Model(&WebhookDeliveryModel{}).Where("merchant_id = ?", filter.MerchantID)
+
+	if filter.TransactionID != uuid.Nil {
+		query = query.Where("transaction_id = ?", filter.TransactionID)
+	}
+	if filter.EndpointID != uuid.Nil {
+		query = query.Where("endpoint_id = ?", filter.EndpointID)
+	}
+	if filter.Succeeded != nil {
+		query = query.Where("succeeded = ?", *filter.Succeeded)
+	}
+	if filter.After != nil {
+		query = query.Where("id < ?", filter.After.ID)
+	}
+
+	var models []WebhookDeliveryModel
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(models))
+	for i := range models {
+		deliveries = append(deliveries, models[i].toDomain())
+	}
+	return deliveries, nil
+}
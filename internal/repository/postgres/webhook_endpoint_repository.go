@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookEndpointModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key"`
+	MerchantID  uuid.UUID `gorm:"type:uuid;not null"`
+	URL         string    `gorm:"type:text;not null"`
+	Description string    `gorm:"size:255"`
+	Events      []byte    `gorm:"type:jsonb;not null"`
+	Enabled     bool      `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (WebhookEndpointModel) TableName() string {
+	return "webhook_endpoints"
+}
+
+func toWebhookEndpointModel(e *domain.WebhookEndpoint) (*WebhookEndpointModel, error) {
+	events, err := json.Marshal(e.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookEndpointModel{
+		ID:          e.ID,
+		MerchantID:  e.MerchantID,
+		URL:         e.URL,
+		Description: e.Description,
+		Events:      events,
+		Enabled:     e.Enabled,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}, nil
+}
+
+func (m *WebhookEndpointModel) toDomain() *domain.WebhookEndpoint {
+	var events []string
+	_ = json.Unmarshal(m.Events, &events)
+
+	return &domain.WebhookEndpoint{
+		ID:          m.ID,
+		MerchantID:  m.MerchantID,
+		URL:         m.URL,
+		Description: m.Description,
+		Events:      events,
+		Enabled:     m.Enabled,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+type webhookEndpointRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookEndpointRepository(db *gorm.DB) domain.WebhookEndpointRepository {
+	return &webhookEndpointRepository{
+		db: db,
+	}
+}
+
+func (r *webhookEndpointRepository) Create(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	model, err := toWebhookEndpointModel(endpoint)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+func (r *webhookEndpointRepository) Update(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	model, err := toWebhookEndpointModel(endpoint)
+	if err != nil {
+		return err
+	}
+
+	updateData := map[string]interface{}{
+		"url":         model.URL,
+		"description": model.Description,
+		"events":      model.Events,
+		"enabled":     model.Enabled,
+		"updated_at":  model.UpdatedAt,
+	}
+
+	result := r.db.WithContext(ctx).Model(&WebhookEndpointModel{}).
+		Where("id = ? AND merchant_id = ?", endpoint.ID, endpoint.MerchantID).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookEndpointNotFound
+	}
+	return nil
+}
+
+func (r *webhookEndpointRepository) Delete(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).Delete(&WebhookEndpointModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookEndpointNotFound
+	}
+	return nil
+}
+
+func (r *webhookEndpointRepository) GetByMerchant(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	var model WebhookEndpointModel
+	if err := r.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrWebhookEndpointNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain(), nil
+}
+
+// ListByMerchant returns every endpoint of a merchant, oldest first
+func (r *webhookEndpointRepository) ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]*domain.WebhookEndpoint, error) {
+	var models []WebhookEndpointModel
+	if err := r.db.WithContext(ctx).Where("merchant_id = ?", merchantID).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*domain.WebhookEndpoint, 0, len(models))
+	for i := range models {
+		endpoints = append(endpoints, models[i].toDomain())
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type idempotencyStore struct {
+	rdb *redis.Client
+}
+
+func NewIdempotencyStore(rdb *redis.Client) domain.IdempotencyStore {
+	return &idempotencyStore{
+		rdb: rdb,
+	}
+}
+
+func (s *idempotencyStore) Reserve(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+
+	return s.rdb.SetNX(ctx, key, body, ttl).Result()
+}
+
+// Get returns nil when nothing is stored under key
+func (s *idempotencyStore) Get(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	body, err := s.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record domain.IdempotencyRecord
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *idempotencyStore) Save(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, key, body, ttl).Err()
+}
+
+func (s *idempotencyStore) Delete(ctx context.Context, key string) error {
+	return s.rdb.Del(ctx, key).Err()
+}
@@ -0,0 +1,141 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// legacyWebhookQueue is the list merchant callbacks were queued on before WebhookStream
+const legacyWebhookQueue = "webhook_queue"
+
+// moveLegacyScript moves up to ARGV[2] jobs from the head of the legacy list, the oldest first,
+// onto the stream. Each job is popped and added in one step, so none is lost or doubled.
+var moveLegacyScript = redis.NewScript(`
+local moved = 0
+for i = 1, tonumber(ARGV[2]) do
+	local body = redis.call('LPOP', KEYS[1])
+	if not body then
+		break
+	end
+	redis.call('XADD', KEYS[2], '*', ARGV[1], body)
+	moved = moved + 1
+end
+return moved
+`)
+
+const moveLegacyBatchSize = 100
+
+type webhookConsumer struct {
+	rdb      *redis.Client
+	consumer string
+	// claimStart is where the next XAUTOCLAIM scan resumes, it is only touched by the consuming loop
+	claimStart string
+}
+
+// NewWebhookConsumer reads WebhookStream as consumer, which must be unique per worker replica
+func NewWebhookConsumer(rdb *redis.Client, consumer string) domain.WebhookConsumer {
+	return &webhookConsumer{
+		rdb:        rdb,
+		consumer:   consumer,
+		claimStart: "0-0",
+	}
+}
+
+func (c *webhookConsumer) CreateGroup(ctx context.Context) error {
+	err := c.rdb.XGroupCreateMkStream(ctx, WebhookStream, WebhookConsumerGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+func (c *webhookConsumer) Read(ctx context.Context, block time.Duration) (*domain.WebhookMessage, error) {
+	streams, err := c.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    WebhookConsumerGroup,
+		Consumer: c.consumer,
+		Streams:  []string{WebhookStream, ">"},
+		Count:    1,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			return c.toMessage(ctx, message)
+		}
+	}
+	return nil, nil
+}
+
+func (c *webhookConsumer) ClaimStale(ctx context.Context, minIdle time.Duration, count int) ([]*domain.WebhookMessage, error) {
+	claimed, next, err := c.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   WebhookStream,
+		Group:    WebhookConsumerGroup,
+		Consumer: c.consumer,
+		MinIdle:  minIdle,
+		Start:    c.claimStart,
+		Count:    int64(count),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	c.claimStart = next
+
+	messages := make([]*domain.WebhookMessage, 0, len(claimed))
+	for _, entry := range claimed {
+		message, err := c.toMessage(ctx, entry)
+		if err != nil {
+			return messages, err
+		}
+		if message != nil {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// Ack also deletes the entry, so the stream only ever holds jobs that are still outstanding
+func (c *webhookConsumer) Ack(ctx context.Context, id string) error {
+	_, err := c.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, WebhookStream, WebhookConsumerGroup, id)
+		pipe.XDel(ctx, WebhookStream, id)
+		return nil
+	})
+	return err
+}
+
+// MoveLegacyQueue moves the list in batches so Redis is not blocked by a long one
+func (c *webhookConsumer) MoveLegacyQueue(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		moved, err := moveLegacyScript.Run(ctx, c.rdb, []string{legacyWebhookQueue, WebhookStream}, webhookPayloadField, moveLegacyBatchSize).Int()
+		total += moved
+		if err != nil || moved < moveLegacyBatchSize {
+			return total, err
+		}
+	}
+}
+
+// toMessage returns nil for an entry without a payload, which is acknowledged straight away
+// because there is nothing to deliver
+func (c *webhookConsumer) toMessage(ctx context.Context, entry redis.XMessage) (*domain.WebhookMessage, error) {
+	body, ok := entry.Values[webhookPayloadField].(string)
+	if !ok {
+		return nil, c.Ack(ctx, entry.ID)
+	}
+
+	return &domain.WebhookMessage{
+		ID:   entry.ID,
+		Body: body,
+	}, nil
+}
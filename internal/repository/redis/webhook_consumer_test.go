@@ -0,0 +1,161 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	queue "go-payment-aggregator/internal/repository/redis"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookConsumer_CreateGroup(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	consumer := queue.NewWebhookConsumer(rdb, "worker-1")
+
+	require.NoError(t, consumer.CreateGroup(ctx))
+	// every replica creates the group on startup, finding it already there is fine
+	require.NoError(t, consumer.CreateGroup(ctx))
+
+	groups, err := rdb.XInfoGroups(ctx, queue.WebhookStream).Result()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, queue.WebhookConsumerGroup, groups[0].Name)
+}
+
+func TestWebhookConsumer_ReadAndAck(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	consumer := queue.NewWebhookConsumer(rdb, "worker-1")
+	require.NoError(t, consumer.CreateGroup(ctx))
+
+	require.NoError(t, queue.NewWebhookPublisher(rdb).Publish(ctx, &domain.WebhookPayload{OrderID: "ORDER-TEST-123"}))
+
+	message, err := consumer.Read(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, message)
+
+	var payload domain.WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(message.Body), &payload))
+	assert.Equal(t, "ORDER-TEST-123", payload.OrderID)
+
+	// a read job is pending until it is acknowledged, and not handed out again
+	pending, err := rdb.XPending(ctx, queue.WebhookStream, queue.WebhookConsumerGroup).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pending.Count)
+
+	next, err := consumer.Read(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+
+	require.NoError(t, consumer.Ack(ctx, message.ID))
+
+	pending, err = rdb.XPending(ctx, queue.WebhookStream, queue.WebhookConsumerGroup).Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+
+	length, err := rdb.XLen(ctx, queue.WebhookStream).Result()
+	require.NoError(t, err)
+	assert.Zero(t, length, "acknowledged jobs are deleted from the stream")
+}
+
+func TestWebhookConsumer_ReadSkipsEntryWithoutPayload(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	consumer := queue.NewWebhookConsumer(rdb, "worker-1")
+	require.NoError(t, consumer.CreateGroup(ctx))
+
+	require.NoError(t, rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: queue.WebhookStream,
+		Values: []interface{}{"other", "value"},
+	}).Err())
+
+	message, err := consumer.Read(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Nil(t, message)
+
+	length, err := rdb.XLen(ctx, queue.WebhookStream).Result()
+	require.NoError(t, err)
+	assert.Zero(t, length)
+}
+
+func TestWebhookConsumer_ClaimStale(t *testing.T) {
+	ctx := context.Background()
+	server, rdb := newTestRedis(t)
+
+	now := time.Now()
+	server.SetTime(now)
+
+	crashed := queue.NewWebhookConsumer(rdb, "worker-1")
+	require.NoError(t, crashed.CreateGroup(ctx))
+	require.NoError(t, queue.NewWebhookPublisher(rdb).Publish(ctx, &domain.WebhookPayload{OrderID: "ORDER-TEST-123"}))
+
+	taken, err := crashed.Read(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, taken)
+
+	survivor := queue.NewWebhookConsumer(rdb, "worker-2")
+
+	// a job still within its idle time may be in flight, it is left alone
+	claimed, err := survivor.ClaimStale(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	server.SetTime(now.Add(2 * time.Minute))
+
+	claimed, err = survivor.ClaimStale(ctx, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, taken.ID, claimed[0].ID)
+	assert.Equal(t, taken.Body, claimed[0].Body)
+
+	pending, err := rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: queue.WebhookStream,
+		Group:  queue.WebhookConsumerGroup,
+		Start:  "-",
+		End:    "+",
+		Count:  10,
+	}).Result()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "worker-2", pending[0].Consumer)
+}
+
+func TestWebhookConsumer_MoveLegacyQueue(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	consumer := queue.NewWebhookConsumer(rdb, "worker-1")
+	require.NoError(t, consumer.CreateGroup(ctx))
+
+	// more than one batch, in the order the old worker would have popped them
+	const jobs = 150
+	for i := 0; i < jobs; i++ {
+		body, err := json.Marshal(&domain.WebhookPayload{OrderID: "ORDER-" + strconv.Itoa(i)})
+		require.NoError(t, err)
+		require.NoError(t, rdb.RPush(ctx, "webhook_queue", body).Err())
+	}
+
+	moved, err := consumer.MoveLegacyQueue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, jobs, moved)
+
+	length, err := rdb.LLen(ctx, "webhook_queue").Result()
+	require.NoError(t, err)
+	assert.Zero(t, length)
+
+	payloads := streamPayloads(t, rdb)
+	require.Len(t, payloads, jobs)
+	assert.Equal(t, "ORDER-0", payloads[0].OrderID)
+	assert.Equal(t, "ORDER-149", payloads[jobs-1].OrderID)
+
+	moved, err = consumer.MoveLegacyQueue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+}
@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"go-payment-aggregator/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// WebhookStream is the stream cmd/worker consumes merchant callbacks from
+	WebhookStream = "webhook_stream"
+	// WebhookConsumerGroup is the consumer group every worker replica reads WebhookStream as
+	WebhookConsumerGroup = "webhook_workers"
+	// webhookPayloadField is the stream entry field holding the JSON encoded domain.WebhookPayload
+	webhookPayloadField = "payload"
+)
+
+type webhookPublisher struct {
+	rdb *redis.Client
+}
+
+func NewWebhookPublisher(rdb *redis.Client) domain.WebhookPublisher {
+	return &webhookPublisher{
+		rdb: rdb,
+	}
+}
+
+func (w *webhookPublisher) Publish(ctx context.Context, payload *domain.WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return w.rdb.XAdd(ctx, webhookEntry(body)).Err()
+}
+
+func webhookEntry(body []byte) *redis.XAddArgs {
+	return &redis.XAddArgs{
+		Stream: WebhookStream,
+		Values: []interface{}{webhookPayloadField, body},
+	}
+}
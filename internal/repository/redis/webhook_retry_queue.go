@@ -0,0 +1,118 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"go-payment-aggregator/internal/domain"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// WebhookRetrySet is a sorted set of payloads scored by their next attempt time in unix milliseconds
+	WebhookRetrySet = "webhook_retry_set"
+	// WebhookDeadLetterQueue holds payloads that ran out of attempts, oldest first
+	WebhookDeadLetterQueue = "webhook_dead_letter_queue"
+)
+
+// promoteDueScript moves due retries to the webhook stream atomically, so two workers never promote the same one
+var promoteDueScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, member in ipairs(due) do
+	redis.call('ZREM', KEYS[1], member)
+	redis.call('XADD', KEYS[2], '*', ARGV[3], member)
+end
+return #due
+`)
+
+type webhookRetryQueue struct {
+	rdb *redis.Client
+}
+
+func NewWebhookRetryQueue(rdb *redis.Client) domain.WebhookRetryQueue {
+	return &webhookRetryQueue{
+		rdb: rdb,
+	}
+}
+
+func (q *webhookRetryQueue) Schedule(ctx context.Context, payload *domain.WebhookPayload, at time.Time) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return q.rdb.ZAdd(ctx, WebhookRetrySet, redis.Z{
+		Score:  float64(at.UnixMilli()),
+		Member: body,
+	}).Err()
+}
+
+func (q *webhookRetryQueue) PromoteDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	return promoteDueScript.Run(ctx, q.rdb,
+		[]string{WebhookRetrySet, WebhookStream},
+		strconv.FormatInt(now.UnixMilli(), 10), limit, webhookPayloadField,
+	).Int()
+}
+
+func (q *webhookRetryQueue) DeadLetter(ctx context.Context, payload *domain.WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return q.rdb.RPush(ctx, WebhookDeadLetterQueue, body).Err()
+}
+
+func (q *webhookRetryQueue) ListDeadLetters(ctx context.Context, offset int, limit int) ([]*domain.WebhookPayload, error) {
+	raws, err := q.rdb.LRange(ctx, WebhookDeadLetterQueue, int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	payloads := make([]*domain.WebhookPayload, 0, len(raws))
+	for _, raw := range raws {
+		var payload domain.WebhookPayload
+		if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, &payload)
+	}
+
+	return payloads, nil
+}
+
+// ReplayDeadLetters trims the replayed entries from the head while new dead letters are only
+// ever appended to the tail, so letters parked during a replay are kept
+func (q *webhookRetryQueue) ReplayDeadLetters(ctx context.Context, limit int) (int, error) {
+	payloads, err := q.ListDeadLetters(ctx, 0, limit)
+	if err != nil || len(payloads) == 0 {
+		return 0, err
+	}
+
+	bodies := make([][]byte, 0, len(payloads))
+	for _, payload := range payloads {
+		payload.RetryCount = 0
+		payload.LastError = ""
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return 0, err
+		}
+		bodies = append(bodies, body)
+	}
+
+	_, err = q.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LTrim(ctx, WebhookDeadLetterQueue, int64(len(bodies)), -1)
+		for _, body := range bodies {
+			pipe.XAdd(ctx, webhookEntry(body))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(bodies), nil
+}
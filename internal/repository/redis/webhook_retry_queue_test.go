@@ -0,0 +1,115 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	queue "go-payment-aggregator/internal/repository/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return server, rdb
+}
+
+// streamPayloads decodes every job on the webhook stream, oldest first
+func streamPayloads(t *testing.T, rdb *redis.Client) []*domain.WebhookPayload {
+	entries, err := rdb.XRange(context.Background(), queue.WebhookStream, "-", "+").Result()
+	require.NoError(t, err)
+
+	payloads := make([]*domain.WebhookPayload, 0, len(entries))
+	for _, entry := range entries {
+		var payload domain.WebhookPayload
+		require.NoError(t, json.Unmarshal([]byte(entry.Values["payload"].(string)), &payload))
+		payloads = append(payloads, &payload)
+	}
+	return payloads
+}
+
+func TestWebhookRetryQueue_PromoteDue(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	retryQueue := queue.NewWebhookRetryQueue(rdb)
+
+	now := time.Now()
+	require.NoError(t, retryQueue.Schedule(ctx, &domain.WebhookPayload{OrderID: "ORDER-DUE-1", RetryCount: 1}, now.Add(-time.Minute)))
+	require.NoError(t, retryQueue.Schedule(ctx, &domain.WebhookPayload{OrderID: "ORDER-DUE-2", RetryCount: 1}, now))
+	require.NoError(t, retryQueue.Schedule(ctx, &domain.WebhookPayload{OrderID: "ORDER-LATER", RetryCount: 1}, now.Add(time.Minute)))
+
+	promoted, err := retryQueue.PromoteDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, promoted)
+
+	payloads := streamPayloads(t, rdb)
+	require.Len(t, payloads, 2)
+	assert.Equal(t, "ORDER-DUE-1", payloads[0].OrderID)
+	assert.Equal(t, "ORDER-DUE-2", payloads[1].OrderID)
+
+	// the job that is not due yet stays scheduled
+	remaining, err := rdb.ZCard(ctx, queue.WebhookRetrySet).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), remaining)
+
+	promoted, err = retryQueue.PromoteDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, promoted)
+}
+
+func TestWebhookRetryQueue_PromoteDueLimit(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	retryQueue := queue.NewWebhookRetryQueue(rdb)
+
+	now := time.Now()
+	for _, orderID := range []string{"ORDER-1", "ORDER-2", "ORDER-3"} {
+		require.NoError(t, retryQueue.Schedule(ctx, &domain.WebhookPayload{OrderID: orderID}, now.Add(-time.Minute)))
+	}
+
+	promoted, err := retryQueue.PromoteDue(ctx, now, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, promoted)
+	assert.Len(t, streamPayloads(t, rdb), 2)
+}
+
+func TestWebhookRetryQueue_ReplayDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	_, rdb := newTestRedis(t)
+	retryQueue := queue.NewWebhookRetryQueue(rdb)
+
+	for _, orderID := range []string{"ORDER-1", "ORDER-2", "ORDER-3"} {
+		require.NoError(t, retryQueue.DeadLetter(ctx, &domain.WebhookPayload{
+			OrderID:    orderID,
+			RetryCount: 5,
+			LastError:  "callback returned 500",
+		}))
+	}
+
+	replayed, err := retryQueue.ReplayDeadLetters(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+
+	payloads := streamPayloads(t, rdb)
+	require.Len(t, payloads, 2)
+	for i, orderID := range []string{"ORDER-1", "ORDER-2"} {
+		assert.Equal(t, orderID, payloads[i].OrderID)
+		assert.Zero(t, payloads[i].RetryCount)
+		assert.Empty(t, payloads[i].LastError)
+	}
+
+	// the newest letter was not replayed and keeps its attempt count
+	remaining, err := retryQueue.ListDeadLetters(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "ORDER-3", remaining[0].OrderID)
+	assert.Equal(t, 5, remaining[0].RetryCount)
+}
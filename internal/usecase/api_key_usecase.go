@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyUC struct {
+	apiKeyRepo   domain.ApiKeyRepository
+	merchantRepo domain.MerchantRepository
+	timeout      time.Duration
+}
+
+func NewApiKeyUC(r domain.ApiKeyRepository, mr domain.MerchantRepository, t time.Duration) domain.ApiKeyUC {
+	return &ApiKeyUC{
+		apiKeyRepo:   r,
+		merchantRepo: mr,
+		timeout:      t,
+	}
+}
+
+func (u *ApiKeyUC) Create(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateApiKeyRequest) (*domain.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	now := time.Now()
+
+	if err := validateApiKeyRequest(req, now); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.apiKeyRepo.ListByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	usable := 0
+	for _, key := range existing {
+		if key.Usable(now) {
+			usable++
+		}
+	}
+	if usable >= domain.MaxApiKeysPerMerchant {
+		return nil, domain.ErrApiKeyLimitExceeded
+	}
+
+	prefix := domain.TestApiKeyPrefix
+	if livemode {
+		prefix = domain.LiveApiKeyPrefix
+	}
+	rawKey := pkg.GenerateApiKey(prefix)
+
+	key := &domain.ApiKey{
+		ID:         pkg.GenerateUUIDV7(),
+		MerchantID: merchantID,
+		Name:       strings.TrimSpace(req.Name),
+		KeyHash:    pkg.HashKey256(rawKey),
+		Prefix:     rawKey[:domain.ApiKeyDisplayLength],
+		Scopes:     req.Scopes,
+		Livemode:   livemode,
+		ExpiresAt:  req.ExpiresAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := u.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	key.Key = rawKey
+
+	return key, nil
+}
+
+func (u *ApiKeyUC) List(ctx context.Context, merchantID uuid.UUID, livemode bool) ([]*domain.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	keys, err := u.apiKeyRepo.ListByMerchant(ctx, merchantID)
+	if err != nil || livemode {
+		return keys, err
+	}
+
+	testKeys := make([]*domain.ApiKey, 0, len(keys))
+	for _, key := range keys {
+		if !key.Livemode {
+			testKeys = append(testKeys, key)
+		}
+	}
+	return testKeys, nil
+}
+
+// Revoke is idempotent, revoking a revoked key returns it unchanged. Live keys look missing to test callers.
+func (u *ApiKeyUC) Revoke(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	key, err := u.apiKeyRepo.GetByMerchant(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Livemode && !livemode {
+		return nil, domain.ErrApiKeyNotFound
+	}
+
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	if err := u.apiKeyRepo.Revoke(ctx, merchantID, id, now); err != nil {
+		return nil, err
+	}
+
+	key.RevokedAt = &now
+	key.UpdatedAt = now
+
+	return key, nil
+}
+
+func (u *ApiKeyUC) Authenticate(ctx context.Context, apiKey string) (*domain.Merchant, *domain.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	key, err := u.apiKeyRepo.FindByHash(ctx, pkg.HashKey256(apiKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if !key.Usable(now) {
+		return nil, nil, domain.ErrApiKeyNotFound
+	}
+
+	merchant, err := u.merchantRepo.FindByID(ctx, key.MerchantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if merchant.Status != domain.MerchantStatusActive {
+		return nil, nil, domain.ErrMerchantNotActive
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= domain.ApiKeyLastUsedInterval {
+		// a failed write only leaves last_used_at stale, it must not fail the request
+		if err := u.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return merchant, key, nil
+}
+
+func validateApiKeyRequest(req *domain.CreateApiKeyRequest, now time.Time) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > domain.MaxApiKeyNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters", domain.ErrInvalidApiKeyRequest, domain.MaxApiKeyNameLength)
+	}
+
+	if len(req.Scopes) == 0 {
+		return fmt.Errorf("%w: grant at least one scope", domain.ErrInvalidApiKeyRequest)
+	}
+	for _, scope := range req.Scopes {
+		if !domain.IsValidApiKeyScope(scope) {
+			return fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidApiKeyRequest, scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expires_at must be in the future", domain.ErrInvalidApiKeyRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApiKeyUsecase_Create(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	fullList := make([]*domain.ApiKey, domain.MaxApiKeysPerMerchant)
+	for i := range fullList {
+		fullList[i] = &domain.ApiKey{}
+	}
+	revoked := make([]*domain.ApiKey, domain.MaxApiKeysPerMerchant)
+	for i := range revoked {
+		revoked[i] = &domain.ApiKey{RevokedAt: &past}
+	}
+
+	tests := []struct {
+		name       string
+		livemode   bool
+		req        *domain.CreateApiKeyRequest
+		mock       func(repo *mocks.MockApiKeyRepository)
+		wantPrefix string
+		wantErr    error
+	}{
+		{
+			name:     "Success Live Key",
+			livemode: true,
+			req: &domain.CreateApiKeyRequest{
+				Name:      "checkout-service",
+				Scopes:    []string{domain.ScopeTransactionsWrite, domain.ScopeTransactionsRead},
+				ExpiresAt: &future,
+			},
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("ListByMerchant", mock.Anything, merchantID).Return([]*domain.ApiKey{}, nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(k *domain.ApiKey) bool {
+					return k.MerchantID == merchantID && k.Livemode && len(k.KeyHash) == 64 &&
+						strings.HasPrefix(k.Prefix, domain.LiveApiKeyPrefix+"_") && len(k.Scopes) == 2
+				})).Return(nil)
+			},
+			wantPrefix: domain.LiveApiKeyPrefix + "_",
+		},
+		{
+			name:     "Success Test Key Past Revoked Keys",
+			livemode: false,
+			req: &domain.CreateApiKeyRequest{
+				Name:   "refund-bot",
+				Scopes: []string{domain.ScopeRefundsWrite},
+			},
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("ListByMerchant", mock.Anything, merchantID).Return(revoked, nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(k *domain.ApiKey) bool {
+					return !k.Livemode && k.ExpiresAt == nil
+				})).Return(nil)
+			},
+			wantPrefix: domain.TestApiKeyPrefix + "_",
+		},
+		{
+			name: "Failed Missing Name",
+			req: &domain.CreateApiKeyRequest{
+				Name:   "  ",
+				Scopes: []string{domain.ScopeTransactionsRead},
+			},
+			mock:    func(repo *mocks.MockApiKeyRepository) {},
+			wantErr: domain.ErrInvalidApiKeyRequest,
+		},
+		{
+			name: "Failed No Scopes",
+			req: &domain.CreateApiKeyRequest{
+				Name: "checkout-service",
+			},
+			mock:    func(repo *mocks.MockApiKeyRepository) {},
+			wantErr: domain.ErrInvalidApiKeyRequest,
+		},
+		{
+			name: "Failed Unknown Scope",
+			req: &domain.CreateApiKeyRequest{
+				Name:   "checkout-service",
+				Scopes: []string{"merchants:write"},
+			},
+			mock:    func(repo *mocks.MockApiKeyRepository) {},
+			wantErr: domain.ErrInvalidApiKeyRequest,
+		},
+		{
+			name: "Failed Expiry In The Past",
+			req: &domain.CreateApiKeyRequest{
+				Name:      "checkout-service",
+				Scopes:    []string{domain.ScopeTransactionsRead},
+				ExpiresAt: &past,
+			},
+			mock:    func(repo *mocks.MockApiKeyRepository) {},
+			wantErr: domain.ErrInvalidApiKeyRequest,
+		},
+		{
+			name: "Failed Limit Reached",
+			req: &domain.CreateApiKeyRequest{
+				Name:   "checkout-service",
+				Scopes: []string{domain.ScopeTransactionsRead},
+			},
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("ListByMerchant", mock.Anything, merchantID).Return(fullList, nil)
+			},
+			wantErr: domain.ErrApiKeyLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockApiKeyRepository)
+			mockMerchantRepo := new(mocks.MockMerchantRepository)
+			tt.mock(mockRepo)
+
+			apiKeyUC := usecase.NewApiKeyUC(mockRepo, mockMerchantRepo, time.Second*2)
+
+			key, err := apiKeyUC.Create(context.Background(), merchantID, tt.livemode, tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, key)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(key.Key, tt.wantPrefix))
+				assert.True(t, strings.HasPrefix(key.Key, key.Prefix))
+				assert.Equal(t, pkg.HashKey256(key.Key), key.KeyHash)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApiKeyUsecase_Authenticate(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	keyID := pkg.GenerateUUIDV7()
+	rawKey := pkg.GenerateApiKey(domain.LiveApiKeyPrefix)
+	keyHash := pkg.HashKey256(rawKey)
+
+	past := time.Now().Add(-time.Hour)
+	recently := time.Now().Add(-10 * time.Second)
+
+	activeMerchant := &domain.Merchant{ID: merchantID, Status: domain.MerchantStatusActive}
+	suspendedMerchant := &domain.Merchant{ID: merchantID, Status: domain.MerchantStatusSuspended}
+
+	newKey := func() *domain.ApiKey {
+		return &domain.ApiKey{
+			ID:         keyID,
+			MerchantID: merchantID,
+			KeyHash:    keyHash,
+			Scopes:     []string{domain.ScopeTransactionsRead},
+			Livemode:   true,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository)
+		wantErr error
+	}{
+		{
+			name: "Success Records Last Use",
+			mock: func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository) {
+				repo.On("FindByHash", mock.Anything, keyHash).Return(newKey(), nil)
+				merchantRepo.On("FindByID", mock.Anything, merchantID).Return(activeMerchant, nil)
+				repo.On("TouchLastUsed", mock.Anything, keyID, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name: "Success Recently Used Key Is Not Written Again",
+			mock: func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository) {
+				key := newKey()
+				key.LastUsedAt = &recently
+				repo.On("FindByHash", mock.Anything, keyHash).Return(key, nil)
+				merchantRepo.On("FindByID", mock.Anything, merchantID).Return(activeMerchant, nil)
+			},
+		},
+		{
+			name: "Failed Revoked Key",
+			mock: func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository) {
+				key := newKey()
+				key.RevokedAt = &past
+				repo.On("FindByHash", mock.Anything, keyHash).Return(key, nil)
+			},
+			wantErr: domain.ErrApiKeyNotFound,
+		},
+		{
+			name: "Failed Expired Key",
+			mock: func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository) {
+				key := newKey()
+				key.ExpiresAt = &past
+				repo.On("FindByHash", mock.Anything, keyHash).Return(key, nil)
+			},
+			wantErr: domain.ErrApiKeyNotFound,
+		},
+		{
+			name: "Failed Unknown Key",
+			mock: func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository) {
+				repo.On("FindByHash", mock.Anything, keyHash).Return(nil, domain.ErrApiKeyNotFound)
+			},
+			wantErr: domain.ErrApiKeyNotFound,
+		},
+		{
+			name: "Failed Suspended Merchant",
+			mock: func(repo *mocks.MockApiKeyRepository, merchantRepo *mocks.MockMerchantRepository) {
+				repo.On("FindByHash", mock.Anything, keyHash).Return(newKey(), nil)
+				merchantRepo.On("FindByID", mock.Anything, merchantID).Return(suspendedMerchant, nil)
+			},
+			wantErr: domain.ErrMerchantNotActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockApiKeyRepository)
+			mockMerchantRepo := new(mocks.MockMerchantRepository)
+			tt.mock(mockRepo, mockMerchantRepo)
+
+			apiKeyUC := usecase.NewApiKeyUC(mockRepo, mockMerchantRepo, time.Second*2)
+
+			merchant, key, err := apiKeyUC.Authenticate(context.Background(), rawKey)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, merchant)
+				assert.Nil(t, key)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, merchantID, merchant.ID)
+				assert.Equal(t, keyID, key.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockMerchantRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApiKeyUsecase_Revoke(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	keyID := pkg.GenerateUUIDV7()
+	revokedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		testMode bool
+		mock     func(repo *mocks.MockApiKeyRepository)
+		wantErr  error
+	}{
+		{
+			name: "Success Revoke",
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, keyID).Return(&domain.ApiKey{ID: keyID, MerchantID: merchantID, Livemode: true}, nil)
+				repo.On("Revoke", mock.Anything, merchantID, keyID, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name:     "Success Test Key Revokes Test Key",
+			testMode: true,
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, keyID).Return(&domain.ApiKey{ID: keyID, MerchantID: merchantID}, nil)
+				repo.On("Revoke", mock.Anything, merchantID, keyID, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name: "Success Already Revoked",
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, keyID).Return(&domain.ApiKey{ID: keyID, MerchantID: merchantID, RevokedAt: &revokedAt}, nil)
+			},
+		},
+		{
+			name: "Failed Not Found",
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, keyID).Return(nil, domain.ErrApiKeyNotFound)
+			},
+			wantErr: domain.ErrApiKeyNotFound,
+		},
+		{
+			name:     "Failed Test Key Revokes Live Key",
+			testMode: true,
+			mock: func(repo *mocks.MockApiKeyRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, keyID).Return(&domain.ApiKey{ID: keyID, MerchantID: merchantID, Livemode: true}, nil)
+			},
+			wantErr: domain.ErrApiKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockApiKeyRepository)
+			tt.mock(mockRepo)
+
+			apiKeyUC := usecase.NewApiKeyUC(mockRepo, new(mocks.MockMerchantRepository), time.Second*2)
+
+			key, err := apiKeyUC.Revoke(context.Background(), merchantID, !tt.testMode, keyID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, key)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, key.RevokedAt)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApiKeyUsecase_List(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	liveKey := &domain.ApiKey{ID: pkg.GenerateUUIDV7(), MerchantID: merchantID, Livemode: true}
+	testKey := &domain.ApiKey{ID: pkg.GenerateUUIDV7(), MerchantID: merchantID}
+
+	tests := []struct {
+		name     string
+		livemode bool
+		want     []*domain.ApiKey
+	}{
+		{"Live Key Sees All Keys", true, []*domain.ApiKey{liveKey, testKey}},
+		{"Test Key Sees Test Keys", false, []*domain.ApiKey{testKey}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockApiKeyRepository)
+			mockRepo.On("ListByMerchant", mock.Anything, merchantID).Return([]*domain.ApiKey{liveKey, testKey}, nil)
+
+			apiKeyUC := usecase.NewApiKeyUC(mockRepo, new(mocks.MockMerchantRepository), time.Second*2)
+
+			keys, err := apiKeyUC.List(context.Background(), merchantID, tt.livemode)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, keys)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// idempotencyLockTTL bounds how long a crashed request can keep its key locked
+const idempotencyLockTTL = time.Minute
+
+type IdempotencyUC struct {
+	store   domain.IdempotencyStore
+	timeout time.Duration
+}
+
+func NewIdempotencyUC(s domain.IdempotencyStore, t time.Duration) domain.IdempotencyUC {
+	return &IdempotencyUC{
+		store:   s,
+		timeout: t,
+	}
+}
+
+func (u *IdempotencyUC) Begin(ctx context.Context, merchantID uuid.UUID, key string, requestHash string) (*domain.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	storeKey := idempotencyStoreKey(merchantID, key)
+
+	reserved, err := u.store.Reserve(ctx, storeKey, &domain.IdempotencyRecord{RequestHash: requestHash}, idempotencyLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	record, err := u.store.Get(ctx, storeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	// the first request was released between our reserve and get, the client may simply retry
+	case record == nil:
+		return nil, domain.ErrIdempotencyKeyInUse
+	case record.RequestHash != requestHash:
+		return nil, domain.ErrIdempotencyKeyMismatch
+	case record.InProgress():
+		return nil, domain.ErrIdempotencyKeyInUse
+	}
+
+	return record, nil
+}
+
+func (u *IdempotencyUC) Complete(ctx context.Context, merchantID uuid.UUID, key string, record *domain.IdempotencyRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.store.Save(ctx, idempotencyStoreKey(merchantID, key), record, domain.IdempotencyKeyTTL)
+}
+
+// Release frees the key so the request can be retried with it
+func (u *IdempotencyUC) Release(ctx context.Context, merchantID uuid.UUID, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.store.Delete(ctx, idempotencyStoreKey(merchantID, key))
+}
+
+// idempotencyStoreKey scopes keys per merchant so two merchants can never replay each other's responses
+func idempotencyStoreKey(merchantID uuid.UUID, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s", merchantID, key)
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotencyUsecase_Begin(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	storeKey := "idempotency:" + merchantID.String() + ":key-123"
+
+	completed := &domain.IdempotencyRecord{
+		RequestHash: "hash-a",
+		StatusCode:  201,
+		Body:        []byte(`{"code":201}`),
+	}
+
+	tests := []struct {
+		name       string
+		mock       func(store *mocks.MockIdempotencyStore)
+		wantRecord *domain.IdempotencyRecord
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name: "First Request Reserves Key",
+			mock: func(store *mocks.MockIdempotencyStore) {
+				store.On("Reserve", mock.Anything, storeKey, &domain.IdempotencyRecord{RequestHash: "hash-a"}, time.Minute).
+					Return(true, nil)
+			},
+			wantRecord: nil,
+		},
+		{
+			name: "Retry Replays Completed Response",
+			mock: func(store *mocks.MockIdempotencyStore) {
+				store.On("Reserve", mock.Anything, storeKey, mock.Anything, mock.Anything).Return(false, nil)
+				store.On("Get", mock.Anything, storeKey).Return(completed, nil)
+			},
+			wantRecord: completed,
+		},
+		{
+			name: "Failed Different Payload",
+			mock: func(store *mocks.MockIdempotencyStore) {
+				store.On("Reserve", mock.Anything, storeKey, mock.Anything, mock.Anything).Return(false, nil)
+				store.On("Get", mock.Anything, storeKey).Return(&domain.IdempotencyRecord{
+					RequestHash: "hash-b",
+					StatusCode:  201,
+				}, nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name: "Failed Request Still In Progress",
+			mock: func(store *mocks.MockIdempotencyStore) {
+				store.On("Reserve", mock.Anything, storeKey, mock.Anything, mock.Anything).Return(false, nil)
+				store.On("Get", mock.Anything, storeKey).Return(&domain.IdempotencyRecord{RequestHash: "hash-a"}, nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrIdempotencyKeyInUse,
+		},
+		{
+			name: "Failed Key Released Concurrently",
+			mock: func(store *mocks.MockIdempotencyStore) {
+				store.On("Reserve", mock.Anything, storeKey, mock.Anything, mock.Anything).Return(false, nil)
+				store.On("Get", mock.Anything, storeKey).Return(nil, nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrIdempotencyKeyInUse,
+		},
+		{
+			name: "Failed Store Error",
+			mock: func(store *mocks.MockIdempotencyStore) {
+				store.On("Reserve", mock.Anything, storeKey, mock.Anything, mock.Anything).Return(false, errors.New("redis down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(mocks.MockIdempotencyStore)
+			tt.mock(mockStore)
+
+			idempotencyUC := usecase.NewIdempotencyUC(mockStore, time.Second*2)
+
+			record, err := idempotencyUC.Begin(context.Background(), merchantID, "key-123", "hash-a")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				assert.Nil(t, record)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRecord, record)
+			}
+
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdempotencyUsecase_CompleteAndRelease(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	storeKey := "idempotency:" + merchantID.String() + ":key-123"
+
+	record := &domain.IdempotencyRecord{
+		RequestHash: "hash-a",
+		StatusCode:  201,
+		Body:        []byte(`{"code":201}`),
+	}
+
+	mockStore := new(mocks.MockIdempotencyStore)
+	mockStore.On("Save", mock.Anything, storeKey, record, domain.IdempotencyKeyTTL).Return(nil)
+	mockStore.On("Delete", mock.Anything, storeKey).Return(nil)
+
+	idempotencyUC := usecase.NewIdempotencyUC(mockStore, time.Second*2)
+
+	assert.NoError(t, idempotencyUC.Complete(context.Background(), merchantID, "key-123", record))
+	assert.NoError(t, idempotencyUC.Release(context.Background(), merchantID, "key-123"))
+
+	mockStore.AssertExpectations(t)
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg"
 	"time"
@@ -22,19 +21,33 @@ func NewMerchantUC(r domain.MerchantRepository, t time.Duration) domain.Merchant
 	}
 }
 
-func (u *merchantUC) ValidateApiKey(ctx context.Context, apiKey string) (*domain.Merchant, error) {
+func (u *merchantUC) ValidateApiKey(ctx context.Context, apiKey string) (*domain.Merchant, *time.Time, error) {
 	apiKeyHash := pkg.HashKey256(apiKey)
 
 	merchant, err := u.merchantRepo.FindByApiKey(ctx, apiKeyHash)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if merchant.Status != domain.MerchantStatusActive {
-		return nil, errors.New("merchant is not active")
+		return nil, nil, domain.ErrMerchantNotActive
 	}
 
-	return merchant, nil
+	var deprecatedUntil *time.Time
+	switch apiKeyHash {
+	case merchant.APIKeyHash, merchant.TestAPIKeyHash:
+		return merchant, nil, nil
+	case merchant.PreviousAPIKeyHash:
+		deprecatedUntil = merchant.PreviousAPIKeyExpiresAt
+	case merchant.PreviousTestAPIKeyHash:
+		deprecatedUntil = merchant.PreviousTestAPIKeyExpiresAt
+	}
+
+	if deprecatedUntil == nil || !time.Now().Before(*deprecatedUntil) {
+		return nil, nil, domain.ErrApiKeyGracePeriodEnded
+	}
+
+	return merchant, deprecatedUntil, nil
 }
 
 func (u *merchantUC) Register(c context.Context, req *domain.RegisterMerchantRequest) (*domain.Merchant, error) {
@@ -43,7 +56,8 @@ func (u *merchantUC) Register(c context.Context, req *domain.RegisterMerchantReq
 
 	id := pkg.GenerateUUIDV7()
 
-	apiKey := pkg.GenerateApiKey("mch")
+	apiKey := pkg.GenerateApiKey(domain.LiveApiKeyPrefix)
+	testApiKey := pkg.GenerateApiKey(domain.TestApiKeyPrefix)
 
 	apiKeyHash := pkg.HashKey256(apiKey)
 
@@ -54,8 +68,13 @@ func (u *merchantUC) Register(c context.Context, req *domain.RegisterMerchantReq
 		ApiKey:      apiKey,
 		APIKeyHash:  apiKeyHash,
 		CallbackURL: req.CallbackURL,
+		APIVersion:  domain.LatestAPIVersion,
 		Status:      domain.MerchantStatusActive,
 		Balance:     0,
+
+		TestAPIKeyHash: pkg.HashKey256(testApiKey),
+
+		WebhookSecret: pkg.GenerateApiKey("whsec"),
 	}
 
 	createdMerchant, err := u.merchantRepo.Create(ctx, merchant)
@@ -64,6 +83,7 @@ func (u *merchantUC) Register(c context.Context, req *domain.RegisterMerchantReq
 	}
 
 	createdMerchant.ApiKey = apiKey
+	createdMerchant.TestApiKey = testApiKey
 
 	return createdMerchant, nil
 }
@@ -77,6 +97,10 @@ func (u *merchantUC) GetProfile(ctx context.Context, id uuid.UUID) (*domain.Merc
 }
 
 func (u *merchantUC) UpdateProfile(ctx context.Context, id uuid.UUID, req *domain.UpdateMerchantRequest) (*domain.Merchant, error) {
+	if req.APIVersion != "" && !domain.IsValidAPIVersion(req.APIVersion) {
+		return nil, domain.ErrInvalidAPIVersion
+	}
+
 	merchant, err := u.merchantRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -90,6 +114,10 @@ func (u *merchantUC) UpdateProfile(ctx context.Context, id uuid.UUID, req *domai
 		merchant.CallbackURL = req.CallbackURL
 	}
 
+	if req.APIVersion != "" {
+		merchant.APIVersion = req.APIVersion
+	}
+
 	merchant.UpdatedAt = time.Now()
 
 	if err := u.merchantRepo.Update(ctx, merchant); err != nil {
@@ -99,14 +127,40 @@ func (u *merchantUC) UpdateProfile(ctx context.Context, id uuid.UUID, req *domai
 	return merchant, nil
 }
 
-func (u *merchantUC) RegenerateApiKey(ctx context.Context, id uuid.UUID) (string, error) {
-	newApiKey := pkg.GenerateApiKey("mch")
+// RegenerateApiKey replaces the merchant's live or test key, the other one keeps working
+func (u *merchantUC) RegenerateApiKey(ctx context.Context, id uuid.UUID, livemode bool, gracePeriod time.Duration) (string, *time.Time, error) {
+	if gracePeriod < 0 || gracePeriod > domain.MaxApiKeyGracePeriod {
+		return "", nil, domain.ErrInvalidGracePeriod
+	}
+
+	var previousExpiresAt *time.Time
+	if gracePeriod > 0 {
+		expiresAt := time.Now().Add(gracePeriod)
+		previousExpiresAt = &expiresAt
+	}
+
+	prefix := domain.TestApiKeyPrefix
+	if livemode {
+		prefix = domain.LiveApiKeyPrefix
+	}
+	newApiKey := pkg.GenerateApiKey(prefix)
 
 	newApiKeyHash := pkg.HashKey256(newApiKey)
 
-	if err := u.merchantRepo.RegenerateApiKey(ctx, id, newApiKeyHash); err != nil {
+	if err := u.merchantRepo.RegenerateApiKey(ctx, id, livemode, newApiKeyHash, previousExpiresAt); err != nil {
+		return "", nil, err
+	}
+
+	return newApiKey, previousExpiresAt, nil
+}
+
+// RotateWebhookSecret issues a new signing secret, callbacks are signed with it from the next delivery on
+func (u *merchantUC) RotateWebhookSecret(ctx context.Context, id uuid.UUID) (string, error) {
+	newSecret := pkg.GenerateApiKey("whsec")
+
+	if err := u.merchantRepo.RotateWebhookSecret(ctx, id, newSecret); err != nil {
 		return "", err
 	}
 
-	return newApiKey, nil
+	return newSecret, nil
 }
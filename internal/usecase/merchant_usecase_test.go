@@ -7,6 +7,7 @@ import (
 	"go-payment-aggregator/internal/mocks"
 	"go-payment-aggregator/internal/pkg"
 	"go-payment-aggregator/internal/usecase"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,7 +44,12 @@ func TestMerchantUsecase_Register(t *testing.T) {
 					return m.Name == reqUC.Name &&
 						m.Email == reqUC.Email &&
 						m.CallbackURL == reqUC.CallbackURL &&
-						m.Status == domain.MerchantStatusActive
+						m.Status == domain.MerchantStatusActive &&
+						m.APIVersion == domain.LatestAPIVersion &&
+						strings.HasPrefix(m.WebhookSecret, "whsec_") &&
+						len(m.APIKeyHash) == 64 &&
+						len(m.TestAPIKeyHash) == 64 &&
+						m.APIKeyHash != m.TestAPIKeyHash
 				})).Return(returnedMerchant, nil)
 			},
 			wantErr: false,
@@ -77,7 +83,8 @@ func TestMerchantUsecase_Register(t *testing.T) {
 				assert.NotNil(t, res)
 				assert.Equal(t, returnedMerchant.Name, res.Name)
 				assert.Equal(t, returnedMerchant.Status, res.Status)
-				assert.NotEmpty(t, res.ApiKey)
+				assert.True(t, strings.HasPrefix(res.ApiKey, domain.LiveApiKeyPrefix+"_"))
+				assert.True(t, domain.IsTestApiKey(res.TestApiKey))
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -161,7 +168,9 @@ func TestMerchantUsecase_UpdateProfile(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
+		name string
+		// req defaults to reqUC
+		req     *domain.UpdateMerchantRequest
 		mock    func(repo *mocks.MockMerchantRepository)
 		wantErr bool
 	}{
@@ -176,6 +185,14 @@ func TestMerchantUsecase_UpdateProfile(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Failed Unknown API Version",
+			req: &domain.UpdateMerchantRequest{
+				APIVersion: "2020-01-01",
+			},
+			mock:    func(repo *mocks.MockMerchantRepository) {},
+			wantErr: true,
+		},
 		{
 			name: "Failed Repository Update Merchant Profile - Not Found",
 			mock: func(repo *mocks.MockMerchantRepository) {
@@ -204,8 +221,13 @@ func TestMerchantUsecase_UpdateProfile(t *testing.T) {
 
 			merchantUC := usecase.NewMerchantUC(mockRepo, time.Second*2)
 
+			req := reqUC
+			if tt.req != nil {
+				req = tt.req
+			}
+
 			ctx := context.Background()
-			res, err := merchantUC.UpdateProfile(ctx, merchantID, reqUC)
+			res, err := merchantUC.UpdateProfile(ctx, merchantID, req)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -236,10 +258,24 @@ func TestMerchantUsecase_ValidateApiKey(t *testing.T) {
 		Balance:     1000,
 	}
 
+	graceEnd := time.Now().Add(time.Hour)
+	graceEnded := time.Now().Add(-time.Second)
+
+	// replacedMerchant is returnedMerchant after its live key was regenerated with a grace period
+	replacedMerchant := func(expiresAt time.Time) *domain.Merchant {
+		m := *returnedMerchant
+		m.APIKeyHash = pkg.HashKey256(pkg.GenerateApiKey(domain.LiveApiKeyPrefix))
+		m.PreviousAPIKeyHash = apiKeyHash
+		m.PreviousAPIKeyExpiresAt = &expiresAt
+		return &m
+	}
+
 	tests := []struct {
-		name    string
-		mock    func(repo *mocks.MockMerchantRepository)
-		wantErr bool
+		name                string
+		mock                func(repo *mocks.MockMerchantRepository)
+		wantDeprecatedUntil *time.Time
+		wantErr             bool
+		errIs               error
 	}{
 		{
 			name: "Success Validate API Key",
@@ -248,6 +284,22 @@ func TestMerchantUsecase_ValidateApiKey(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Success Replaced Key Inside Grace Period",
+			mock: func(repo *mocks.MockMerchantRepository) {
+				repo.On("FindByApiKey", mock.Anything, apiKeyHash).Return(replacedMerchant(graceEnd), nil)
+			},
+			wantDeprecatedUntil: &graceEnd,
+			wantErr:             false,
+		},
+		{
+			name: "Failed Replaced Key After Grace Period",
+			mock: func(repo *mocks.MockMerchantRepository) {
+				repo.On("FindByApiKey", mock.Anything, apiKeyHash).Return(replacedMerchant(graceEnded), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrApiKeyGracePeriodEnded,
+		},
 		{
 			name: "Failed Validate API Key - Merchant Not Found",
 			mock: func(repo *mocks.MockMerchantRepository) {
@@ -276,13 +328,17 @@ func TestMerchantUsecase_ValidateApiKey(t *testing.T) {
 			merchantUC := usecase.NewMerchantUC(mockRepo, time.Second*2)
 
 			ctx := context.Background()
-			res, err := merchantUC.ValidateApiKey(ctx, apiKey)
+			res, deprecatedUntil, err := merchantUC.ValidateApiKey(ctx, apiKey)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, res)
+				assert.Equal(t, tt.wantDeprecatedUntil, deprecatedUntil)
 				assert.Equal(t, returnedMerchant.ID, res.ID)
 				assert.Equal(t, returnedMerchant.Name, res.Name)
 				assert.Equal(t, returnedMerchant.Balance, res.Balance)
@@ -294,24 +350,120 @@ func TestMerchantUsecase_ValidateApiKey(t *testing.T) {
 func TestMerchantUsecase_RegenerateApiKey(t *testing.T) {
 	merchantID := pkg.GenerateUUIDV7()
 
+	revokedNow := mock.MatchedBy(func(expiresAt *time.Time) bool {
+		return expiresAt == nil
+	})
+
+	tests := []struct {
+		name        string
+		livemode    bool
+		gracePeriod time.Duration
+		mock        func(repo *mocks.MockMerchantRepository)
+		wantPrefix  string
+		wantGrace   bool
+		wantErr     bool
+		errIs       error
+	}{
+		{
+			name:     "Success Regenerate Live API Key",
+			livemode: true,
+			mock: func(repo *mocks.MockMerchantRepository) {
+				repo.On("RegenerateApiKey", mock.Anything, merchantID, true, mock.MatchedBy(func(apiKeyHash string) bool {
+					return len(apiKeyHash) == 64
+				}), revokedNow).Return(nil)
+			},
+			wantPrefix: domain.LiveApiKeyPrefix + "_",
+			wantErr:    false,
+		},
+		{
+			name:     "Success Regenerate Test API Key",
+			livemode: false,
+			mock: func(repo *mocks.MockMerchantRepository) {
+				repo.On("RegenerateApiKey", mock.Anything, merchantID, false, mock.AnythingOfType("string"), revokedNow).Return(nil)
+			},
+			wantPrefix: domain.TestApiKeyPrefix + "_",
+			wantErr:    false,
+		},
+		{
+			name:        "Success Regenerate With Grace Period",
+			livemode:    true,
+			gracePeriod: 24 * time.Hour,
+			mock: func(repo *mocks.MockMerchantRepository) {
+				repo.On("RegenerateApiKey", mock.Anything, merchantID, true, mock.AnythingOfType("string"), mock.MatchedBy(func(expiresAt *time.Time) bool {
+					return expiresAt != nil && time.Until(*expiresAt) > 23*time.Hour && time.Until(*expiresAt) <= 24*time.Hour
+				})).Return(nil)
+			},
+			wantPrefix: domain.LiveApiKeyPrefix + "_",
+			wantGrace:  true,
+			wantErr:    false,
+		},
+		{
+			name:        "Failed Grace Period Too Long",
+			livemode:    true,
+			gracePeriod: domain.MaxApiKeyGracePeriod + time.Second,
+			mock:        func(repo *mocks.MockMerchantRepository) {},
+			wantErr:     true,
+			errIs:       domain.ErrInvalidGracePeriod,
+		},
+		{
+			name:     "Failed Regenerate API Key - Repository Error",
+			livemode: true,
+			mock: func(repo *mocks.MockMerchantRepository) {
+				repo.On("RegenerateApiKey", mock.Anything, merchantID, true, mock.AnythingOfType("string"), revokedNow).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockMerchantRepository)
+
+			tt.mock(mockRepo)
+
+			merchantUC := usecase.NewMerchantUC(mockRepo, time.Second*2)
+
+			ctx := context.Background()
+			res, previousExpiresAt, err := merchantUC.RegenerateApiKey(ctx, merchantID, tt.livemode, tt.gracePeriod)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, res)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(res, tt.wantPrefix))
+				assert.Equal(t, tt.wantGrace, previousExpiresAt != nil)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMerchantUsecase_RotateWebhookSecret(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+
 	tests := []struct {
 		name    string
 		mock    func(repo *mocks.MockMerchantRepository)
 		wantErr bool
 	}{
 		{
-			name: "Success Regenerate API Key",
+			name: "Success Rotate Webhook Secret",
 			mock: func(repo *mocks.MockMerchantRepository) {
-				repo.On("RegenerateApiKey", mock.Anything, merchantID, mock.MatchedBy(func(apiKeyHash string) bool {
-					return len(apiKeyHash) == 64
+				repo.On("RotateWebhookSecret", mock.Anything, merchantID, mock.MatchedBy(func(secret string) bool {
+					return strings.HasPrefix(secret, "whsec_")
 				})).Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "Failed Regenerate API Key - Repository Error",
+			name: "Failed Rotate Webhook Secret - Repository Error",
 			mock: func(repo *mocks.MockMerchantRepository) {
-				repo.On("RegenerateApiKey", mock.Anything, merchantID, mock.AnythingOfType("string")).Return(assert.AnError)
+				repo.On("RotateWebhookSecret", mock.Anything, merchantID, mock.AnythingOfType("string")).Return(assert.AnError)
 			},
 			wantErr: true,
 		},
@@ -326,16 +478,17 @@ func TestMerchantUsecase_RegenerateApiKey(t *testing.T) {
 			merchantUC := usecase.NewMerchantUC(mockRepo, time.Second*2)
 
 			ctx := context.Background()
-			res, err := merchantUC.RegenerateApiKey(ctx, merchantID)
+			res, err := merchantUC.RotateWebhookSecret(ctx, merchantID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Empty(t, res)
 			} else {
 				assert.NoError(t, err)
-				assert.NotNil(t, res)
-				assert.NotEmpty(t, res)
+				assert.True(t, strings.HasPrefix(res, "whsec_"))
 			}
+
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"time"
+)
+
+type OutboxRelayUC struct {
+	outboxRepo       domain.OutboxRepository
+	webhookPublisher domain.WebhookPublisher
+	timeout          time.Duration
+}
+
+func NewOutboxRelayUC(r domain.OutboxRepository, p domain.WebhookPublisher, t time.Duration) domain.OutboxRelayUC {
+	return &OutboxRelayUC{
+		outboxRepo:       r,
+		webhookPublisher: p,
+		timeout:          t,
+	}
+}
+
+// Relay moves up to limit outbox events onto the webhook queue. Delivery is at least once:
+// an event pushed just before its row fails to be marked is pushed again on the next run.
+func (u *OutboxRelayUC) Relay(ctx context.Context, limit int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.outboxRepo.PublishPending(ctx, limit, u.publish)
+}
+
+func (u *OutboxRelayUC) publish(ctx context.Context, event *domain.OutboxEvent) error {
+	var payload domain.WebhookPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("outbox event %s: %w", event.ID, err)
+	}
+
+	return u.webhookPublisher.Publish(ctx, &payload)
+}
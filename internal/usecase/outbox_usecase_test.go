@@ -0,0 +1,111 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxRelayUsecase_Relay(t *testing.T) {
+	payload := &domain.WebhookPayload{
+		Event:         domain.WebhookEventTransactionPaid,
+		TransactionID: pkg.GenerateUUIDV7().String(),
+		OrderID:       "ORDER-TEST-123",
+		Status:        "PAID",
+		Amount:        100000,
+		Provider:      "midtrans",
+	}
+	body, _ := json.Marshal(payload)
+
+	event := &domain.OutboxEvent{
+		ID:        pkg.GenerateUUIDV7(),
+		EventType: domain.WebhookEventTransactionPaid,
+		Payload:   body,
+	}
+
+	// relayEvents makes PublishPending hand every event to the relay's publish function
+	relayEvents := func(events ...*domain.OutboxEvent) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			publish := args.Get(2).(domain.OutboxPublishFunc)
+			for _, e := range events {
+				if err := publish(args.Get(0).(context.Context), e); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mock        func(repo *mocks.MockOutboxRepository, publisher *mocks.MockWebhookPublisher)
+		wantRelayed int
+		wantErr     bool
+	}{
+		{
+			name: "Success Relay Event To Webhook Queue",
+			mock: func(repo *mocks.MockOutboxRepository, publisher *mocks.MockWebhookPublisher) {
+				repo.On("PublishPending", mock.Anything, 100, mock.Anything).
+					Run(relayEvents(event)).
+					Return(1, nil)
+
+				publisher.On("Publish", mock.Anything, payload).Return(nil)
+			},
+			wantRelayed: 1,
+		},
+		{
+			name: "Failed Publish",
+			mock: func(repo *mocks.MockOutboxRepository, publisher *mocks.MockWebhookPublisher) {
+				repo.On("PublishPending", mock.Anything, 100, mock.Anything).
+					Run(relayEvents(event)).
+					Return(0, errors.New("redis down"))
+
+				publisher.On("Publish", mock.Anything, payload).Return(errors.New("redis down"))
+			},
+			wantRelayed: 0,
+			wantErr:     true,
+		},
+		{
+			name: "Failed Malformed Payload Is Not Published",
+			mock: func(repo *mocks.MockOutboxRepository, publisher *mocks.MockWebhookPublisher) {
+				repo.On("PublishPending", mock.Anything, 100, mock.Anything).
+					Run(relayEvents(&domain.OutboxEvent{ID: pkg.GenerateUUIDV7(), Payload: []byte("{")})).
+					Return(0, errors.New("invalid payload"))
+			},
+			wantRelayed: 0,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockOutboxRepository)
+			mockPublisher := new(mocks.MockWebhookPublisher)
+
+			tt.mock(mockRepo, mockPublisher)
+
+			relayUC := usecase.NewOutboxRelayUC(mockRepo, mockPublisher, time.Second*2)
+
+			relayed, err := relayUC.Relay(context.Background(), 100)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRelayed, relayed)
+
+			mockRepo.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+		})
+	}
+}
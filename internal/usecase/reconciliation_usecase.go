@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"sync"
+	"time"
+)
+
+type ReconciliationConfig struct {
+	// PendingThreshold is how long a transaction must stay PENDING before we start polling for it
+	PendingThreshold time.Duration
+	// ExpiredWindow is how long after expiring a transaction is still polled for a late payment
+	ExpiredWindow time.Duration
+	BatchSize     int
+	// RateLimits caps CheckStatus calls per second for each provider, providers missing here are not limited
+	RateLimits map[string]float64
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+type ReconciliationUC struct {
+	transactionRepo domain.TransactionRepository
+	transactionUC   domain.TransactionUC
+	gateways        domain.Gateways
+	config          ReconciliationConfig
+	limiters        map[string]*pkg.RateLimiter
+	timeout         time.Duration
+
+	mu               sync.Mutex
+	providerFailures map[string]int
+	providerPaused   map[string]time.Time
+}
+
+func NewReconciliationUC(r domain.TransactionRepository, tu domain.TransactionUC, g domain.Gateways, cfg ReconciliationConfig, t time.Duration) domain.ReconciliationUC {
+	limiters := make(map[string]*pkg.RateLimiter, len(cfg.RateLimits))
+	for provider, perSecond := range cfg.RateLimits {
+		limiters[provider] = pkg.NewRateLimiter(perSecond)
+	}
+
+	return &ReconciliationUC{
+		transactionRepo:  r,
+		transactionUC:    tu,
+		gateways:         g,
+		config:           cfg,
+		limiters:         limiters,
+		timeout:          t,
+		providerFailures: map[string]int{},
+		providerPaused:   map[string]time.Time{},
+	}
+}
+
+// ReconcilePending polls providers for transactions that stayed PENDING too long, or expired
+// recently, and applies any status we missed through the same path as webhooks, returning every
+// correction it made
+func (u *ReconciliationUC) ReconcilePending(ctx context.Context) ([]*domain.StatusCorrection, error) {
+	now := time.Now()
+
+	transactions, err := u.transactionRepo.FindDueForReconciliation(ctx, now.Add(-u.config.PendingThreshold), now.Add(-u.config.ExpiredWindow), now, u.config.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*domain.StatusCorrection
+	var errs []error
+	for _, tx := range transactions {
+		// rows of a paused provider are pushed past the pause, or they would fill every batch
+		// ahead of other providers until it ends
+		if until, paused := u.pausedUntil(tx.Provider); paused {
+			if err := u.transactionRepo.ScheduleReconciliation(ctx, tx.ID, tx.ReconcileAttempts, until); err != nil {
+				errs = append(errs, fmt.Errorf("transaction %s: %w", tx.ID, err))
+			}
+			continue
+		}
+
+		correction, err := u.reconcile(ctx, tx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("transaction %s: %w", tx.ID, err))
+			continue
+		}
+		if correction != nil {
+			corrections = append(corrections, correction)
+		}
+	}
+
+	return corrections, errors.Join(errs...)
+}
+
+func (u *ReconciliationUC) reconcile(ctx context.Context, tx *domain.Transaction) (*domain.StatusCorrection, error) {
+	gateway, exists := u.gateways.For(tx.Provider, tx.Livemode)
+	if !exists {
+		return nil, errors.New("payment provider not supported")
+	}
+
+	if limiter, ok := u.limiters[tx.Provider]; ok {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, u.timeout)
+	providerStatus, err := gateway.CheckStatus(checkCtx, &domain.CheckStatusRequest{
+		OrderID:    tx.ProviderOrderID,
+		ExternalID: tx.ExternalID,
+	})
+	cancel()
+
+	if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
+		u.recordFailure(tx.Provider)
+		return nil, errors.Join(err, u.scheduleNext(ctx, tx))
+	}
+	u.recordSuccess(tx.Provider)
+
+	nextStatus := domain.TransactionStatus(providerStatus)
+	if err != nil || nextStatus == domain.TransactionStatusPending || nextStatus == tx.Status {
+		return nil, u.scheduleNext(ctx, tx)
+	}
+	// an expired transaction is only polled for a payment that settled after all
+	if tx.Status == domain.TransactionStatusExpired && nextStatus != domain.TransactionStatusPaid {
+		return nil, u.scheduleNext(ctx, tx)
+	}
+
+	if err := u.transactionUC.HandleNotification(ctx, &domain.UpdateStatusRequest{
+		OrderID:  tx.ProviderOrderID,
+		Status:   string(nextStatus),
+		Livemode: tx.Livemode,
+	}); err != nil {
+		// a webhook moved the transaction while we were polling
+		if errors.Is(err, domain.ErrStatusConflict) {
+			return nil, nil
+		}
+		return nil, errors.Join(err, u.scheduleNext(ctx, tx))
+	}
+
+	return &domain.StatusCorrection{
+		TransactionID: tx.ID,
+		OrderID:       tx.OrderID,
+		Provider:      tx.Provider,
+		From:          tx.Status,
+		To:            nextStatus,
+	}, nil
+}
+
+// scheduleNext backs the transaction off exponentially so long-pending payments are not polled every cycle
+func (u *ReconciliationUC) scheduleNext(ctx context.Context, tx *domain.Transaction) error {
+	delay := pkg.Backoff(tx.ReconcileAttempts, u.config.MinBackoff, u.config.MaxBackoff)
+
+	return u.transactionRepo.ScheduleReconciliation(ctx, tx.ID, tx.ReconcileAttempts+1, time.Now().Add(delay))
+}
+
+// recordFailure pauses a provider with a growing backoff, so an outage is not hammered
+// once per pending transaction
+func (u *ReconciliationUC) recordFailure(provider string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	delay := pkg.Backoff(u.providerFailures[provider], u.config.MinBackoff, u.config.MaxBackoff)
+	u.providerFailures[provider]++
+	u.providerPaused[provider] = time.Now().Add(delay)
+}
+
+func (u *ReconciliationUC) recordSuccess(provider string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	delete(u.providerFailures, provider)
+	delete(u.providerPaused, provider)
+}
+
+func (u *ReconciliationUC) pausedUntil(provider string) (time.Time, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	until := u.providerPaused[provider]
+	return until, time.Now().Before(until)
+}
@@ -0,0 +1,218 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconciliationUsecase_ReconcilePending(t *testing.T) {
+	transactionID := pkg.GenerateUUIDV7()
+
+	pendingTransaction := func() *domain.Transaction {
+		return &domain.Transaction{
+			ID:                transactionID,
+			OrderID:           "ORDER-TEST-123",
+			ProviderOrderID:   transactionID.String(),
+			ExternalID:        "ext-12345",
+			Amount:            100000,
+			Provider:          "midtrans",
+			Status:            domain.TransactionStatusPending,
+			Livemode:          true,
+			ReconcileAttempts: 2,
+		}
+	}
+
+	checkRequest := &domain.CheckStatusRequest{
+		OrderID:    transactionID.String(),
+		ExternalID: "ext-12345",
+	}
+
+	// third attempt backs off 4 minutes with a one minute base
+	matchBackoff := mock.MatchedBy(func(nextAt time.Time) bool {
+		delay := time.Until(nextAt)
+		return delay > 3*time.Minute && delay <= 4*time.Minute
+	})
+
+	tests := []struct {
+		name            string
+		mock            func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway)
+		wantCorrections []*domain.StatusCorrection
+		wantErr         bool
+	}{
+		{
+			name: "Missed Payment Is Corrected",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return([]*domain.Transaction{pendingTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PAID", nil)
+
+				transactionUC.On("HandleNotification", mock.Anything, &domain.UpdateStatusRequest{
+					OrderID:  transactionID.String(),
+					Status:   "PAID",
+					Livemode: true,
+				}).Return(nil)
+			},
+			wantCorrections: []*domain.StatusCorrection{
+				{
+					TransactionID: transactionID,
+					OrderID:       "ORDER-TEST-123",
+					Provider:      "midtrans",
+					From:          domain.TransactionStatusPending,
+					To:            domain.TransactionStatusPaid,
+				},
+			},
+		},
+		{
+			name: "Still Pending Is Backed Off",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return([]*domain.Transaction{pendingTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PENDING", nil)
+
+				repo.On("ScheduleReconciliation", mock.Anything, transactionID, 3, matchBackoff).
+					Return(nil)
+			},
+		},
+		{
+			name: "Expired Transaction Paid Late Is Corrected",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				expired := pendingTransaction()
+				expired.Status = domain.TransactionStatusExpired
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return([]*domain.Transaction{expired}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PAID", nil)
+
+				transactionUC.On("HandleNotification", mock.Anything, &domain.UpdateStatusRequest{
+					OrderID:  transactionID.String(),
+					Status:   "PAID",
+					Livemode: true,
+				}).Return(nil)
+			},
+			wantCorrections: []*domain.StatusCorrection{
+				{
+					TransactionID: transactionID,
+					OrderID:       "ORDER-TEST-123",
+					Provider:      "midtrans",
+					From:          domain.TransactionStatusExpired,
+					To:            domain.TransactionStatusPaid,
+				},
+			},
+		},
+		{
+			name: "Expired Transaction Reported Failed Is Backed Off",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				expired := pendingTransaction()
+				expired.Status = domain.TransactionStatusExpired
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return([]*domain.Transaction{expired}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("FAILED", nil)
+
+				repo.On("ScheduleReconciliation", mock.Anything, transactionID, 3, matchBackoff).
+					Return(nil)
+			},
+		},
+		{
+			name: "Webhook Arrived First",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return([]*domain.Transaction{pendingTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PAID", nil)
+
+				transactionUC.On("HandleNotification", mock.Anything, mock.AnythingOfType("*domain.UpdateStatusRequest")).
+					Return(domain.ErrStatusConflict)
+			},
+		},
+		{
+			name: "Provider Failure Pauses Provider",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				second := pendingTransaction()
+				second.ID = pkg.GenerateUUIDV7()
+
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return([]*domain.Transaction{pendingTransaction(), second}, nil)
+
+				// only the first transaction reaches the provider
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("", domain.ErrGatewayTimeout).Once()
+
+				repo.On("ScheduleReconciliation", mock.Anything, transactionID, 3, matchBackoff).
+					Return(nil)
+
+				// the second one waits for the pause without counting an attempt
+				repo.On("ScheduleReconciliation", mock.Anything, second.ID, 2, mock.MatchedBy(func(nextAt time.Time) bool {
+					delay := time.Until(nextAt)
+					return delay > 0 && delay <= time.Minute
+				})).
+					Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Repository Find",
+			mock: func(repo *mocks.MockTransactionRepository, transactionUC *mocks.MockTransactionUC, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindDueForReconciliation", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 50).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTransactionRepository)
+			mockTransactionUC := new(mocks.MockTransactionUC)
+			mockGateway := new(mocks.MockPaymentGateway)
+
+			tt.mock(mockRepo, mockTransactionUC, mockGateway)
+
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": mockGateway,
+				},
+			}
+
+			reconciliationUC := usecase.NewReconciliationUC(mockRepo, mockTransactionUC, gateways, usecase.ReconciliationConfig{
+				PendingThreshold: time.Minute,
+				ExpiredWindow:    24 * time.Hour,
+				BatchSize:        50,
+				RateLimits:       map[string]float64{"midtrans": 100},
+				MinBackoff:       time.Minute,
+				MaxBackoff:       time.Hour,
+			}, time.Second*2)
+
+			ctx := context.Background()
+			corrections, err := reconciliationUC.ReconcilePending(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCorrections, corrections)
+
+			mockRepo.AssertExpectations(t)
+			mockTransactionUC.AssertExpectations(t)
+			mockGateway.AssertExpectations(t)
+		})
+	}
+}
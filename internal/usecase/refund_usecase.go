@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefundUC struct {
+	refundRepo      domain.RefundRepository
+	transactionRepo domain.TransactionRepository
+	gateways        domain.Gateways
+	timeout         time.Duration
+}
+
+func NewRefundUC(rr domain.RefundRepository, tr domain.TransactionRepository, g domain.Gateways, t time.Duration) domain.RefundUC {
+	return &RefundUC{
+		refundRepo:      rr,
+		transactionRepo: tr,
+		gateways:        g,
+		timeout:         t,
+	}
+}
+
+func (u *RefundUC) Create(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID, req *domain.CreateRefundRequest) (*domain.Refund, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := getTransactionInMode(ctx, u.transactionRepo, merchantID, livemode, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Status != domain.TransactionStatusPaid && tx.Status != domain.TransactionStatusPartiallyRefunded {
+		return nil, domain.ErrTransactionNotRefundable
+	}
+
+	amount := req.Amount
+	if amount < 0 {
+		return nil, domain.ErrInvalidRefundAmount
+	}
+	if amount == 0 {
+		amount = tx.RefundableAmount()
+	}
+	if amount > tx.RefundableAmount() {
+		return nil, domain.ErrRefundAmountExceeded
+	}
+
+	nextStatus := domain.TransactionStatusPartiallyRefunded
+	if tx.RefundedAmount+amount == tx.Amount {
+		nextStatus = domain.TransactionStatusRefunded
+	}
+
+	if !tx.Status.CanTransitionTo(nextStatus) {
+		return nil, &domain.StatusTransitionError{From: tx.Status, To: nextStatus}
+	}
+
+	gateway, exists := u.gateways.For(tx.Provider, tx.Livemode)
+	if !exists {
+		return nil, errors.New("payment provider not supported")
+	}
+
+	refund := &domain.Refund{
+		ID:            pkg.GenerateUUIDV7(),
+		TransactionID: tx.ID,
+		MerchantID:    tx.MerchantID,
+		Amount:        amount,
+		Currency:      tx.Currency,
+		Reason:        req.Reason,
+		Status:        domain.RefundStatusPending,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	createdRefund, err := u.refundRepo.Create(ctx, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	// the balance is reserved before the provider is called, so a concurrent refund fails here
+	// instead of being paid out by the provider and then rejected
+	if err := u.transactionRepo.AddRefundedAmount(ctx, tx.ID, tx.Status, nextStatus, createdRefund.Amount, nil); err != nil {
+		createdRefund.Status = domain.RefundStatusFailed
+		createdRefund.UpdatedAt = time.Now()
+		if updateErr := u.refundRepo.UpdatePending(context.WithoutCancel(ctx), createdRefund, nil); updateErr != nil {
+			return nil, errors.Join(err, updateErr)
+		}
+		return nil, err
+	}
+
+	reserved := *tx
+	reserved.Status = nextStatus
+	reserved.RefundedAmount += createdRefund.Amount
+
+	refundResponse, err := gateway.Refund(ctx, &domain.RefundPaymentRequest{
+		RefundID:   createdRefund.ID.String(),
+		OrderID:    tx.ProviderOrderID,
+		ExternalID: tx.ExternalID,
+		Amount:     createdRefund.Amount,
+		Currency:   createdRefund.Currency,
+		Reason:     createdRefund.Reason,
+	})
+	if err != nil {
+		// after a timeout the provider may still have processed the refund, so it stays PENDING
+		// and keeps its reservation. The provider's callback finds it by the refund ID it was sent.
+		if errors.Is(err, domain.ErrGatewayTimeout) {
+			return nil, err
+		}
+
+		createdRefund.Status = domain.RefundStatusFailed
+		createdRefund.UpdatedAt = time.Now()
+		if releaseErr := u.release(ctx, &reserved, createdRefund, false); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, err
+	}
+
+	createdRefund.Status = refundResponse.Status
+	createdRefund.ExternalID = refundResponse.RefundID
+	createdRefund.UpdatedAt = time.Now()
+
+	if createdRefund.Status == domain.RefundStatusFailed {
+		if err := u.release(ctx, &reserved, createdRefund, true); err != nil {
+			return nil, err
+		}
+		return createdRefund, nil
+	}
+
+	event, err := refundEvent(&reserved, createdRefund)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.refundRepo.UpdatePending(ctx, createdRefund, event); err != nil {
+		return nil, err
+	}
+
+	return createdRefund, nil
+}
+
+// HandleNotification settles a refund the provider accepted as PENDING. Reports on refunds that
+// are already settled are acknowledged and ignored, so provider retries are harmless.
+func (u *RefundUC) HandleNotification(ctx context.Context, req *domain.UpdateRefundStatusRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	refund, err := u.findNotifiedRefund(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	tx, err := getTransactionInMode(ctx, u.transactionRepo, refund.MerchantID, req.Livemode, refund.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	if refund.Status != domain.RefundStatusPending || req.Status == domain.RefundStatusPending {
+		return nil
+	}
+
+	refund.Status = req.Status
+	if req.ExternalID != "" {
+		refund.ExternalID = req.ExternalID
+	}
+	refund.UpdatedAt = time.Now()
+
+	if refund.Status == domain.RefundStatusFailed {
+		err = u.release(ctx, tx, refund, true)
+	} else {
+		var event *domain.OutboxEvent
+		event, err = refundEvent(tx, refund)
+		if err == nil {
+			err = u.refundRepo.UpdatePending(ctx, refund, event)
+		}
+	}
+
+	if errors.Is(err, domain.ErrStatusConflict) {
+		// a concurrent callback settled the refund first
+		return nil
+	}
+	return err
+}
+
+// findNotifiedRefund looks a refund up by the provider's ID, falling back to our own ID for refunds
+// whose request timed out before the provider's ID was stored
+func (u *RefundUC) findNotifiedRefund(ctx context.Context, req *domain.UpdateRefundStatusRequest) (*domain.Refund, error) {
+	refund, err := u.refundRepo.FindByExternalID(ctx, req.ExternalID)
+	if err == nil || !errors.Is(err, domain.ErrRefundNotFound) || req.RefundID == "" {
+		return refund, err
+	}
+
+	id, parseErr := uuid.Parse(req.RefundID)
+	if parseErr != nil {
+		return nil, domain.ErrRefundNotFound
+	}
+
+	refund, err = u.refundRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// a provider ID already stored belongs to another refund at the provider
+	if refund.ExternalID != "" && refund.ExternalID != req.ExternalID {
+		return nil, domain.ErrRefundNotFound
+	}
+
+	return refund, nil
+}
+
+// release marks a refund the provider rejected as FAILED and gives its reserved amount back to
+// tx, which must still hold the reservation. The refund is settled first, so a crash in between
+// leaves the amount reserved rather than released twice. When announce is set merchants are told
+// the refund failed, a refund rejected while its request is still open is reported there instead.
+func (u *RefundUC) release(ctx context.Context, tx *domain.Transaction, refund *domain.Refund, announce bool) error {
+	// a cancelled request must not leave the reservation behind
+	ctx = context.WithoutCancel(ctx)
+
+	if err := u.refundRepo.UpdatePending(ctx, refund, nil); err != nil {
+		return err
+	}
+
+	released := *tx
+	released.RefundedAmount -= refund.Amount
+	released.Status = domain.TransactionStatusPartiallyRefunded
+	if released.RefundedAmount == 0 {
+		released.Status = domain.TransactionStatusPaid
+	}
+
+	var event *domain.OutboxEvent
+	if announce {
+		var err error
+		if event, err = refundEvent(&released, refund); err != nil {
+			return err
+		}
+	}
+
+	return u.transactionRepo.ReleaseRefundedAmount(ctx, tx.ID, refund.Amount, event)
+}
+
+func (u *RefundUC) List(ctx context.Context, merchantID uuid.UUID, livemode bool, transactionID uuid.UUID) ([]*domain.Refund, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := getTransactionInMode(ctx, u.transactionRepo, merchantID, livemode, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := u.refundRepo.FindByTransactionID(ctx, tx.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+// refundEvent builds the outbox event announcing refund with tx as it is after the refund
+func refundEvent(tx *domain.Transaction, refund *domain.Refund) (*domain.OutboxEvent, error) {
+	var eventType string
+	switch refund.Status {
+	case domain.RefundStatusSucceeded:
+		eventType = domain.WebhookEventRefundSucceeded
+	case domain.RefundStatusPending:
+		eventType = domain.WebhookEventRefundPending
+	default:
+		eventType = domain.WebhookEventRefundFailed
+	}
+
+	snapshot := *tx
+	snapshot.UpdatedAt = time.Now()
+
+	return newOutboxEvent(eventType, &snapshot, refund)
+}
@@ -0,0 +1,415 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type refundMocks struct {
+	refundRepo      *mocks.MockRefundRepository
+	transactionRepo *mocks.MockTransactionRepository
+	gateway         *mocks.MockPaymentGateway
+}
+
+func TestRefundUsecase_Create(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	otherMerchantID := pkg.GenerateUUIDV7()
+	transactionID := pkg.GenerateUUIDV7()
+
+	newTransaction := func(status domain.TransactionStatus, refunded int64) *domain.Transaction {
+		return &domain.Transaction{
+			ID:              transactionID,
+			MerchantID:      merchantID,
+			OrderID:         "ORDER-TEST-123",
+			ProviderOrderID: transactionID.String(),
+			ExternalID:      "ext-12345",
+			Amount:          100000,
+			RefundedAmount:  refunded,
+			Currency:        "IDR",
+			Provider:        "midtrans",
+			Status:          status,
+			Livemode:        true,
+		}
+	}
+
+	returnRefund := func(ctx context.Context, r *domain.Refund) (*domain.Refund, error) {
+		return r, nil
+	}
+
+	tests := []struct {
+		name       string
+		merchantID uuid.UUID
+		request    *domain.CreateRefundRequest
+		mock       func(m refundMocks)
+		wantAmount int64
+		wantStatus domain.RefundStatus
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name:    "Success Full Refund",
+			request: &domain.CreateRefundRequest{},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPaid, 0), nil)
+
+				m.refundRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Refund")).
+					Return(returnRefund)
+
+				m.gateway.On("Refund", mock.Anything, mock.MatchedBy(func(req *domain.RefundPaymentRequest) bool {
+					return req.OrderID == transactionID.String() && req.ExternalID == "ext-12345" && req.Amount == 100000
+				})).Return(&domain.RefundPaymentResponse{RefundID: "rf-123", Status: domain.RefundStatusSucceeded}, nil)
+
+				m.transactionRepo.On("AddRefundedAmount", mock.Anything, transactionID, domain.TransactionStatusPaid, domain.TransactionStatusRefunded, int64(100000), (*domain.OutboxEvent)(nil)).
+					Return(nil)
+
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.AnythingOfType("*domain.Refund"), matchRefundEvent(domain.WebhookEventRefundSucceeded)).
+					Return(nil)
+			},
+			wantAmount: 100000,
+			wantStatus: domain.RefundStatusSucceeded,
+		},
+		{
+			name:    "Success Partial Refund",
+			request: &domain.CreateRefundRequest{Amount: 30000, Reason: "damaged item"},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPartiallyRefunded, 20000), nil)
+
+				m.refundRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Refund")).
+					Return(returnRefund)
+
+				m.gateway.On("Refund", mock.Anything, mock.AnythingOfType("*domain.RefundPaymentRequest")).
+					Return(&domain.RefundPaymentResponse{RefundID: "rf-123", Status: domain.RefundStatusPending}, nil)
+
+				m.transactionRepo.On("AddRefundedAmount", mock.Anything, transactionID, domain.TransactionStatusPartiallyRefunded, domain.TransactionStatusPartiallyRefunded, int64(30000), (*domain.OutboxEvent)(nil)).
+					Return(nil)
+
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.AnythingOfType("*domain.Refund"), matchRefundEvent(domain.WebhookEventRefundPending)).
+					Return(nil)
+			},
+			wantAmount: 30000,
+			wantStatus: domain.RefundStatusPending,
+		},
+		{
+			name:    "Failed Amount Exceeds Refundable Balance",
+			request: &domain.CreateRefundRequest{Amount: 90000},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPartiallyRefunded, 20000), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrRefundAmountExceeded,
+		},
+		{
+			name:    "Failed Transaction Not Paid",
+			request: &domain.CreateRefundRequest{},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPending, 0), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrTransactionNotRefundable,
+		},
+		{
+			name:       "Failed Transaction Of Another Merchant",
+			merchantID: otherMerchantID,
+			request:    &domain.CreateRefundRequest{},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, otherMerchantID, transactionID).
+					Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+			errIs:   domain.ErrTransactionNotFound,
+		},
+		{
+			name:    "Failed Concurrent Refund Reserved The Balance First",
+			request: &domain.CreateRefundRequest{},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPaid, 0), nil)
+
+				m.refundRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Refund")).
+					Return(returnRefund)
+
+				m.transactionRepo.On("AddRefundedAmount", mock.Anything, transactionID, domain.TransactionStatusPaid, domain.TransactionStatusRefunded, int64(100000), (*domain.OutboxEvent)(nil)).
+					Return(domain.ErrStatusConflict)
+
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+					return r.Status == domain.RefundStatusFailed
+				}), (*domain.OutboxEvent)(nil)).Return(nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrStatusConflict,
+		},
+		{
+			name:    "Failed Payment Gateway Releases The Reservation",
+			request: &domain.CreateRefundRequest{Amount: 30000},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPaid, 0), nil)
+
+				m.refundRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Refund")).
+					Return(returnRefund)
+
+				m.transactionRepo.On("AddRefundedAmount", mock.Anything, transactionID, domain.TransactionStatusPaid, domain.TransactionStatusPartiallyRefunded, int64(30000), (*domain.OutboxEvent)(nil)).
+					Return(nil)
+
+				m.gateway.On("Refund", mock.Anything, mock.AnythingOfType("*domain.RefundPaymentRequest")).
+					Return(nil, errors.New("gateway error"))
+
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+					return r.Status == domain.RefundStatusFailed
+				}), (*domain.OutboxEvent)(nil)).Return(nil)
+
+				m.transactionRepo.On("ReleaseRefundedAmount", mock.Anything, transactionID, int64(30000), (*domain.OutboxEvent)(nil)).
+					Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Failed Payment Gateway Timeout Leaves Refund Pending",
+			request: &domain.CreateRefundRequest{},
+			mock: func(m refundMocks) {
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPaid, 0), nil)
+
+				m.refundRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Refund")).
+					Return(returnRefund)
+
+				m.transactionRepo.On("AddRefundedAmount", mock.Anything, transactionID, domain.TransactionStatusPaid, domain.TransactionStatusRefunded, int64(100000), (*domain.OutboxEvent)(nil)).
+					Return(nil)
+
+				m.gateway.On("Refund", mock.Anything, mock.AnythingOfType("*domain.RefundPaymentRequest")).
+					Return(nil, domain.ErrGatewayTimeout)
+			},
+			wantErr: true,
+			errIs:   domain.ErrGatewayTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := refundMocks{
+				refundRepo:      new(mocks.MockRefundRepository),
+				transactionRepo: new(mocks.MockTransactionRepository),
+				gateway:         new(mocks.MockPaymentGateway),
+			}
+
+			tt.mock(m)
+
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": m.gateway,
+				},
+			}
+
+			refundUC := usecase.NewRefundUC(m.refundRepo, m.transactionRepo, gateways, time.Second*2)
+
+			callerID := merchantID
+			if tt.merchantID != uuid.Nil {
+				callerID = tt.merchantID
+			}
+
+			ctx := context.Background()
+			res, err := refundUC.Create(ctx, callerID, true, transactionID, tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+				assert.Equal(t, tt.wantAmount, res.Amount)
+				assert.Equal(t, tt.wantStatus, res.Status)
+				assert.Equal(t, "rf-123", res.ExternalID)
+			}
+
+			m.refundRepo.AssertExpectations(t)
+			m.transactionRepo.AssertExpectations(t)
+			m.gateway.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_HandleNotification(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	transactionID := pkg.GenerateUUIDV7()
+	refundID := pkg.GenerateUUIDV7()
+
+	newRefund := func(status domain.RefundStatus) *domain.Refund {
+		return &domain.Refund{
+			ID:            refundID,
+			TransactionID: transactionID,
+			MerchantID:    merchantID,
+			Amount:        30000,
+			Currency:      "IDR",
+			Status:        status,
+			ExternalID:    "rfd-123",
+		}
+	}
+
+	// the transaction still holds the reservation of the pending refund
+	reserved := &domain.Transaction{
+		ID:             transactionID,
+		MerchantID:     merchantID,
+		Amount:         100000,
+		RefundedAmount: 30000,
+		Currency:       "IDR",
+		Provider:       "xendit",
+		Status:         domain.TransactionStatusPartiallyRefunded,
+		Livemode:       true,
+	}
+
+	tests := []struct {
+		name    string
+		request *domain.UpdateRefundStatusRequest
+		mock    func(m refundMocks)
+		wantErr error
+	}{
+		{
+			name:    "Success Refund Succeeded",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-123", Status: domain.RefundStatusSucceeded, Livemode: true},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-123").Return(newRefund(domain.RefundStatusPending), nil)
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(reserved, nil)
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+					return r.Status == domain.RefundStatusSucceeded
+				}), matchRefundEvent(domain.WebhookEventRefundSucceeded)).Return(nil)
+			},
+		},
+		{
+			name:    "Success Refund Failed Releases The Reservation",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-123", Status: domain.RefundStatusFailed, Livemode: true},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-123").Return(newRefund(domain.RefundStatusPending), nil)
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(reserved, nil)
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+					return r.Status == domain.RefundStatusFailed
+				}), (*domain.OutboxEvent)(nil)).Return(nil)
+				m.transactionRepo.On("ReleaseRefundedAmount", mock.Anything, transactionID, int64(30000), mock.MatchedBy(func(event *domain.OutboxEvent) bool {
+					var payload domain.WebhookPayload
+					if err := json.Unmarshal(event.Payload, &payload); err != nil {
+						return false
+					}
+					return event.EventType == domain.WebhookEventRefundFailed &&
+						payload.Data.Transaction.RefundedAmount == 0 &&
+						payload.Status == string(domain.TransactionStatusPaid)
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "Success Timed Out Refund Is Found By Our ID",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-123", RefundID: refundID.String(), Status: domain.RefundStatusSucceeded, Livemode: true},
+			mock: func(m refundMocks) {
+				timedOut := newRefund(domain.RefundStatusPending)
+				timedOut.ExternalID = ""
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-123").Return(nil, domain.ErrRefundNotFound)
+				m.refundRepo.On("FindByID", mock.Anything, refundID).Return(timedOut, nil)
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(reserved, nil)
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+					return r.Status == domain.RefundStatusSucceeded && r.ExternalID == "rfd-123"
+				}), matchRefundEvent(domain.WebhookEventRefundSucceeded)).Return(nil)
+			},
+		},
+		{
+			name:    "Failed Our ID Belongs To Another Provider Refund",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-456", RefundID: refundID.String(), Status: domain.RefundStatusSucceeded, Livemode: true},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-456").Return(nil, domain.ErrRefundNotFound)
+				m.refundRepo.On("FindByID", mock.Anything, refundID).Return(newRefund(domain.RefundStatusPending), nil)
+			},
+			wantErr: domain.ErrRefundNotFound,
+		},
+		{
+			name:    "Success Already Settled Refund Is Ignored",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-123", Status: domain.RefundStatusSucceeded, Livemode: true},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-123").Return(newRefund(domain.RefundStatusSucceeded), nil)
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(reserved, nil)
+			},
+		},
+		{
+			name:    "Success Concurrent Callback Settled It First",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-123", Status: domain.RefundStatusSucceeded, Livemode: true},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-123").Return(newRefund(domain.RefundStatusPending), nil)
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(reserved, nil)
+				m.refundRepo.On("UpdatePending", mock.Anything, mock.AnythingOfType("*domain.Refund"), mock.Anything).Return(domain.ErrStatusConflict)
+			},
+		},
+		{
+			name:    "Failed Test Callback For Live Refund",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-123", Status: domain.RefundStatusSucceeded, Livemode: false},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-123").Return(newRefund(domain.RefundStatusPending), nil)
+				m.transactionRepo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(reserved, nil)
+			},
+			wantErr: domain.ErrTransactionNotFound,
+		},
+		{
+			name:    "Failed Unknown Refund",
+			request: &domain.UpdateRefundStatusRequest{ExternalID: "rfd-404", Status: domain.RefundStatusSucceeded, Livemode: true},
+			mock: func(m refundMocks) {
+				m.refundRepo.On("FindByExternalID", mock.Anything, "rfd-404").Return(nil, domain.ErrRefundNotFound)
+			},
+			wantErr: domain.ErrRefundNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := refundMocks{
+				refundRepo:      new(mocks.MockRefundRepository),
+				transactionRepo: new(mocks.MockTransactionRepository),
+			}
+
+			tt.mock(m)
+
+			refundUC := usecase.NewRefundUC(m.refundRepo, m.transactionRepo, domain.Gateways{}, time.Second*2)
+
+			err := refundUC.HandleNotification(context.Background(), tt.request)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			m.refundRepo.AssertExpectations(t)
+			m.transactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// matchRefundEvent matches the outbox event that announces a refund of 100000 or 30000
+func matchRefundEvent(eventType string) interface{} {
+	return mock.MatchedBy(func(event *domain.OutboxEvent) bool {
+		var payload domain.WebhookPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return false
+		}
+		return event.EventType == eventType &&
+			payload.Event == eventType &&
+			payload.RefundID != "" &&
+			(payload.RefundAmount == 100000 || payload.RefundAmount == 30000) &&
+			payload.Data != nil &&
+			payload.Data.Refund.ID == payload.RefundID &&
+			payload.Data.Transaction.RefundedAmount >= payload.RefundAmount
+	})
+}
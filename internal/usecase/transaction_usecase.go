@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"go-payment-aggregator/internal/domain"
 	"go-payment-aggregator/internal/pkg"
 	"time"
@@ -12,11 +14,11 @@ import (
 
 type TransactionUC struct {
 	transactionRepo domain.TransactionRepository
-	gateways        map[string]domain.PaymentGateway
+	gateways        domain.Gateways
 	timeout         time.Duration
 }
 
-func NewTransactionUC(r domain.TransactionRepository, g map[string]domain.PaymentGateway, t time.Duration) domain.TransactionUC {
+func NewTransactionUC(r domain.TransactionRepository, g domain.Gateways, t time.Duration) domain.TransactionUC {
 	return &TransactionUC{
 		transactionRepo: r,
 		gateways:        g,
@@ -24,26 +26,33 @@ func NewTransactionUC(r domain.TransactionRepository, g map[string]domain.Paymen
 	}
 }
 
-func (u *TransactionUC) Create(ctx context.Context, merchantID uuid.UUID, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+func (u *TransactionUC) Create(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
+	gateway, exists := u.gateways.For(req.Provider, livemode)
+	if !exists {
+		return nil, errors.New("payment provider not supported")
+	}
+
 	id := pkg.GenerateUUIDV7()
 
-	expiryDuration := 2 * time.Minute
+	expiryDuration := domain.TransactionExpiry
 
 	transaction := &domain.Transaction{
-		ID:            id,
-		MerchantID:    merchantID,
-		OrderID:       req.OrderID,
-		Provider:      req.Provider,
-		Amount:        req.Amount,
-		Currency:      req.Currency,
-		Status:        domain.TransactionStatusPending,
-		PaymentMethod: req.PaymentMethod,
-		ExpiredAt:     time.Now().Add(expiryDuration),
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		ID:              id,
+		MerchantID:      merchantID,
+		OrderID:         req.OrderID,
+		ProviderOrderID: id.String(),
+		Provider:        req.Provider,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Status:          domain.TransactionStatusPending,
+		PaymentMethod:   req.PaymentMethod,
+		Livemode:        livemode,
+		ExpiredAt:       time.Now().Add(expiryDuration),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
 
 	createdTransaction, err := u.transactionRepo.Create(ctx, transaction)
@@ -52,7 +61,7 @@ func (u *TransactionUC) Create(ctx context.Context, merchantID uuid.UUID, req *d
 	}
 
 	paymentRequest := &domain.CreatePaymentRequest{
-		OrderID:       createdTransaction.OrderID,
+		OrderID:       createdTransaction.ProviderOrderID,
 		Amount:        createdTransaction.Amount,
 		PaymentMethod: createdTransaction.PaymentMethod,
 		Currency:      createdTransaction.Currency,
@@ -61,13 +70,20 @@ func (u *TransactionUC) Create(ctx context.Context, merchantID uuid.UUID, req *d
 		Items:         req.Items,
 	}
 
-	gateway, exists := u.gateways[req.Provider]
-	if !exists {
-		return nil, errors.New("payment provider not supported")
-	}
-
-	paymentResponse, err := gateway.CreatePayment(paymentRequest)
+	paymentResponse, err := gateway.CreatePayment(ctx, paymentRequest)
 	if err != nil {
+		// the provider may have created the payment before the call gave up, so the row keeps the
+		// order ID and a retry gets a duplicate order instead of a second invoice. Without an
+		// external reference the expiry sweeper closes it.
+		if errors.Is(err, domain.ErrGatewayTimeout) || errors.Is(err, domain.ErrGatewayCanceled) {
+			return nil, err
+		}
+
+		// the customer never got a payment link, so the order ID is freed for a retry. The
+		// context may be what failed, the cleanup must still run.
+		if deleteErr := u.transactionRepo.DeleteUnstarted(context.WithoutCancel(ctx), createdTransaction.ID); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
 		return nil, err
 	}
 
@@ -85,29 +101,360 @@ func (u *TransactionUC) Create(ctx context.Context, merchantID uuid.UUID, req *d
 	return updatedTransaction, nil
 }
 
-func (u *TransactionUC) Get(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+func (u *TransactionUC) Get(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return getTransactionInMode(ctx, u.transactionRepo, merchantID, livemode, id)
+}
+
+func (u *TransactionUC) GetByOrderID(ctx context.Context, merchantID uuid.UUID, livemode bool, orderID string) (*domain.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.transactionRepo.FindByMerchantAndOrderID(ctx, merchantID, livemode, orderID)
+}
+
+func (u *TransactionUC) GetByExternalID(ctx context.Context, merchantID uuid.UUID, livemode bool, externalID string) (*domain.Transaction, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
-	getTransaction, err := u.transactionRepo.Get(ctx, id)
+	return u.transactionRepo.FindByMerchantAndExternalID(ctx, merchantID, livemode, externalID)
+}
+
+// getTransactionInMode loads a merchant's transaction, hiding it from keys of the other mode
+func getTransactionInMode(ctx context.Context, r domain.TransactionRepository, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error) {
+	tx, err := r.GetByMerchant(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Livemode != livemode {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return tx, nil
+}
+
+func (u *TransactionUC) List(ctx context.Context, merchantID uuid.UUID, livemode bool, req *domain.ListTransactionsRequest) (*domain.TransactionPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	filter, err := newTransactionFilter(merchantID, req)
+	if err != nil {
+		return nil, err
+	}
+	filter.Livemode = livemode
+
+	pageSize := filter.Limit
+	// one extra row tells us whether another page exists
+	filter.Limit = pageSize + 1
+
+	transactions, err := u.transactionRepo.List(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return getTransaction, nil
+	page := &domain.TransactionPage{
+		Transactions: transactions,
+	}
+
+	if len(transactions) > pageSize {
+		page.Transactions = transactions[:pageSize]
+		last := page.Transactions[pageSize-1]
+		page.NextCursor = pkg.EncodeCursor(domain.TransactionCursor{
+			SortBy:     filter.SortBy,
+			Descending: filter.Descending,
+			ID:         last.ID,
+			Amount:     last.Amount,
+		})
+	}
+
+	return page, nil
+}
+
+func newTransactionFilter(merchantID uuid.UUID, req *domain.ListTransactionsRequest) (*domain.TransactionFilter, error) {
+	filter := &domain.TransactionFilter{
+		MerchantID:    merchantID,
+		Status:        domain.TransactionStatus(req.Status),
+		Provider:      req.Provider,
+		PaymentMethod: req.PaymentMethod,
+		Currency:      req.Currency,
+		MinAmount:     req.MinAmount,
+		MaxAmount:     req.MaxAmount,
+		CreatedFrom:   req.CreatedFrom,
+		CreatedTo:     req.CreatedTo,
+		SortBy:        req.SortBy,
+		Descending:    true,
+		Limit:         req.Limit,
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidListFilter, req.Status)
+	}
+	if req.MinAmount < 0 || req.MaxAmount < 0 || (req.MaxAmount > 0 && req.MinAmount > req.MaxAmount) {
+		return nil, fmt.Errorf("%w: invalid amount range", domain.ErrInvalidListFilter)
+	}
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && !req.CreatedFrom.Before(req.CreatedTo) {
+		return nil, fmt.Errorf("%w: created_from must be before created_to", domain.ErrInvalidListFilter)
+	}
+
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = domain.TransactionSortCreatedAt
+	case domain.TransactionSortCreatedAt, domain.TransactionSortAmount:
+	default:
+		return nil, fmt.Errorf("%w: sort_by must be created_at or amount", domain.ErrInvalidListFilter)
+	}
+
+	switch req.SortOrder {
+	case "", "desc":
+	case "asc":
+		filter.Descending = false
+	default:
+		return nil, fmt.Errorf("%w: sort_order must be asc or desc", domain.ErrInvalidListFilter)
+	}
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = domain.DefaultTransactionPageSize
+	case filter.Limit > domain.MaxTransactionPageSize:
+		filter.Limit = domain.MaxTransactionPageSize
+	}
+
+	if req.Cursor != "" {
+		var cursor domain.TransactionCursor
+		if err := pkg.DecodeCursor(req.Cursor, &cursor); err != nil || cursor.ID == uuid.Nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		// a cursor only makes sense for the ordering it was issued for
+		if cursor.SortBy != filter.SortBy || cursor.Descending != filter.Descending {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter.After = &cursor
+	}
+
+	return filter, nil
 }
 
 func (u *TransactionUC) HandleNotification(ctx context.Context, req *domain.UpdateStatusRequest) error {
-	tx, err := u.transactionRepo.FindByOrderID(ctx, req.OrderID)
+	tx, err := u.transactionRepo.FindByProviderOrderID(ctx, req.OrderID)
 	if err != nil {
 		return err
 	}
 
-	tx.Status = domain.TransactionStatus(req.Status)
+	// sandbox credentials must never move a live transaction, nor the other way around
+	if tx.Livemode != req.Livemode {
+		return domain.ErrTransactionNotFound
+	}
 
-	_, err = u.transactionRepo.Update(ctx, tx)
+	return u.applyStatus(ctx, tx, domain.TransactionStatus(req.Status))
+}
+
+// applyStatus moves tx through the state machine and queues the merchant callback for the change
+func (u *TransactionUC) applyStatus(ctx context.Context, tx *domain.Transaction, nextStatus domain.TransactionStatus) error {
+	// providers resend notifications, a repeated status is not a transition
+	if tx.Status == nextStatus {
+		return nil
+	}
+
+	// cancelling at the provider triggers its own cancel/expire notification, which only echoes ours
+	if tx.Status == domain.TransactionStatusCancelled &&
+		(nextStatus == domain.TransactionStatusFailed || nextStatus == domain.TransactionStatusExpired) {
+		return nil
+	}
+
+	if !tx.Status.CanTransitionTo(nextStatus) {
+		return &domain.StatusTransitionError{From: tx.Status, To: nextStatus}
+	}
+
+	event, err := statusChangedEvent(tx, nextStatus)
 	if err != nil {
 		return err
 	}
+
+	if err := u.transactionRepo.UpdateStatus(ctx, tx.ID, tx.Status, nextStatus, event); err != nil {
+		return err
+	}
+
+	tx.Status = nextStatus
+
 	return nil
 }
+
+// statusChangedEvent builds the outbox event announcing tx moving to status, or nil when the
+// change is not announced to the merchant
+func statusChangedEvent(tx *domain.Transaction, status domain.TransactionStatus) (*domain.OutboxEvent, error) {
+	eventType := domain.TransactionStatusEvent(status)
+	if eventType == "" {
+		return nil, nil
+	}
+
+	snapshot := *tx
+	snapshot.Status = status
+	snapshot.UpdatedAt = time.Now()
+
+	return newOutboxEvent(eventType, &snapshot, nil)
+}
+
+// newOutboxEvent builds the outbox event announcing eventType with tx, and refund when it is set,
+// as the change left them
+func newOutboxEvent(eventType string, tx *domain.Transaction, refund *domain.Refund) (*domain.OutboxEvent, error) {
+	id := pkg.GenerateUUIDV7()
+	now := time.Now()
+
+	webhookPayload := &domain.WebhookPayload{
+		Event:          eventType,
+		EventID:        "evt_" + id.String(),
+		EventCreatedAt: now,
+		Data: &domain.EventData{
+			Transaction: domain.NewTransactionEventData(tx),
+		},
+		TransactionID: tx.ID.String(),
+		MerchantID:    tx.MerchantID.String(),
+		OrderID:       tx.OrderID,
+		Status:        string(tx.Status),
+		Amount:        float64(tx.Amount),
+		Provider:      tx.Provider,
+		Livemode:      &tx.Livemode,
+	}
+	if refund != nil {
+		webhookPayload.Data.Refund = domain.NewRefundEventData(refund)
+		webhookPayload.RefundID = refund.ID.String()
+		webhookPayload.RefundAmount = refund.Amount
+	}
+
+	payload, err := json.Marshal(webhookPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OutboxEvent{
+		ID:          id,
+		AggregateID: tx.ID,
+		EventType:   eventType,
+		Payload:     payload,
+		CreatedAt:   now,
+	}, nil
+}
+
+func (u *TransactionUC) Cancel(ctx context.Context, merchantID uuid.UUID, livemode bool, id uuid.UUID) (*domain.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := getTransactionInMode(ctx, u.transactionRepo, merchantID, livemode, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Status == domain.TransactionStatusCancelled {
+		return tx, nil
+	}
+
+	if !tx.Status.CanTransitionTo(domain.TransactionStatusCancelled) {
+		return nil, domain.ErrTransactionNotCancellable
+	}
+
+	gateway, exists := u.gateways.For(tx.Provider, tx.Livemode)
+	if !exists {
+		return nil, errors.New("payment provider not supported")
+	}
+
+	event, err := statusChangedEvent(tx, domain.TransactionStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gateway.Cancel(ctx, &domain.CancelPaymentRequest{
+		OrderID:    tx.ProviderOrderID,
+		ExternalID: tx.ExternalID,
+	}); err != nil {
+		return nil, err
+	}
+
+	// a payment notification may have landed while the provider was cancelling
+	if err := u.transactionRepo.UpdateStatus(ctx, tx.ID, tx.Status, domain.TransactionStatusCancelled, event); err != nil {
+		return nil, err
+	}
+
+	tx.Status = domain.TransactionStatusCancelled
+
+	return tx, nil
+}
+
+// ExpirePending resolves up to limit PENDING transactions whose expiry has passed, asking the
+// provider first so a payment that was made but never notified is recorded as PAID
+func (u *TransactionUC) ExpirePending(ctx context.Context, limit int) (int, error) {
+	transactions, err := u.transactionRepo.FindExpiredPending(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	resolved := 0
+	var errs []error
+	for _, tx := range transactions {
+		if err := u.expire(ctx, tx); err != nil {
+			errs = append(errs, fmt.Errorf("transaction %s: %w", tx.ID, err))
+			continue
+		}
+		resolved++
+	}
+
+	return resolved, errors.Join(errs...)
+}
+
+func (u *TransactionUC) expire(ctx context.Context, tx *domain.Transaction) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	nextStatus, err := u.expiredStatus(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = u.applyStatus(ctx, tx, nextStatus)
+	// a webhook resolved the transaction while we were asking the provider
+	if errors.Is(err, domain.ErrStatusConflict) {
+		return nil
+	}
+	return err
+}
+
+// expiredStatus returns the status an overdue PENDING transaction resolves to. Payments the
+// provider still holds open are closed there first, since providers may keep them open longer
+// than ExpiredAt: Stripe sessions always live at least 30 minutes.
+func (u *TransactionUC) expiredStatus(ctx context.Context, tx *domain.Transaction) (domain.TransactionStatus, error) {
+	// without an external reference the payment was never created, or never handed to the customer
+	if tx.ExternalID == "" {
+		return domain.TransactionStatusExpired, nil
+	}
+
+	gateway, exists := u.gateways.For(tx.Provider, tx.Livemode)
+	if !exists {
+		return "", errors.New("payment provider not supported")
+	}
+
+	providerStatus, err := gateway.CheckStatus(ctx, &domain.CheckStatusRequest{
+		OrderID:    tx.ProviderOrderID,
+		ExternalID: tx.ExternalID,
+	})
+	if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
+		return "", err
+	}
+
+	switch domain.TransactionStatus(providerStatus) {
+	case domain.TransactionStatusPaid, domain.TransactionStatusFailed:
+		return domain.TransactionStatus(providerStatus), nil
+	case domain.TransactionStatusPending:
+		// closing fails when the customer pays in the meantime, the next sweep then sees PAID
+		if err := gateway.Cancel(ctx, &domain.CancelPaymentRequest{
+			OrderID:    tx.ProviderOrderID,
+			ExternalID: tx.ExternalID,
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	// EXPIRED -> PAID stays possible if a late payment settles anyway
+	return domain.TransactionStatusExpired, nil
+}
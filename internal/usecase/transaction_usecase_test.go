@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -11,12 +12,14 @@ import (
 	"go-payment-aggregator/internal/pkg"
 	"go-payment-aggregator/internal/usecase"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 	merchantID := pkg.GenerateUUIDV7()
+	transactionID := pkg.GenerateUUIDV7()
 
 	reqUC := &domain.CreateTransactionRequest{
 		OrderID:       "ORDER-TEST-123",
@@ -34,7 +37,7 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 	}
 
 	reqGateway := &domain.CreatePaymentRequest{
-		OrderID:       "ORDER-TEST-123",
+		OrderID:       transactionID.String(),
 		Amount:        100000,
 		PaymentMethod: "credit_card",
 		ExpiryMinutes: 2,
@@ -43,12 +46,14 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 	}
 
 	mockTransaction := &domain.Transaction{
-		OrderID:       reqUC.OrderID,
-		Amount:        reqUC.Amount,
-		PaymentMethod: reqUC.PaymentMethod,
-		Provider:      "midtrans",
-		Currency:      reqUC.Currency,
-		Status:        domain.TransactionStatusPending,
+		ID:              transactionID,
+		OrderID:         reqUC.OrderID,
+		ProviderOrderID: transactionID.String(),
+		Amount:          reqUC.Amount,
+		PaymentMethod:   reqUC.PaymentMethod,
+		Provider:        "midtrans",
+		Currency:        reqUC.Currency,
+		Status:          domain.TransactionStatusPending,
 	}
 
 	paymentResponse := &domain.PaymentResponse{
@@ -69,14 +74,16 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 		mock    func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway)
 		request *domain.CreateTransactionRequest
 		wantErr bool
+		errIs   error
 	}{
 		{
 			name: "Success Create Transaction",
 			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
-				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
-					Return(mockTransaction, nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+					return tx.OrderID == reqUC.OrderID && tx.ProviderOrderID == tx.ID.String()
+				})).Return(mockTransaction, nil)
 
-				gateway.On("CreatePayment", matchGatewayRequest).
+				gateway.On("CreatePayment", mock.Anything, matchGatewayRequest).
 					Return(paymentResponse, nil)
 
 				repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
@@ -92,16 +99,60 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Failed Duplicate Order ID",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
+					Return(nil, domain.ErrDuplicateOrderID)
+			},
+			wantErr: true,
+			errIs:   domain.ErrDuplicateOrderID,
+		},
 		{
 			name: "Failed Payment Gateway",
 			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
 				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
 					Return(mockTransaction, nil)
 
-				gateway.On("CreatePayment", matchGatewayRequest).
-					Return(nil, errors.New("gateway timeout"))
+				gateway.On("CreatePayment", mock.Anything, matchGatewayRequest).
+					Return(nil, errors.New("gateway error"))
+
+				// frees the order ID so an idempotent retry can create the transaction again
+				repo.On("DeleteUnstarted", mock.Anything, transactionID).
+					Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Payment Gateway And Cleanup",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
+					Return(mockTransaction, nil)
+
+				gateway.On("CreatePayment", mock.Anything, matchGatewayRequest).
+					Return(nil, errors.New("gateway error"))
+
+				repo.On("DeleteUnstarted", mock.Anything, transactionID).
+					Return(errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Payment Gateway Timeout",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
+					Return(mockTransaction, nil)
+
+				gateway.On("CreatePayment", mock.MatchedBy(func(ctx context.Context) bool {
+					_, hasDeadline := ctx.Deadline()
+					return hasDeadline
+				}), matchGatewayRequest).
+					Return(nil, domain.ErrGatewayTimeout)
+
+				// the provider may hold an invoice for the order, so the row is kept and not deleted
 			},
 			wantErr: true,
+			errIs:   domain.ErrGatewayTimeout,
 		},
 		{
 			name: "Failed Repository Update",
@@ -109,7 +160,7 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
 					Return(mockTransaction, nil)
 
-				gateway.On("CreatePayment", matchGatewayRequest).
+				gateway.On("CreatePayment", mock.Anything, matchGatewayRequest).
 					Return(paymentResponse, nil)
 
 				repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
@@ -119,10 +170,7 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 		},
 		{
 			name: "Failed  Unsupported Provider",
-			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
-				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).
-					Return(mockTransaction, nil)
-			},
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {},
 			request: &domain.CreateTransactionRequest{
 				OrderID:       "ORDER-TEST-456",
 				Amount:        50000,
@@ -148,8 +196,10 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 
 			tt.mock(mockRepo, mockGateway)
 
-			gateways := map[string]domain.PaymentGateway{
-				"midtrans": mockGateway,
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": mockGateway,
+				},
 			}
 
 			transactionUC := usecase.NewTransactionUC(mockRepo, gateways, time.Second*2)
@@ -161,10 +211,13 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 				request = tt.request
 			}
 
-			res, err := transactionUC.Create(ctx, merchantID, request)
+			res, err := transactionUC.Create(ctx, merchantID, true, request)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, res)
@@ -180,32 +233,54 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 }
 
 func TestTransactionUsecase_GetTransaction(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	otherMerchantID := pkg.GenerateUUIDV7()
 	transactionID := pkg.GenerateUUIDV7()
 	mockTransaction := &domain.Transaction{
 		ID:            transactionID,
+		MerchantID:    merchantID,
 		OrderID:       "ORDER-TEST-123",
 		Amount:        100000,
 		PaymentMethod: "credit_card",
 		Currency:      "IDR",
 		Status:        domain.TransactionStatusPending,
+		Livemode:      true,
 	}
 
 	tests := []struct {
-		name    string
-		mock    func(repo *mocks.MockTransactionRepository)
-		wantErr bool
+		name       string
+		merchantID uuid.UUID
+		testmode   bool
+		mock       func(repo *mocks.MockTransactionRepository)
+		wantErr    bool
 	}{
 		{
 			name: "Success Get Transaction",
 			mock: func(repo *mocks.MockTransactionRepository) {
-				repo.On("Get", mock.Anything, transactionID).Return(mockTransaction, nil)
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(mockTransaction, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "Failed Get Transaction - Not Found",
 			mock: func(repo *mocks.MockTransactionRepository) {
-				repo.On("Get", mock.Anything, transactionID).Return(nil, errors.New("Transaction not found"))
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Failed Get Transaction - Another Merchant",
+			merchantID: otherMerchantID,
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("GetByMerchant", mock.Anything, otherMerchantID, transactionID).Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "Failed Get Transaction - Live Transaction With Test Key",
+			testmode: true,
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).Return(mockTransaction, nil)
 			},
 			wantErr: true,
 		},
@@ -218,14 +293,21 @@ func TestTransactionUsecase_GetTransaction(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			gateways := map[string]domain.PaymentGateway{
-				"midtrans": mockGateway,
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": mockGateway,
+				},
 			}
 
 			transactionUC := usecase.NewTransactionUC(mockRepo, gateways, time.Second*2)
 
+			callerID := merchantID
+			if tt.merchantID != uuid.Nil {
+				callerID = tt.merchantID
+			}
+
 			ctx := context.Background()
-			res, err := transactionUC.Get(ctx, transactionID)
+			res, err := transactionUC.Get(ctx, callerID, !tt.testmode, transactionID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -241,53 +323,260 @@ func TestTransactionUsecase_GetTransaction(t *testing.T) {
 	}
 }
 
-func TestTransactionUsecase_HandleNotification(t *testing.T) {
-	orderID := "ORDER-TEST-123"
+func TestTransactionUsecase_GetByOrderID(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	otherMerchantID := pkg.GenerateUUIDV7()
 	mockTransaction := &domain.Transaction{
-		ID:      pkg.GenerateUUIDV7(),
-		OrderID: orderID,
-		Amount:  100000,
-		Status:  domain.TransactionStatusPending,
+		ID:         pkg.GenerateUUIDV7(),
+		MerchantID: merchantID,
+		OrderID:    "ORDER-TEST-123",
+		Amount:     100000,
+		Currency:   "IDR",
+		Status:     domain.TransactionStatusPending,
+	}
+
+	tests := []struct {
+		name       string
+		merchantID uuid.UUID
+		mock       func(repo *mocks.MockTransactionRepository)
+		wantErr    bool
+	}{
+		{
+			name: "Success Get By Order ID",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByMerchantAndOrderID", mock.Anything, merchantID, true, "ORDER-TEST-123").Return(mockTransaction, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Get By Order ID - Not Found",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByMerchantAndOrderID", mock.Anything, merchantID, true, "ORDER-TEST-123").Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Failed Get By Order ID - Another Merchant",
+			merchantID: otherMerchantID,
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByMerchantAndOrderID", mock.Anything, otherMerchantID, true, "ORDER-TEST-123").Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTransactionRepository)
+			tt.mock(mockRepo)
+
+			transactionUC := usecase.NewTransactionUC(mockRepo, domain.Gateways{}, time.Second*2)
+
+			callerID := merchantID
+			if tt.merchantID != uuid.Nil {
+				callerID = tt.merchantID
+			}
+
+			res, err := transactionUC.GetByOrderID(context.Background(), callerID, true, "ORDER-TEST-123")
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, mockTransaction.ID, res.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
 	}
+}
 
-	req := &domain.UpdateStatusRequest{
-		OrderID: orderID,
-		Status:  "PAID",
+func TestTransactionUsecase_GetByExternalID(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	otherMerchantID := pkg.GenerateUUIDV7()
+	mockTransaction := &domain.Transaction{
+		ID:         pkg.GenerateUUIDV7(),
+		MerchantID: merchantID,
+		OrderID:    "ORDER-TEST-123",
+		ExternalID: "inv-123",
+		Amount:     100000,
+		Currency:   "IDR",
+		Status:     domain.TransactionStatusPending,
 	}
 
 	tests := []struct {
-		name    string
-		mock    func(repo *mocks.MockTransactionRepository)
-		wantErr bool
+		name       string
+		merchantID uuid.UUID
+		mock       func(repo *mocks.MockTransactionRepository)
+		wantErr    bool
 	}{
 		{
-			name: "Success Handle Notification",
+			name: "Success Get By External ID",
 			mock: func(repo *mocks.MockTransactionRepository) {
-				repo.On("FindByOrderID", mock.Anything, orderID).
-					Return(mockTransaction, nil)
+				repo.On("FindByMerchantAndExternalID", mock.Anything, merchantID, true, "inv-123").Return(mockTransaction, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Get By External ID - Not Found",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByMerchantAndExternalID", mock.Anything, merchantID, true, "inv-123").Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Failed Get By External ID - Another Merchant",
+			merchantID: otherMerchantID,
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByMerchantAndExternalID", mock.Anything, otherMerchantID, true, "inv-123").Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+	}
 
-				repo.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
-					return tx.OrderID == orderID && tx.Status == domain.TransactionStatusPaid
-				})).Return(mockTransaction, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTransactionRepository)
+			tt.mock(mockRepo)
+
+			transactionUC := usecase.NewTransactionUC(mockRepo, domain.Gateways{}, time.Second*2)
+
+			callerID := merchantID
+			if tt.merchantID != uuid.Nil {
+				callerID = tt.merchantID
+			}
+
+			res, err := transactionUC.GetByExternalID(context.Background(), callerID, true, "inv-123")
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, mockTransaction.ID, res.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionUsecase_HandleNotification(t *testing.T) {
+	transactionID := pkg.GenerateUUIDV7()
+	providerOrderID := transactionID.String()
+
+	newTransaction := func(status domain.TransactionStatus) *domain.Transaction {
+		return &domain.Transaction{
+			ID:              transactionID,
+			OrderID:         "ORDER-TEST-123",
+			ProviderOrderID: providerOrderID,
+			Amount:          100000,
+			Status:          status,
+			Livemode:        true,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		status   string
+		testmode bool
+		mock     func(repo *mocks.MockTransactionRepository)
+		wantErr  bool
+		errIs    error
+	}{
+		{
+			name:   "Success Handle Notification",
+			status: "PAID",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusPaid, matchStatusEvent(domain.TransactionStatusPaid)).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Duplicate Notification Is Ignored",
+			status: "PAID",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusPaid), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Provider Echo Of Cancellation Is Ignored",
+			status: "EXPIRED",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusCancelled), nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "Transaction Not Found",
+			name:   "Late Pending Notification Rejected",
+			status: "PENDING",
 			mock: func(repo *mocks.MockTransactionRepository) {
-				repo.On("FindByOrderID", mock.Anything, orderID).
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusPaid), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "Failed Transaction Cannot Be Paid",
+			status: "PAID",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusFailed), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "Transaction Not Found",
+			status: "PAID",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
 					Return(nil, errors.New("transaction not found"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "Failed to Update Transaction",
+			name:     "Test Mode Notification For Live Transaction",
+			status:   "PAID",
+			testmode: true,
 			mock: func(repo *mocks.MockTransactionRepository) {
-				repo.On("FindByOrderID", mock.Anything, orderID).
-					Return(mockTransaction, nil)
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrTransactionNotFound,
+		},
+		{
+			name:   "Concurrent Status Change",
+			status: "PAID",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
 
-				repo.On("Update", mock.Anything, mock.Anything).
-					Return(nil, errors.New("database error"))
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusPaid, matchStatusEvent(domain.TransactionStatusPaid)).
+					Return(domain.ErrStatusConflict)
+			},
+			wantErr: true,
+			errIs:   domain.ErrStatusConflict,
+		},
+		{
+			name:   "Failed to Update Transaction",
+			status: "FAILED",
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("FindByProviderOrderID", mock.Anything, providerOrderID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusFailed, matchStatusEvent(domain.TransactionStatusFailed)).
+					Return(errors.New("database error"))
 			},
 			wantErr: true,
 		},
@@ -300,17 +589,26 @@ func TestTransactionUsecase_HandleNotification(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			gateways := map[string]domain.PaymentGateway{
-				"midtrans": mockGateway,
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": mockGateway,
+				},
 			}
 
 			transactionUC := usecase.NewTransactionUC(mockRepo, gateways, time.Second*2)
 
 			ctx := context.Background()
-			err := transactionUC.HandleNotification(ctx, req)
+			err := transactionUC.HandleNotification(ctx, &domain.UpdateStatusRequest{
+				OrderID:  providerOrderID,
+				Status:   tt.status,
+				Livemode: !tt.testmode,
+			})
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
 			} else {
 				assert.NoError(t, err)
 			}
@@ -319,3 +617,504 @@ func TestTransactionUsecase_HandleNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionUsecase_Cancel(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	otherMerchantID := pkg.GenerateUUIDV7()
+	transactionID := pkg.GenerateUUIDV7()
+
+	newTransaction := func(status domain.TransactionStatus) *domain.Transaction {
+		return &domain.Transaction{
+			ID:              transactionID,
+			MerchantID:      merchantID,
+			OrderID:         "ORDER-TEST-123",
+			ProviderOrderID: transactionID.String(),
+			ExternalID:      "ext-12345",
+			Amount:          100000,
+			Provider:        "midtrans",
+			Status:          status,
+			Livemode:        true,
+		}
+	}
+
+	cancelRequest := &domain.CancelPaymentRequest{
+		OrderID:    transactionID.String(),
+		ExternalID: "ext-12345",
+	}
+
+	tests := []struct {
+		name       string
+		merchantID uuid.UUID
+		mock       func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway)
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name: "Success Cancel Transaction",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+
+				gateway.On("Cancel", mock.Anything, cancelRequest).
+					Return(nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusCancelled, matchStatusEvent(domain.TransactionStatusCancelled)).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Already Cancelled Transaction",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusCancelled), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Transaction Already Paid",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPaid), nil)
+			},
+			wantErr: true,
+			errIs:   domain.ErrTransactionNotCancellable,
+		},
+		{
+			name:       "Failed Transaction Of Another Merchant",
+			merchantID: otherMerchantID,
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, otherMerchantID, transactionID).
+					Return(nil, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+			errIs:   domain.ErrTransactionNotFound,
+		},
+		{
+			name: "Failed Payment Gateway",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+
+				gateway.On("Cancel", mock.Anything, cancelRequest).
+					Return(errors.New("gateway error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Payment Not Started At Provider",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+
+				gateway.On("Cancel", mock.Anything, cancelRequest).
+					Return(domain.ErrPaymentNotStarted)
+			},
+			wantErr: true,
+			errIs:   domain.ErrPaymentNotStarted,
+		},
+		{
+			name: "Failed Paid While Cancelling",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, transactionID).
+					Return(newTransaction(domain.TransactionStatusPending), nil)
+
+				gateway.On("Cancel", mock.Anything, cancelRequest).
+					Return(nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusCancelled, matchStatusEvent(domain.TransactionStatusCancelled)).
+					Return(domain.ErrStatusConflict)
+			},
+			wantErr: true,
+			errIs:   domain.ErrStatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTransactionRepository)
+			mockGateway := new(mocks.MockPaymentGateway)
+
+			tt.mock(mockRepo, mockGateway)
+
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": mockGateway,
+				},
+			}
+
+			transactionUC := usecase.NewTransactionUC(mockRepo, gateways, time.Second*2)
+
+			callerID := merchantID
+			if tt.merchantID != uuid.Nil {
+				callerID = tt.merchantID
+			}
+
+			ctx := context.Background()
+			res, err := transactionUC.Cancel(ctx, callerID, true, transactionID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.TransactionStatusCancelled, res.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockGateway.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionUsecase_ExpirePending(t *testing.T) {
+	transactionID := pkg.GenerateUUIDV7()
+
+	expiredTransaction := func() *domain.Transaction {
+		return &domain.Transaction{
+			ID:              transactionID,
+			OrderID:         "ORDER-TEST-123",
+			ProviderOrderID: transactionID.String(),
+			ExternalID:      "ext-12345",
+			Amount:          100000,
+			Provider:        "midtrans",
+			Status:          domain.TransactionStatusPending,
+			Livemode:        true,
+			ExpiredAt:       time.Now().Add(-time.Minute),
+		}
+	}
+
+	checkRequest := &domain.CheckStatusRequest{
+		OrderID:    transactionID.String(),
+		ExternalID: "ext-12345",
+	}
+
+	tests := []struct {
+		name         string
+		mock         func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway)
+		wantResolved int
+		wantErr      bool
+	}{
+		{
+			name: "Provider Reports Expired",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("EXPIRED", nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusExpired, matchStatusEvent(domain.TransactionStatusExpired)).
+					Return(nil)
+			},
+			wantResolved: 1,
+		},
+		{
+			name: "Provider Still Open Is Closed First",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PENDING", nil)
+
+				gateway.On("Cancel", mock.Anything, &domain.CancelPaymentRequest{
+					OrderID:    transactionID.String(),
+					ExternalID: "ext-12345",
+				}).Return(nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusExpired, matchStatusEvent(domain.TransactionStatusExpired)).
+					Return(nil)
+			},
+			wantResolved: 1,
+		},
+		{
+			name: "Provider Refuses To Close Leaves It Pending",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PENDING", nil)
+
+				gateway.On("Cancel", mock.Anything, mock.AnythingOfType("*domain.CancelPaymentRequest")).
+					Return(errors.New("session is complete"))
+			},
+			wantResolved: 0,
+			wantErr:      true,
+		},
+		{
+			name: "Payment Never Created Expires Without The Provider",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				orphan := expiredTransaction()
+				orphan.ExternalID = ""
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{orphan}, nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusExpired, matchStatusEvent(domain.TransactionStatusExpired)).
+					Return(nil)
+			},
+			wantResolved: 1,
+		},
+		{
+			name: "Provider Reports Paid",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("PAID", nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusPaid, matchStatusEvent(domain.TransactionStatusPaid)).
+					Return(nil)
+			},
+			wantResolved: 1,
+		},
+		{
+			name: "Provider Has No Payment On Record",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("", domain.ErrPaymentNotFound)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusExpired, matchStatusEvent(domain.TransactionStatusExpired)).
+					Return(nil)
+			},
+			wantResolved: 1,
+		},
+		{
+			name: "Webhook Resolved It First",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("EXPIRED", nil)
+
+				repo.On("UpdateStatus", mock.Anything, transactionID, domain.TransactionStatusPending, domain.TransactionStatusExpired, matchStatusEvent(domain.TransactionStatusExpired)).
+					Return(domain.ErrStatusConflict)
+			},
+			wantResolved: 1,
+		},
+		{
+			name: "Failed Payment Gateway",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return([]*domain.Transaction{expiredTransaction()}, nil)
+
+				gateway.On("CheckStatus", mock.Anything, checkRequest).
+					Return("", domain.ErrGatewayTimeout)
+			},
+			wantResolved: 0,
+			wantErr:      true,
+		},
+		{
+			name: "Failed Repository Find",
+			mock: func(repo *mocks.MockTransactionRepository, gateway *mocks.MockPaymentGateway) {
+				repo.On("FindExpiredPending", mock.Anything, mock.AnythingOfType("time.Time"), 100).
+					Return(nil, errors.New("database error"))
+			},
+			wantResolved: 0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTransactionRepository)
+			mockGateway := new(mocks.MockPaymentGateway)
+
+			tt.mock(mockRepo, mockGateway)
+
+			gateways := domain.Gateways{
+				Live: map[string]domain.PaymentGateway{
+					"midtrans": mockGateway,
+				},
+			}
+
+			transactionUC := usecase.NewTransactionUC(mockRepo, gateways, time.Second*2)
+
+			ctx := context.Background()
+			resolved, err := transactionUC.ExpirePending(ctx, 100)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantResolved, resolved)
+
+			mockRepo.AssertExpectations(t)
+			mockGateway.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionUsecase_List(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+
+	newTransactions := func(n int) []*domain.Transaction {
+		transactions := make([]*domain.Transaction, 0, n)
+		for i := 0; i < n; i++ {
+			transactions = append(transactions, &domain.Transaction{
+				ID:         pkg.GenerateUUIDV7(),
+				MerchantID: merchantID,
+				Amount:     int64(1000 * (i + 1)),
+				Status:     domain.TransactionStatusPaid,
+			})
+		}
+		return transactions
+	}
+
+	cursorID := pkg.GenerateUUIDV7()
+
+	tests := []struct {
+		name           string
+		request        *domain.ListTransactionsRequest
+		mock           func(repo *mocks.MockTransactionRepository)
+		wantCount      int
+		wantNextCursor bool
+		wantErr        bool
+		errIs          error
+	}{
+		{
+			name:    "Default Page Newest First",
+			request: &domain.ListTransactionsRequest{},
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("List", mock.Anything, &domain.TransactionFilter{
+					MerchantID: merchantID,
+					Livemode:   true,
+					SortBy:     domain.TransactionSortCreatedAt,
+					Descending: true,
+					Limit:      domain.DefaultTransactionPageSize + 1,
+				}).Return(newTransactions(3), nil)
+			},
+			wantCount: 3,
+		},
+		{
+			name:    "Full Page Returns Next Cursor",
+			request: &domain.ListTransactionsRequest{Limit: 2, Status: "PAID", Provider: "xendit"},
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("List", mock.Anything, mock.MatchedBy(func(f *domain.TransactionFilter) bool {
+					return f.Limit == 3 && f.Status == domain.TransactionStatusPaid && f.Provider == "xendit"
+				})).Return(newTransactions(3), nil)
+			},
+			wantCount:      2,
+			wantNextCursor: true,
+		},
+		{
+			name: "Cursor Continues Amount Sort",
+			request: &domain.ListTransactionsRequest{
+				SortBy:    "amount",
+				SortOrder: "asc",
+				Cursor: pkg.EncodeCursor(domain.TransactionCursor{
+					SortBy: domain.TransactionSortAmount,
+					ID:     cursorID,
+					Amount: 5000,
+				}),
+			},
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("List", mock.Anything, mock.MatchedBy(func(f *domain.TransactionFilter) bool {
+					return f.SortBy == domain.TransactionSortAmount && !f.Descending &&
+						f.After != nil && f.After.ID == cursorID && f.After.Amount == 5000
+				})).Return(newTransactions(1), nil)
+			},
+			wantCount: 1,
+		},
+		{
+			name: "Cursor From Another Sort Rejected",
+			request: &domain.ListTransactionsRequest{
+				SortBy: "amount",
+				Cursor: pkg.EncodeCursor(domain.TransactionCursor{
+					SortBy:     domain.TransactionSortCreatedAt,
+					Descending: true,
+					ID:         cursorID,
+				}),
+			},
+			mock:    func(repo *mocks.MockTransactionRepository) {},
+			wantErr: true,
+			errIs:   domain.ErrInvalidCursor,
+		},
+		{
+			name:    "Malformed Cursor Rejected",
+			request: &domain.ListTransactionsRequest{Cursor: "not-a-cursor"},
+			mock:    func(repo *mocks.MockTransactionRepository) {},
+			wantErr: true,
+			errIs:   domain.ErrInvalidCursor,
+		},
+		{
+			name:    "Unknown Status Rejected",
+			request: &domain.ListTransactionsRequest{Status: "SETTLED"},
+			mock:    func(repo *mocks.MockTransactionRepository) {},
+			wantErr: true,
+			errIs:   domain.ErrInvalidListFilter,
+		},
+		{
+			name:    "Inverted Amount Range Rejected",
+			request: &domain.ListTransactionsRequest{MinAmount: 5000, MaxAmount: 1000},
+			mock:    func(repo *mocks.MockTransactionRepository) {},
+			wantErr: true,
+			errIs:   domain.ErrInvalidListFilter,
+		},
+		{
+			name:    "Failed Repository List",
+			request: &domain.ListTransactionsRequest{},
+			mock: func(repo *mocks.MockTransactionRepository) {
+				repo.On("List", mock.Anything, mock.AnythingOfType("*domain.TransactionFilter")).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTransactionRepository)
+
+			tt.mock(mockRepo)
+
+			transactionUC := usecase.NewTransactionUC(mockRepo, domain.Gateways{}, time.Second*2)
+
+			ctx := context.Background()
+			page, err := transactionUC.List(ctx, merchantID, true, tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, page)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, page.Transactions, tt.wantCount)
+				assert.Equal(t, tt.wantNextCursor, page.NextCursor != "")
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// matchStatusEvent matches the outbox event that announces the transaction moving to status
+func matchStatusEvent(status domain.TransactionStatus) interface{} {
+	return mock.MatchedBy(func(event *domain.OutboxEvent) bool {
+		var payload domain.WebhookPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return false
+		}
+		return event.EventType == domain.TransactionStatusEvent(status) &&
+			payload.Event == event.EventType &&
+			payload.EventID == "evt_"+event.ID.String() &&
+			payload.Status == string(status) &&
+			payload.OrderID == "ORDER-TEST-123" &&
+			payload.Data != nil &&
+			payload.Data.Transaction.Status == string(status) &&
+			payload.Data.Refund == nil &&
+			payload.CallbackURL == ""
+	})
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryUC struct {
+	deliveryRepo     domain.WebhookDeliveryRepository
+	endpointRepo     domain.WebhookEndpointRepository
+	merchantRepo     domain.MerchantRepository
+	webhookPublisher domain.WebhookPublisher
+	timeout          time.Duration
+}
+
+func NewWebhookDeliveryUC(r domain.WebhookDeliveryRepository, er domain.WebhookEndpointRepository, mr domain.MerchantRepository, p domain.WebhookPublisher, t time.Duration) domain.WebhookDeliveryUC {
+	return &WebhookDeliveryUC{
+		deliveryRepo:     r,
+		endpointRepo:     er,
+		merchantRepo:     mr,
+		webhookPublisher: p,
+		timeout:          t,
+	}
+}
+
+func (u *WebhookDeliveryUC) List(ctx context.Context, merchantID uuid.UUID, req *domain.ListWebhookDeliveriesRequest) (*domain.WebhookDeliveryPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	filter, err := newWebhookDeliveryFilter(merchantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := filter.Limit
+	// one extra row tells us whether another page exists
+	filter.Limit = pageSize + 1
+
+	deliveries, err := u.deliveryRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.WebhookDeliveryPage{
+		Deliveries: deliveries,
+	}
+
+	if len(deliveries) > pageSize {
+		page.Deliveries = deliveries[:pageSize]
+		page.NextCursor = pkg.EncodeCursor(domain.WebhookDeliveryCursor{
+			ID: page.Deliveries[pageSize-1].ID,
+		})
+	}
+
+	return page, nil
+}
+
+func newWebhookDeliveryFilter(merchantID uuid.UUID, req *domain.ListWebhookDeliveriesRequest) (*domain.WebhookDeliveryFilter, error) {
+	filter := &domain.WebhookDeliveryFilter{
+		MerchantID: merchantID,
+		Limit:      req.Limit,
+	}
+
+	if req.TransactionID != "" {
+		transactionID, err := uuid.Parse(req.TransactionID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid transaction_id", domain.ErrInvalidWebhookDeliveryQuery)
+		}
+		filter.TransactionID = transactionID
+	}
+
+	if req.EndpointID != "" {
+		endpointID, err := uuid.Parse(req.EndpointID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid endpoint_id", domain.ErrInvalidWebhookDeliveryQuery)
+		}
+		filter.EndpointID = endpointID
+	}
+
+	switch req.Status {
+	case "":
+	case domain.WebhookDeliveryStatusSucceeded, domain.WebhookDeliveryStatusFailed:
+		succeeded := req.Status == domain.WebhookDeliveryStatusSucceeded
+		filter.Succeeded = &succeeded
+	default:
+		return nil, fmt.Errorf("%w: status must be succeeded or failed", domain.ErrInvalidWebhookDeliveryQuery)
+	}
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = domain.DefaultWebhookDeliveryPageSize
+	case filter.Limit > domain.MaxWebhookDeliveryPageSize:
+		filter.Limit = domain.MaxWebhookDeliveryPageSize
+	}
+
+	if req.Cursor != "" {
+		var cursor domain.WebhookDeliveryCursor
+		if err := pkg.DecodeCursor(req.Cursor, &cursor); err != nil || cursor.ID == uuid.Nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+func (u *WebhookDeliveryUC) Redeliver(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	delivery, err := u.deliveryRepo.GetByMerchant(ctx, merchantID, id)
+	if err != nil {
+		return err
+	}
+
+	// a merchant usually asks for a redelivery after fixing their endpoint, so the endpoint's
+	// current URL wins over the one the original attempt went to
+	payload := *delivery.Payload
+	callbackURL, err := u.currentURL(ctx, merchantID, delivery.EndpointID)
+	if err != nil {
+		return err
+	}
+	if callbackURL != "" {
+		payload.CallbackURL = callbackURL
+	}
+	payload.RetryCount = 0
+	payload.LastError = ""
+
+	return u.webhookPublisher.Publish(ctx, &payload)
+}
+
+// currentURL resolves where a delivery goes today, the profile's callback_url when it was not
+// made to a registered endpoint
+func (u *WebhookDeliveryUC) currentURL(ctx context.Context, merchantID uuid.UUID, endpointID uuid.UUID) (string, error) {
+	if endpointID == uuid.Nil {
+		merchant, err := u.merchantRepo.FindByID(ctx, merchantID)
+		if err != nil {
+			return "", err
+		}
+		return merchant.CallbackURL, nil
+	}
+
+	endpoint, err := u.endpointRepo.GetByMerchant(ctx, merchantID, endpointID)
+	if err != nil {
+		return "", err
+	}
+	return endpoint.URL, nil
+}
@@ -0,0 +1,230 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookDeliveryUsecase_List(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	transactionID := pkg.GenerateUUIDV7()
+	endpointID := pkg.GenerateUUIDV7()
+
+	deliveries := []*domain.WebhookDelivery{
+		{ID: pkg.GenerateUUIDV7(), MerchantID: merchantID},
+		{ID: pkg.GenerateUUIDV7(), MerchantID: merchantID},
+		{ID: pkg.GenerateUUIDV7(), MerchantID: merchantID},
+	}
+
+	tests := []struct {
+		name           string
+		req            *domain.ListWebhookDeliveriesRequest
+		mock           func(repo *mocks.MockWebhookDeliveryRepository)
+		wantCount      int
+		wantNextCursor bool
+		wantErr        error
+	}{
+		{
+			name: "Success Default Filter",
+			req:  &domain.ListWebhookDeliveriesRequest{},
+			mock: func(repo *mocks.MockWebhookDeliveryRepository) {
+				repo.On("List", mock.Anything, mock.MatchedBy(func(f *domain.WebhookDeliveryFilter) bool {
+					return f.MerchantID == merchantID && f.Limit == domain.DefaultWebhookDeliveryPageSize+1 &&
+						f.Succeeded == nil && f.After == nil
+				})).Return(deliveries, nil)
+			},
+			wantCount: 3,
+		},
+		{
+			name: "Success Failed Deliveries Of A Transaction With Next Page",
+			req: &domain.ListWebhookDeliveriesRequest{
+				TransactionID: transactionID.String(),
+				Status:        domain.WebhookDeliveryStatusFailed,
+				Limit:         2,
+			},
+			mock: func(repo *mocks.MockWebhookDeliveryRepository) {
+				repo.On("List", mock.Anything, mock.MatchedBy(func(f *domain.WebhookDeliveryFilter) bool {
+					return f.TransactionID == transactionID && f.Succeeded != nil && !*f.Succeeded && f.Limit == 3
+				})).Return(deliveries, nil)
+			},
+			wantCount:      2,
+			wantNextCursor: true,
+		},
+		{
+			name: "Success Deliveries Of An Endpoint",
+			req:  &domain.ListWebhookDeliveriesRequest{EndpointID: endpointID.String()},
+			mock: func(repo *mocks.MockWebhookDeliveryRepository) {
+				repo.On("List", mock.Anything, mock.MatchedBy(func(f *domain.WebhookDeliveryFilter) bool {
+					return f.EndpointID == endpointID && f.TransactionID == uuid.Nil
+				})).Return(deliveries, nil)
+			},
+			wantCount: 3,
+		},
+		{
+			name:    "Failed Unknown Status",
+			req:     &domain.ListWebhookDeliveriesRequest{Status: "pending"},
+			mock:    func(repo *mocks.MockWebhookDeliveryRepository) {},
+			wantErr: domain.ErrInvalidWebhookDeliveryQuery,
+		},
+		{
+			name:    "Failed Invalid Transaction ID",
+			req:     &domain.ListWebhookDeliveriesRequest{TransactionID: "not-a-uuid"},
+			mock:    func(repo *mocks.MockWebhookDeliveryRepository) {},
+			wantErr: domain.ErrInvalidWebhookDeliveryQuery,
+		},
+		{
+			name:    "Failed Malformed Cursor",
+			req:     &domain.ListWebhookDeliveriesRequest{Cursor: "!!!"},
+			mock:    func(repo *mocks.MockWebhookDeliveryRepository) {},
+			wantErr: domain.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockWebhookDeliveryRepository)
+			mockEndpointRepo := new(mocks.MockWebhookEndpointRepository)
+			mockMerchantRepo := new(mocks.MockMerchantRepository)
+			mockPublisher := new(mocks.MockWebhookPublisher)
+
+			tt.mock(mockRepo)
+
+			deliveryUC := usecase.NewWebhookDeliveryUC(mockRepo, mockEndpointRepo, mockMerchantRepo, mockPublisher, time.Second*2)
+
+			page, err := deliveryUC.List(context.Background(), merchantID, tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, page.Deliveries, tt.wantCount)
+				assert.Equal(t, tt.wantNextCursor, page.NextCursor != "")
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookDeliveryUsecase_Redeliver(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	deliveryID := pkg.GenerateUUIDV7()
+	endpointID := pkg.GenerateUUIDV7()
+
+	delivery := &domain.WebhookDelivery{
+		ID:         deliveryID,
+		MerchantID: merchantID,
+		Payload: &domain.WebhookPayload{
+			TransactionID: pkg.GenerateUUIDV7().String(),
+			MerchantID:    merchantID.String(),
+			OrderID:       "ORDER-TEST-123",
+			Status:        "PAID",
+			CallbackURL:   "https://old.example.com/callback",
+			RetryCount:    5,
+			LastError:     "merchant responded 500",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockWebhookDeliveryRepository, endpointRepo *mocks.MockWebhookEndpointRepository, merchantRepo *mocks.MockMerchantRepository, publisher *mocks.MockWebhookPublisher)
+		wantErr error
+	}{
+		{
+			name: "Success Redeliver To Current Callback URL",
+			mock: func(repo *mocks.MockWebhookDeliveryRepository, endpointRepo *mocks.MockWebhookEndpointRepository, merchantRepo *mocks.MockMerchantRepository, publisher *mocks.MockWebhookPublisher) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, deliveryID).Return(delivery, nil)
+				merchantRepo.On("FindByID", mock.Anything, merchantID).Return(&domain.Merchant{
+					ID:          merchantID,
+					CallbackURL: "https://new.example.com/callback",
+				}, nil)
+				publisher.On("Publish", mock.Anything, mock.MatchedBy(func(p *domain.WebhookPayload) bool {
+					return p.CallbackURL == "https://new.example.com/callback" && p.RetryCount == 0 &&
+						p.LastError == "" && p.OrderID == "ORDER-TEST-123"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Success Redeliver To Current Endpoint URL",
+			mock: func(repo *mocks.MockWebhookDeliveryRepository, endpointRepo *mocks.MockWebhookEndpointRepository, merchantRepo *mocks.MockMerchantRepository, publisher *mocks.MockWebhookPublisher) {
+				endpointDelivery := *delivery
+				endpointDelivery.EndpointID = endpointID
+				repo.On("GetByMerchant", mock.Anything, merchantID, deliveryID).Return(&endpointDelivery, nil)
+				endpointRepo.On("GetByMerchant", mock.Anything, merchantID, endpointID).Return(&domain.WebhookEndpoint{
+					ID:  endpointID,
+					URL: "https://hooks.example.com/payments",
+				}, nil)
+				publisher.On("Publish", mock.Anything, mock.MatchedBy(func(p *domain.WebhookPayload) bool {
+					return p.CallbackURL == "https://hooks.example.com/payments" && p.RetryCount == 0
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Failed Endpoint Deleted",
+			mock: func(repo *mocks.MockWebhookDeliveryRepository, endpointRepo *mocks.MockWebhookEndpointRepository, merchantRepo *mocks.MockMerchantRepository, publisher *mocks.MockWebhookPublisher) {
+				endpointDelivery := *delivery
+				endpointDelivery.EndpointID = endpointID
+				repo.On("GetByMerchant", mock.Anything, merchantID, deliveryID).Return(&endpointDelivery, nil)
+				endpointRepo.On("GetByMerchant", mock.Anything, merchantID, endpointID).Return(nil, domain.ErrWebhookEndpointNotFound)
+			},
+			wantErr: domain.ErrWebhookEndpointNotFound,
+		},
+		{
+			name: "Failed Delivery Not Found",
+			mock: func(repo *mocks.MockWebhookDeliveryRepository, endpointRepo *mocks.MockWebhookEndpointRepository, merchantRepo *mocks.MockMerchantRepository, publisher *mocks.MockWebhookPublisher) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, deliveryID).Return(nil, domain.ErrWebhookDeliveryNotFound)
+			},
+			wantErr: domain.ErrWebhookDeliveryNotFound,
+		},
+		{
+			name: "Failed Publish",
+			mock: func(repo *mocks.MockWebhookDeliveryRepository, endpointRepo *mocks.MockWebhookEndpointRepository, merchantRepo *mocks.MockMerchantRepository, publisher *mocks.MockWebhookPublisher) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, deliveryID).Return(delivery, nil)
+				merchantRepo.On("FindByID", mock.Anything, merchantID).Return(&domain.Merchant{ID: merchantID}, nil)
+				publisher.On("Publish", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+			},
+			wantErr: errors.New("redis down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockWebhookDeliveryRepository)
+			mockEndpointRepo := new(mocks.MockWebhookEndpointRepository)
+			mockMerchantRepo := new(mocks.MockMerchantRepository)
+			mockPublisher := new(mocks.MockWebhookPublisher)
+
+			tt.mock(mockRepo, mockEndpointRepo, mockMerchantRepo, mockPublisher)
+
+			deliveryUC := usecase.NewWebhookDeliveryUC(mockRepo, mockEndpointRepo, mockMerchantRepo, mockPublisher, time.Second*2)
+
+			err := deliveryUC.Redeliver(context.Background(), merchantID, deliveryID)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// the stored attempt is never modified by a redelivery
+			assert.Equal(t, 5, delivery.Payload.RetryCount)
+
+			mockRepo.AssertExpectations(t)
+			mockEndpointRepo.AssertExpectations(t)
+			mockMerchantRepo.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+		})
+	}
+}
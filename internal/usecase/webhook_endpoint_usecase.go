@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/pkg"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEndpointUC struct {
+	endpointRepo domain.WebhookEndpointRepository
+	timeout      time.Duration
+}
+
+func NewWebhookEndpointUC(r domain.WebhookEndpointRepository, t time.Duration) domain.WebhookEndpointUC {
+	return &WebhookEndpointUC{
+		endpointRepo: r,
+		timeout:      t,
+	}
+}
+
+func (u *WebhookEndpointUC) Create(ctx context.Context, merchantID uuid.UUID, req *domain.CreateWebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if err := validateWebhookEndpoint(req.URL, req.Events); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.endpointRepo.ListByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	// every endpoint is a delivery per event, so the fan-out is kept bounded
+	if len(existing) >= domain.MaxWebhookEndpointsPerMerchant {
+		return nil, domain.ErrWebhookEndpointLimitExceeded
+	}
+
+	endpoint := &domain.WebhookEndpoint{
+		ID:          pkg.GenerateUUIDV7(),
+		MerchantID:  merchantID,
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+		Enabled:     req.Enabled == nil || *req.Enabled,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := u.endpointRepo.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (u *WebhookEndpointUC) List(ctx context.Context, merchantID uuid.UUID) ([]*domain.WebhookEndpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.endpointRepo.ListByMerchant(ctx, merchantID)
+}
+
+func (u *WebhookEndpointUC) Get(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.endpointRepo.GetByMerchant(ctx, merchantID, id)
+}
+
+func (u *WebhookEndpointUC) Update(ctx context.Context, merchantID uuid.UUID, id uuid.UUID, req *domain.UpdateWebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	endpoint, err := u.endpointRepo.GetByMerchant(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		endpoint.URL = *req.URL
+	}
+	if req.Description != nil {
+		endpoint.Description = *req.Description
+	}
+	if req.Events != nil {
+		endpoint.Events = req.Events
+	}
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+
+	if err := validateWebhookEndpoint(endpoint.URL, endpoint.Events); err != nil {
+		return nil, err
+	}
+
+	endpoint.UpdatedAt = time.Now()
+	if err := u.endpointRepo.Update(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (u *WebhookEndpointUC) Delete(ctx context.Context, merchantID uuid.UUID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.endpointRepo.Delete(ctx, merchantID, id)
+}
+
+func validateWebhookEndpoint(rawURL string, events []string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhookEndpoint)
+	}
+
+	if len(events) == 0 {
+		return fmt.Errorf("%w: subscribe to at least one event", domain.ErrInvalidWebhookEndpoint)
+	}
+	for _, event := range events {
+		if !domain.IsValidWebhookEvent(event) {
+			return fmt.Errorf("%w: unknown event %q", domain.ErrInvalidWebhookEndpoint, event)
+		}
+	}
+
+	return nil
+}
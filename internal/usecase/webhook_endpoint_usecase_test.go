@@ -0,0 +1,199 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/internal/domain"
+	"go-payment-aggregator/internal/mocks"
+	"go-payment-aggregator/internal/pkg"
+	"go-payment-aggregator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookEndpointUsecase_Create(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	disabled := false
+
+	fullList := make([]*domain.WebhookEndpoint, domain.MaxWebhookEndpointsPerMerchant)
+
+	tests := []struct {
+		name        string
+		req         *domain.CreateWebhookEndpointRequest
+		mock        func(repo *mocks.MockWebhookEndpointRepository)
+		wantEnabled bool
+		wantErr     error
+	}{
+		{
+			name: "Success Enabled By Default",
+			req: &domain.CreateWebhookEndpointRequest{
+				URL:    "https://hooks.example.com/payments",
+				Events: []string{domain.WebhookEventTransactionPaid, domain.WebhookEventRefundSucceeded},
+			},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("ListByMerchant", mock.Anything, merchantID).Return([]*domain.WebhookEndpoint{}, nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.WebhookEndpoint) bool {
+					return e.MerchantID == merchantID && e.Enabled && len(e.Events) == 2
+				})).Return(nil)
+			},
+			wantEnabled: true,
+		},
+		{
+			name: "Success Created Disabled With Wildcard",
+			req: &domain.CreateWebhookEndpointRequest{
+				URL:     "http://localhost:9000/hooks",
+				Events:  []string{domain.WebhookEventAll},
+				Enabled: &disabled,
+			},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("ListByMerchant", mock.Anything, merchantID).Return([]*domain.WebhookEndpoint{}, nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantEnabled: false,
+		},
+		{
+			name: "Failed Relative URL",
+			req: &domain.CreateWebhookEndpointRequest{
+				URL:    "/hooks",
+				Events: []string{domain.WebhookEventTransactionPaid},
+			},
+			mock:    func(repo *mocks.MockWebhookEndpointRepository) {},
+			wantErr: domain.ErrInvalidWebhookEndpoint,
+		},
+		{
+			name: "Failed No Events",
+			req: &domain.CreateWebhookEndpointRequest{
+				URL: "https://hooks.example.com/payments",
+			},
+			mock:    func(repo *mocks.MockWebhookEndpointRepository) {},
+			wantErr: domain.ErrInvalidWebhookEndpoint,
+		},
+		{
+			name: "Failed Unknown Event",
+			req: &domain.CreateWebhookEndpointRequest{
+				URL:    "https://hooks.example.com/payments",
+				Events: []string{"transaction.created"},
+			},
+			mock:    func(repo *mocks.MockWebhookEndpointRepository) {},
+			wantErr: domain.ErrInvalidWebhookEndpoint,
+		},
+		{
+			name: "Failed Limit Reached",
+			req: &domain.CreateWebhookEndpointRequest{
+				URL:    "https://hooks.example.com/payments",
+				Events: []string{domain.WebhookEventTransactionPaid},
+			},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("ListByMerchant", mock.Anything, merchantID).Return(fullList, nil)
+			},
+			wantErr: domain.ErrWebhookEndpointLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockWebhookEndpointRepository)
+			tt.mock(mockRepo)
+
+			endpointUC := usecase.NewWebhookEndpointUC(mockRepo, time.Second*2)
+
+			endpoint, err := endpointUC.Create(context.Background(), merchantID, tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, endpoint)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEnabled, endpoint.Enabled)
+				assert.Equal(t, tt.req.URL, endpoint.URL)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookEndpointUsecase_Update(t *testing.T) {
+	merchantID := pkg.GenerateUUIDV7()
+	endpointID := pkg.GenerateUUIDV7()
+
+	newEndpoint := func() *domain.WebhookEndpoint {
+		return &domain.WebhookEndpoint{
+			ID:         endpointID,
+			MerchantID: merchantID,
+			URL:        "https://hooks.example.com/payments",
+			Events:     []string{domain.WebhookEventTransactionPaid},
+			Enabled:    true,
+		}
+	}
+
+	newURL := "https://hooks.example.com/v2"
+	badURL := "ftp://hooks.example.com"
+	disabled := false
+
+	tests := []struct {
+		name    string
+		req     *domain.UpdateWebhookEndpointRequest
+		mock    func(repo *mocks.MockWebhookEndpointRepository)
+		wantErr error
+	}{
+		{
+			name: "Success Partial Update Keeps Events",
+			req:  &domain.UpdateWebhookEndpointRequest{URL: &newURL, Enabled: &disabled},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, endpointID).Return(newEndpoint(), nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.WebhookEndpoint) bool {
+					return e.URL == newURL && !e.Enabled && len(e.Events) == 1 &&
+						e.Events[0] == domain.WebhookEventTransactionPaid
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Failed Invalid URL",
+			req:  &domain.UpdateWebhookEndpointRequest{URL: &badURL},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, endpointID).Return(newEndpoint(), nil)
+			},
+			wantErr: domain.ErrInvalidWebhookEndpoint,
+		},
+		{
+			name: "Failed Unsubscribe From Everything",
+			req:  &domain.UpdateWebhookEndpointRequest{Events: []string{}},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, endpointID).Return(newEndpoint(), nil)
+			},
+			wantErr: domain.ErrInvalidWebhookEndpoint,
+		},
+		{
+			name: "Failed Not Found",
+			req:  &domain.UpdateWebhookEndpointRequest{Enabled: &disabled},
+			mock: func(repo *mocks.MockWebhookEndpointRepository) {
+				repo.On("GetByMerchant", mock.Anything, merchantID, endpointID).Return(nil, domain.ErrWebhookEndpointNotFound)
+			},
+			wantErr: domain.ErrWebhookEndpointNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockWebhookEndpointRepository)
+			tt.mock(mockRepo)
+
+			endpointUC := usecase.NewWebhookEndpointUC(mockRepo, time.Second*2)
+
+			endpoint, err := endpointUC.Update(context.Background(), merchantID, endpointID, tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, endpoint)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
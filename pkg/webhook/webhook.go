@@ -0,0 +1,98 @@
+// Package webhook verifies the callbacks the payment aggregator sends to merchants.
+//
+// Every callback carries an X-Timestamp header with the unix time it was sent at and an
+// X-Signature header with the hex encoded HMAC-SHA256 of "<timestamp>.<raw body>", keyed
+// with the merchant's webhook secret:
+//
+//	func handleCallback(w http.ResponseWriter, r *http.Request) {
+//		body, err := webhook.VerifyRequest(r, os.Getenv("WEBHOOK_SECRET"), webhook.DefaultTolerance)
+//		if err != nil {
+//			http.Error(w, "invalid signature", http.StatusUnauthorized)
+//			return
+//		}
+//		// body is authentic
+//	}
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Signature"
+	TimestampHeader = "X-Timestamp"
+
+	// DefaultTolerance is how old a callback may be before it is treated as a replay
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature or timestamp")
+	ErrInvalidTimestamp = errors.New("webhook: invalid timestamp")
+	ErrTimestampExpired = errors.New("webhook: timestamp outside the tolerance window")
+	ErrInvalidSignature = errors.New("webhook: signature does not match")
+)
+
+// Sign returns the X-Signature value for body sent at timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature and timestamp, taken from the X-Signature and X-Timestamp headers,
+// against the raw body. A tolerance of zero disables the replay check.
+func Verify(secret string, body []byte, signature string, timestamp string, tolerance time.Duration) error {
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	age := time.Since(time.Unix(unix, 0))
+	if tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return ErrTimestampExpired
+	}
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	expected, _ := hex.DecodeString(Sign(secret, unix, body))
+	if !hmac.Equal(expected, decoded) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyRequest verifies an incoming callback and returns its body. The request body is
+// restored, so it can still be decoded after verification.
+func VerifyRequest(r *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := Verify(secret, body, r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), tolerance); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
@@ -0,0 +1,112 @@
+package webhook_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-payment-aggregator/pkg/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	secret := "whsec_test_secret"
+	body := []byte(`{"transaction_id":"019b9836","status":"PAID"}`)
+	now := time.Now().Unix()
+	stale := time.Now().Add(-10 * time.Minute).Unix()
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		tolerance time.Duration
+		wantErr   error
+	}{
+		{
+			name:      "Valid Signature",
+			signature: webhook.Sign(secret, now, body),
+			timestamp: strconv.FormatInt(now, 10),
+			tolerance: webhook.DefaultTolerance,
+		},
+		{
+			name:      "Wrong Secret",
+			signature: webhook.Sign("whsec_other", now, body),
+			timestamp: strconv.FormatInt(now, 10),
+			tolerance: webhook.DefaultTolerance,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "Timestamp Not Covered By Signature",
+			signature: webhook.Sign(secret, now, body),
+			timestamp: strconv.FormatInt(now+1, 10),
+			tolerance: webhook.DefaultTolerance,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "Stale Timestamp",
+			signature: webhook.Sign(secret, stale, body),
+			timestamp: strconv.FormatInt(stale, 10),
+			tolerance: webhook.DefaultTolerance,
+			wantErr:   webhook.ErrTimestampExpired,
+		},
+		{
+			name:      "Stale Timestamp Without Tolerance",
+			signature: webhook.Sign(secret, stale, body),
+			timestamp: strconv.FormatInt(stale, 10),
+			tolerance: 0,
+		},
+		{
+			name:      "Malformed Timestamp",
+			signature: webhook.Sign(secret, now, body),
+			timestamp: "yesterday",
+			tolerance: webhook.DefaultTolerance,
+			wantErr:   webhook.ErrInvalidTimestamp,
+		},
+		{
+			name:      "Malformed Signature",
+			signature: "not-hex",
+			timestamp: strconv.FormatInt(now, 10),
+			tolerance: webhook.DefaultTolerance,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "Missing Signature",
+			timestamp: strconv.FormatInt(now, 10),
+			tolerance: webhook.DefaultTolerance,
+			wantErr:   webhook.ErrMissingSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(secret, body, tt.signature, tt.timestamp, tt.tolerance)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	secret := "whsec_test_secret"
+	body := []byte(`{"transaction_id":"019b9836","status":"PAID"}`)
+	now := time.Now().Unix()
+
+	req, _ := http.NewRequest(http.MethodPost, "https://merchant.example.com/callback", bytes.NewReader(body))
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(now, 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, now, body))
+
+	verified, err := webhook.VerifyRequest(req, secret, webhook.DefaultTolerance)
+	assert.NoError(t, err)
+	assert.Equal(t, body, verified)
+
+	// the body is still readable by the merchant's own handler
+	restored, _ := io.ReadAll(req.Body)
+	assert.Equal(t, body, restored)
+}